@@ -0,0 +1,45 @@
+// audit 校验审计日志的哈希链，发现被修改、删除或调换顺序的记录
+//
+// 用法:
+//
+//	go run ./cmd/audit                             # 校验 data/audit.jsonl，输出记录数和末尾哈希
+//	go run ./cmd/audit -head 3f5a...               # 同时检查末尾哈希，发现末尾记录被删除
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"xizexcample/internal/audit"
+)
+
+func main() {
+	path := flag.String("file", "data/audit.jsonl", "审计日志文件")
+	head := flag.String("head", "", "预期的末尾哈希，通常是之前校验时记下的值")
+	flag.Parse()
+
+	file, err := os.Open(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "open audit log: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	result, err := audit.Verify(file)
+	if err != nil {
+		var chainErr *audit.ChainError
+		if errors.As(err, &chainErr) {
+			fmt.Printf("TAMPERED %v\n", chainErr)
+		} else {
+			fmt.Fprintf(os.Stderr, "read audit log: %v\n", err)
+		}
+		fmt.Printf("%d entries verified before the failure\n", result.Entries)
+		os.Exit(1)
+	}
+	if *head != "" && *head != result.Head {
+		fmt.Printf("TAMPERED head hash is %s, expected %s: entries were removed from or added to the end\n", result.Head, *head)
+		os.Exit(1)
+	}
+	fmt.Printf("%d entries verified, last seq %d, head %s\n", result.Entries, result.LastSeq, result.Head)
+}
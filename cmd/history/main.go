@@ -0,0 +1,73 @@
+// history 按玩家ID或牌局ID查询牌局记录
+//
+// 用法:
+//
+//	go run ./cmd/history -player 10001
+//	go run ./cmd/history -round 100000-1760000000000000000 -json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"xizexcample/internal/history"
+	"xizexcample/internal/logic"
+)
+
+func main() {
+	dir := flag.String("dir", "data/history", "牌局记录目录")
+	playerID := flag.Int64("player", 0, "按玩家ID查询")
+	roundID := flag.String("round", "", "按牌局ID查询")
+	asJSON := flag.Bool("json", false, "以 JSON Lines 格式输出")
+	flag.Parse()
+
+	if *playerID == 0 && *roundID == "" {
+		fmt.Fprintln(os.Stderr, "either -player or -round is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	records, err := history.Query(*dir, history.Filter{PlayerID: *playerID, RoundID: *roundID})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "query hand history: %v\n", err)
+		os.Exit(1)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for _, record := range records {
+		if *asJSON {
+			encoder.Encode(record)
+			continue
+		}
+		printRecord(record)
+	}
+	if !*asJSON {
+		fmt.Printf("%d rounds found\n", len(records))
+	}
+}
+
+// printRecord 以便于阅读的格式输出一条牌局记录
+func printRecord(r history.Record) {
+	fmt.Printf("round %s  room %d  #%d  %s  base bet %d  banker %d  shuffle seed %d\n",
+		r.RoundID, r.RoomID, r.Round, r.SettledAt.Format("2006-01-02 15:04:05"), r.BaseBet, r.BankerID, r.ShuffleSeed)
+	for _, p := range r.Players {
+		role := "bet " + fmt.Sprint(p.BetMultiple)
+		if p.IsBanker {
+			role = "banker"
+		}
+		fmt.Printf("  seat %-2d player %-8d %-8s bid %d  [%s]  %s  change %+d  rake %d  final %d\n",
+			p.Seat, p.PlayerID, role, p.BidMultiple, formatHand(p.Hand), p.CardType, p.ScoreChange, p.Rake, p.FinalScore)
+	}
+	fmt.Println()
+}
+
+// formatHand 将手牌格式化为一行
+func formatHand(hand []logic.Card) string {
+	cards := make([]string, len(hand))
+	for i := range hand {
+		cards[i] = hand[i].String()
+	}
+	return strings.Join(cards, ", ")
+}
@@ -0,0 +1,89 @@
+// replay 复盘牌局记录，检查重新计算的结果是否与记录一致
+//
+// 用法:
+//
+//	go run ./cmd/replay -round 100000-1760000000000000000   # 输出这一局的事件流
+//	go run ./cmd/replay -verify                             # 复盘所有记录，列出不一致的牌局
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"xizexcample/internal/history"
+	"xizexcample/internal/replay"
+)
+
+func main() {
+	dir := flag.String("dir", "data/history", "牌局记录目录")
+	roundID := flag.String("round", "", "复盘指定的牌局，以 JSON Lines 格式输出事件流")
+	playerID := flag.Int64("player", 0, "与 -verify 一起使用，只复盘该玩家参与的牌局")
+	verify := flag.Bool("verify", false, "复盘所有牌局并报告与记录不一致的牌局")
+	flag.Parse()
+
+	if *roundID == "" && !*verify {
+		fmt.Fprintln(os.Stderr, "either -round or -verify is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	records, err := history.Query(*dir, history.Filter{PlayerID: *playerID, RoundID: *roundID})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "query hand history: %v\n", err)
+		os.Exit(1)
+	}
+	if len(records) == 0 {
+		fmt.Fprintln(os.Stderr, "no matching rounds found")
+		os.Exit(1)
+	}
+
+	if !*verify {
+		os.Exit(replayRound(records[0]))
+	}
+	os.Exit(verifyAll(records))
+}
+
+// replayRound 复盘一局并输出事件流，不一致项输出到标准错误，返回退出码
+func replayRound(record history.Record) int {
+	result, err := replay.Replay(record)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay round %s: %v\n", record.RoundID, err)
+		return 1
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	for _, event := range result.Events {
+		encoder.Encode(event)
+	}
+	for _, d := range result.Divergences {
+		fmt.Fprintf(os.Stderr, "DIVERGED %s\n", d)
+	}
+	if result.Diverged() {
+		return 1
+	}
+	return 0
+}
+
+// verifyAll 复盘所有牌局，返回退出码：全部一致为 0，否则为 1
+func verifyAll(records []history.Record) int {
+	failed := 0
+	for _, record := range records {
+		result, err := replay.Replay(record)
+		if err != nil {
+			failed++
+			fmt.Printf("ERROR    %s: %v\n", record.RoundID, err)
+			continue
+		}
+		if result.Diverged() {
+			failed++
+			for _, d := range result.Divergences {
+				fmt.Printf("DIVERGED %s: %s\n", record.RoundID, d)
+			}
+		}
+	}
+	fmt.Printf("%d rounds replayed, %d diverged\n", len(records), failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
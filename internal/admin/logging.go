@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"net/http"
+	"xizexcample/internal/pkg/logger"
+)
+
+// logLevelRequest 修改日志级别的请求
+type logLevelRequest struct {
+	Level string `json:"level"` // debug / info / warn / error
+}
+
+// getLogLevel 返回当前的日志级别
+func (s *Server) getLogLevel(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"level": logger.GetLevel()})
+}
+
+// setLogLevel 修改日志级别，立即生效，重启后恢复为配置文件中的级别
+func (s *Server) setLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req logLevelRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Level == "" {
+		writeError(w, http.StatusBadRequest, "level must not be empty")
+		return
+	}
+	if err := logger.SetLevel(req.Level); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	logger.L().Info("Admin changed log level", "level", logger.GetLevel())
+	writeJSON(w, http.StatusOK, map[string]string{"level": logger.GetLevel()})
+}
@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"xizexcample/internal/pkg/logger"
+)
+
+func TestAdminLogLevel(t *testing.T) {
+	h := newTestServer(t)
+	defer logger.SetLevel("info")
+
+	rec := doRequest(h, http.MethodPut, "/admin/log-level", `{"level":"debug"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if logger.GetLevel() != "debug" {
+		t.Errorf("Expected log level debug, got %s", logger.GetLevel())
+	}
+
+	rec = doRequest(h, http.MethodGet, "/admin/log-level", "")
+	var resp map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp["level"] != "debug" {
+		t.Errorf("Expected level debug, got %q", resp["level"])
+	}
+
+	for _, body := range []string{`{"level":"verbose"}`, `{}`} {
+		if rec := doRequest(h, http.MethodPut, "/admin/log-level", body); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, rec.Code)
+		}
+	}
+	if logger.GetLevel() != "debug" {
+		t.Errorf("Expected an invalid level to leave the level unchanged, got %s", logger.GetLevel())
+	}
+}
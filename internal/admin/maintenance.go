@@ -0,0 +1,56 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+	"xizexcample/internal/router"
+	"xizexcample/internal/server"
+)
+
+// maintenanceRequest 开启或关闭维护模式的请求
+type maintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"` // 推送给玩家的维护说明
+}
+
+// announcementRequest 发布系统公告的请求
+type announcementRequest struct {
+	Message string `json:"message"`
+}
+
+// getMaintenance 返回维护模式的当前状态
+func (s *Server) getMaintenance(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, server.GetRoomManager().GetMaintenance())
+}
+
+// setMaintenance 开启或关闭维护模式并通知所有连接；关闭时所有玩家都已准备好的房间立即开局
+func (s *Server) setMaintenance(w http.ResponseWriter, r *http.Request) {
+	var req maintenanceRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	status := server.GetRoomManager().SetMaintenance(req.Enabled, req.Message)
+	router.BroadcastMaintenance(s.game, status.Enabled, status.Message, time.Time{})
+	if !status.Enabled {
+		router.ResumeRounds()
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// announce 向所有连接推送系统公告
+func (s *Server) announce(w http.ResponseWriter, r *http.Request) {
+	var req announcementRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Message == "" {
+		writeError(w, http.StatusBadRequest, "message must not be empty")
+		return
+	}
+
+	recipients := router.BroadcastAnnouncement(s.game, req.Message)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"recipients": recipients,
+	})
+}
@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"net/http"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/metrics"
+	"xizexcample/internal/server"
+)
+
+// roomPhases 按状态机顺序列出的房间阶段，没有房间的阶段也输出 0
+var roomPhases = []logic.GameState{
+	logic.STATE_WAITING_FOR_PLAYERS,
+	logic.STATE_DEALING,
+	logic.STATE_BIDDING,
+	logic.STATE_BETTING,
+	logic.STATE_SHOWDOWN,
+	logic.STATE_SETTLEMENT,
+	logic.STATE_CLOSED,
+}
+
+// playerStatuses 列出的玩家状态，没有玩家的状态也输出 0
+var playerStatuses = []logic.PlayerStatus{
+	logic.STATUS_WAITING,
+	logic.STATUS_READY,
+	logic.STATUS_PLAYING,
+	logic.STATUS_OFFLINE,
+}
+
+// serveMetrics 以 Prometheus 文本格式输出指标：累计的计数器和直方图，以及采集时计算的连接、房间和玩家数
+func (s *Server) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	rooms := server.GetRoomManager().GetAllRooms()
+	roomsByPhase := make(map[logic.GameState]int)
+	playersByStatus := make(map[logic.PlayerStatus]int)
+	spectators := 0
+	for _, room := range rooms {
+		roomsByPhase[room.GetFSM().GetCurrentState()]++
+		for _, p := range room.GetPlayers() {
+			playersByStatus[p.GetStatus()]++
+		}
+		spectators += room.GetSpectatorCount()
+	}
+
+	metrics.WriteGauge(w, "game_connections", "Open client connections.", nil,
+		[]metrics.GaugeSample{{Value: float64(s.game.GetConnMgr().Len())}})
+
+	phaseSamples := make([]metrics.GaugeSample, 0, len(roomPhases))
+	for _, phase := range roomPhases {
+		phaseSamples = append(phaseSamples, metrics.GaugeSample{Labels: []string{phase.String()}, Value: float64(roomsByPhase[phase])})
+	}
+	metrics.WriteGauge(w, "game_rooms", "Rooms by phase.", []string{"phase"}, phaseSamples)
+
+	statusSamples := make([]metrics.GaugeSample, 0, len(playerStatuses)+1)
+	for _, status := range playerStatuses {
+		statusSamples = append(statusSamples, metrics.GaugeSample{Labels: []string{status.String()}, Value: float64(playersByStatus[status])})
+	}
+	statusSamples = append(statusSamples, metrics.GaugeSample{Labels: []string{"spectating"}, Value: float64(spectators)})
+	metrics.WriteGauge(w, "game_players", "Players in rooms by status.", []string{"status"}, statusSamples)
+
+	metrics.Default.Write(w)
+}
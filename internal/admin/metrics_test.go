@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/metrics"
+	"xizexcample/internal/server"
+)
+
+func TestAdminMetrics(t *testing.T) {
+	h := newTestServer(t)
+	room := newTestRoom(t, 94001, 94002)
+	defer server.GetRoomManager().DeleteRoom(room.ID)
+	p, _ := room.GetPlayer(94001)
+	p.SetStatus(logic.STATUS_READY)
+	metrics.RequestsTotal.Inc("C2S_PLAYER_READY_REQ")
+
+	rec := doRequest(h, http.MethodGet, "/metrics", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, line := range []string{
+		"game_connections 0",
+		`game_rooms{phase="bidding"} 0`,
+		`game_players{status="ready"} `,
+		`game_requests_total{msg_id="C2S_PLAYER_READY_REQ"} `,
+		"# TYPE game_request_duration_seconds histogram",
+		"# TYPE game_round_duration_seconds histogram",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected metrics to contain %q", line)
+		}
+	}
+	if strings.Contains(body, `game_rooms{phase="waiting_for_players"} 0`) {
+		t.Error("Expected the waiting room to be counted")
+	}
+}
+
+// TestAdminMetricsDuringRound 在牌局进行的同时采集指标和房间状态，用 go test -race 运行时检查数据竞争
+func TestAdminMetricsDuringRound(t *testing.T) {
+	h := newTestServer(t)
+	room := newTestRoom(t, 94011, 94012)
+	defer server.GetRoomManager().DeleteRoom(room.ID)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			for _, path := range []string{"/metrics", "/admin/rooms", fmt.Sprintf("/admin/rooms/%d", room.ID)} {
+				if rec := doRequest(h, http.MethodGet, path, ""); rec.Code != http.StatusOK {
+					t.Errorf("Expected 200 for %s, got %d", path, rec.Code)
+					return
+				}
+			}
+		}
+	}()
+
+	fsm := room.GetFSM()
+	for i := 0; i < 10; i++ {
+		room.Do(func() {
+			for _, p := range room.GetPlayers() {
+				p.SetStatus(logic.STATUS_READY)
+			}
+			if err := fsm.StartGame(); err != nil {
+				t.Errorf("StartGame failed: %v", err)
+				return
+			}
+			fsm.DealCards()
+			room.SetBanker(94011)
+			fsm.BidBanker()
+			p, _ := room.GetPlayer(94012)
+			p.PlaceBet(1)
+			fsm.PlaceBet()
+			fsm.Showdown()
+			fsm.Settlement()
+		})
+	}
+	<-done
+}
@@ -0,0 +1,99 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/profile"
+	"xizexcample/internal/router"
+	"xizexcample/internal/server"
+	"xizexcample/internal/wallet"
+)
+
+// playerLookup 玩家查询结果
+type playerLookup struct {
+	PlayerID   int64            `json:"player_id"`
+	Balance    int64            `json:"balance"`
+	Spectating bool             `json:"spectating"`
+	Room       *roomView        `json:"room"` // 玩家不在任何房间中时为 null
+	Profile    *profile.Profile `json:"profile,omitempty"`
+}
+
+// balanceRequest 调整玩家余额的请求
+type balanceRequest struct {
+	Amount int64 `json:"amount"` // 正数增加，负数扣减
+}
+
+// getPlayer 查询玩家的余额、档案和所在的房间
+func (s *Server) getPlayer(w http.ResponseWriter, r *http.Request) {
+	playerID, ok := parsePlayerID(w, r)
+	if !ok {
+		return
+	}
+	ledger := wallet.GetWallet()
+	if !ledger.HasAccount(playerID) {
+		writeError(w, http.StatusNotFound, "player not found")
+		return
+	}
+
+	lookup := playerLookup{
+		PlayerID: playerID,
+		Balance:  ledger.Balance(playerID),
+	}
+	if room := server.GetRoomManager().FindPlayerRoom(playerID); room != nil {
+		view := newRoomView(room, false)
+		lookup.Room = &view
+		lookup.Spectating = room.IsSpectator(playerID)
+	}
+	if p, err := profile.GetRepository().Load(playerID); err == nil {
+		lookup.Profile = p
+	}
+	writeJSON(w, http.StatusOK, lookup)
+}
+
+// adjustBalance 通过钱包账目调整玩家余额，玩家正在参与的牌局结算前不能调整
+func (s *Server) adjustBalance(w http.ResponseWriter, r *http.Request) {
+	playerID, ok := parsePlayerID(w, r)
+	if !ok {
+		return
+	}
+	var req balanceRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	// 调整余额并同步房间内的分数
+	balance, err := router.AdjustBalance(playerID, req.Amount)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, wallet.ErrInsufficientBalance) || errors.Is(err, router.ErrInActiveRound) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	logger.L().Info("Admin adjusted balance", logger.PlayerID(playerID), "amount", req.Amount, "balance", balance)
+
+	// 同步玩家档案
+	repo := profile.GetRepository()
+	if p, err := repo.Load(playerID); err == nil {
+		p.Balance = balance
+		if err := repo.Save(p); err != nil {
+			logger.L().Error("Failed to update profile", logger.PlayerID(playerID), logger.Err(err))
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"player_id": playerID,
+		"balance":   balance,
+	})
+}
+
+// parsePlayerID 解析路径中的玩家ID，失败时直接返回 400
+func parsePlayerID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	playerID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid player id")
+		return 0, false
+	}
+	return playerID, true
+}
@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/server"
+	"xizexcample/internal/wallet"
+)
+
+func TestAdminPlayerLookupAndBalance(t *testing.T) {
+	h := newTestServer(t)
+	if rec := doRequest(h, http.MethodGet, "/admin/players/93009", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown player, got %d", rec.Code)
+	}
+
+	room := newTestRoom(t, 93001, 93002)
+	defer server.GetRoomManager().DeleteRoom(room.ID)
+
+	rec := doRequest(h, http.MethodGet, "/admin/players/93001", "")
+	var lookup playerLookup
+	json.Unmarshal(rec.Body.Bytes(), &lookup)
+	if lookup.Room == nil || lookup.Room.RoomID != room.ID || lookup.Balance != logic.InitialScore {
+		t.Errorf("Expected player 93001 in room %d with %d, got %+v", room.ID, logic.InitialScore, lookup)
+	}
+
+	// 余额调整记入钱包账目并同步到房间内的分数
+	rec = doRequest(h, http.MethodPost, "/admin/players/93001/balance", `{"amount":500}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	player, _ := room.GetPlayer(93001)
+	if player.GetScore() != 1500 {
+		t.Errorf("Expected the in-room score to be 1500, got %d", player.GetScore())
+	}
+	if sum, _ := wallet.GetWallet().SumByReason(93001, wallet.REASON_ADMIN_ADJUSTMENT); sum != 500 {
+		t.Errorf("Expected a 500 adjustment in the ledger, got %d", sum)
+	}
+	if rec := doRequest(h, http.MethodPost, "/admin/players/93001/balance", `{"amount":-2000}`); rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 when the balance would go negative, got %d", rec.Code)
+	}
+	if rec := doRequest(h, http.MethodPost, "/admin/players/93001/balance", `{"amount":0}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a zero adjustment, got %d", rec.Code)
+	}
+
+	// 牌局进行中不能调整参与玩家的余额
+	for _, p := range room.GetPlayers() {
+		p.SetStatus(logic.STATUS_READY)
+	}
+	room.GetFSM().StartGame()
+	room.GetFSM().DealCards()
+	if rec := doRequest(h, http.MethodPost, "/admin/players/93002/balance", `{"amount":100}`); rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 during a round, got %d", rec.Code)
+	}
+
+	// 断线后仍占着座位的玩家同样能查到所在的房间，牌局进行中也不能调整余额
+	room.SetPlayerOffline(93002)
+	server.GetRoomManager().UnregisterPlayer(93002)
+	rec = doRequest(h, http.MethodGet, "/admin/players/93002", "")
+	lookup = playerLookup{}
+	json.Unmarshal(rec.Body.Bytes(), &lookup)
+	if lookup.Room == nil || lookup.Room.RoomID != room.ID {
+		t.Errorf("Expected the disconnected player 93002 in room %d, got %+v", room.ID, lookup)
+	}
+	if rec := doRequest(h, http.MethodPost, "/admin/players/93002/balance", `{"amount":100}`); rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for a disconnected player during a round, got %d", rec.Code)
+	}
+	if balance := wallet.GetWallet().Balance(93002); balance != logic.InitialScore {
+		t.Errorf("Expected the disconnected player's balance to stay %d, got %d", logic.InitialScore, balance)
+	}
+}
@@ -0,0 +1,175 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/router"
+	"xizexcample/internal/server"
+)
+
+// playerView 管理后台看到的玩家状态，手牌只在房间详情中返回
+type playerView struct {
+	PlayerID    int64    `json:"player_id"`
+	Nickname    string   `json:"nickname"`
+	Seat        int      `json:"seat,omitempty"`
+	Status      string   `json:"status"`
+	Online      bool     `json:"online"`
+	Score       int64    `json:"score"`
+	IsBanker    bool     `json:"is_banker,omitempty"`
+	BidMultiple int32    `json:"bid_multiple,omitempty"`
+	BetMultiple int32    `json:"bet_multiple,omitempty"`
+	HasShown    bool     `json:"has_shown,omitempty"`
+	Hand        []string `json:"hand,omitempty"`
+}
+
+// roomView 管理后台看到的房间概要
+type roomView struct {
+	RoomID     int32        `json:"room_id"`
+	Phase      string       `json:"phase"`
+	RoundID    string       `json:"round_id,omitempty"`
+	Paused     bool         `json:"paused"`
+	IsPrivate  bool         `json:"is_private"`
+	OwnerID    int64        `json:"owner_id,omitempty"`
+	BaseBet    int64        `json:"base_bet"`
+	MaxPlayers int          `json:"max_players"`
+	Players    []playerView `json:"players"`
+	Spectators []playerView `json:"spectators"`
+}
+
+// roomDetail 管理后台看到的房间完整状态，包括所有玩家的手牌
+type roomDetail struct {
+	roomView
+	Settings    logic.RoomSettings `json:"settings"`
+	InviteCode  string             `json:"invite_code,omitempty"`
+	BankerID    int64              `json:"banker_id,omitempty"`
+	LockedSeats []int              `json:"locked_seats"`
+	ShuffleSeed int64              `json:"shuffle_seed"`
+	RoundsDone  int                `json:"rounds_done"`
+}
+
+// kickRequest 将玩家移出房间的请求
+type kickRequest struct {
+	PlayerID int64 `json:"player_id"`
+}
+
+// listRooms 列出所有房间的阶段和玩家
+func (s *Server) listRooms(w http.ResponseWriter, r *http.Request) {
+	rooms := server.GetRoomManager().GetAllRooms()
+	views := make([]roomView, 0, len(rooms))
+	for _, room := range rooms {
+		views = append(views, newRoomView(room, false))
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+// getRoom 返回一个房间的完整状态
+func (s *Server) getRoom(w http.ResponseWriter, r *http.Request) {
+	room, ok := lookupRoom(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, roomDetail{
+		roomView:    newRoomView(room, true),
+		Settings:    room.Settings,
+		InviteCode:  room.InviteCode,
+		BankerID:    room.GetBankerID(),
+		LockedSeats: room.GetLockedSeats(),
+		ShuffleSeed: room.GetShuffleSeed(),
+		RoundsDone:  len(room.GetSession().Rounds),
+	})
+}
+
+// kickPlayer 将玩家或观战者移出房间
+func (s *Server) kickPlayer(w http.ResponseWriter, r *http.Request) {
+	room, ok := lookupRoom(w, r)
+	if !ok {
+		return
+	}
+	var req kickRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := router.RemoveFromRoom(room, req.PlayerID); err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, newRoomView(room, false))
+}
+
+// closeRoom 关闭房间，进行中的牌局作废并退款
+func (s *Server) closeRoom(w http.ResponseWriter, r *http.Request) {
+	room, ok := lookupRoom(w, r)
+	if !ok {
+		return
+	}
+	if err := router.CloseRoom(room); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"ret_code": 0,
+		"room_id":  room.ID,
+	})
+}
+
+// lookupRoom 按路径中的房间ID查找房间，找不到时直接返回 404
+func lookupRoom(w http.ResponseWriter, r *http.Request) (*logic.Room, bool) {
+	roomID, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid room id")
+		return nil, false
+	}
+	room, err := server.GetRoomManager().GetRoom(int32(roomID))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return nil, false
+	}
+	return room, true
+}
+
+// newRoomView 构建房间概要，withHands 为 true 时包括所有玩家的手牌
+func newRoomView(room *logic.Room, withHands bool) roomView {
+	fsm := room.GetFSM()
+	view := roomView{
+		RoomID:     room.ID,
+		Phase:      fsm.GetCurrentState().String(),
+		RoundID:    fsm.GetRoundID(),
+		Paused:     fsm.IsPaused(),
+		IsPrivate:  room.Settings.IsPrivate,
+		OwnerID:    room.GetOwnerID(),
+		BaseBet:    room.Settings.BaseBet,
+		MaxPlayers: room.Settings.MaxPlayers,
+		Players:    make([]playerView, 0),
+		Spectators: make([]playerView, 0),
+	}
+	for _, p := range room.GetPlayers() {
+		view.Players = append(view.Players, newPlayerView(p, withHands))
+	}
+	for _, p := range room.GetSpectators() {
+		view.Spectators = append(view.Spectators, newPlayerView(p, false))
+	}
+	return view
+}
+
+// newPlayerView 构建玩家状态
+func newPlayerView(p *logic.Player, withHand bool) playerView {
+	view := playerView{
+		PlayerID:    p.ID,
+		Nickname:    p.Nickname,
+		Seat:        p.GetSeat(),
+		Status:      p.GetStatus().String(),
+		Online:      p.IsOnline(),
+		Score:       p.GetScore(),
+		IsBanker:    p.IsBanker(),
+		BidMultiple: p.GetBidMultiple(),
+		BetMultiple: p.GetBetAmount(),
+		HasShown:    p.HasShown(),
+	}
+	if withHand {
+		for _, card := range p.GetHand() {
+			view.Hand = append(view.Hand, card.String())
+		}
+	}
+	return view
+}
@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/server"
+)
+
+// newTestRoom 在全局 RoomManager 中创建一个有两名玩家的房间
+func newTestRoom(t *testing.T, id1, id2 int64) *logic.Room {
+	rm := server.GetRoomManager()
+	room, err := rm.CreateRoomWithSettings(logic.DefaultRoomSettings(), "")
+	if err != nil {
+		t.Fatalf("CreateRoomWithSettings failed: %v", err)
+	}
+	for _, id := range []int64{id1, id2} {
+		if err := room.AddPlayer(logic.NewPlayer(id, fmt.Sprintf("Player%d", id), nil)); err != nil {
+			t.Fatalf("AddPlayer failed: %v", err)
+		}
+		rm.RegisterPlayer(id, room.ID)
+	}
+	return room
+}
+
+func TestAdminRoomState(t *testing.T) {
+	h := newTestServer(t)
+	room := newTestRoom(t, 91001, 91002)
+	for _, p := range room.GetPlayers() {
+		p.SetStatus(logic.STATUS_READY)
+	}
+	room.GetFSM().StartGame()
+	room.GetFSM().DealCards()
+
+	rec := doRequest(h, http.MethodGet, "/admin/rooms", "")
+	var rooms []roomView
+	json.Unmarshal(rec.Body.Bytes(), &rooms)
+	found := false
+	for _, v := range rooms {
+		if v.RoomID != room.ID {
+			continue
+		}
+		found = true
+		if v.Phase != "bidding" || len(v.Players) != 2 || v.Players[0].Hand != nil {
+			t.Errorf("Expected a bidding room with 2 players and hidden hands, got %+v", v)
+		}
+	}
+	if !found {
+		t.Fatalf("Expected room %d in the room list", room.ID)
+	}
+
+	rec = doRequest(h, http.MethodGet, fmt.Sprintf("/admin/rooms/%d", room.ID), "")
+	var detail roomDetail
+	json.Unmarshal(rec.Body.Bytes(), &detail)
+	for _, p := range detail.Players {
+		if len(p.Hand) != 5 {
+			t.Errorf("Expected player %d's full hand in the room detail, got %v", p.PlayerID, p.Hand)
+		}
+	}
+	if rec := doRequest(h, http.MethodGet, "/admin/rooms/1", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown room, got %d", rec.Code)
+	}
+
+	// 牌局进行中不能踢人，关闭房间时牌局作废
+	if rec := doRequest(h, http.MethodPost, fmt.Sprintf("/admin/rooms/%d/kick", room.ID), `{"player_id":91002}`); rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 when kicking during a round, got %d", rec.Code)
+	}
+	if rec := doRequest(h, http.MethodDelete, fmt.Sprintf("/admin/rooms/%d", room.ID), ""); rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 when closing the room, got %d: %s", rec.Code, rec.Body)
+	}
+	if _, err := server.GetRoomManager().GetRoom(room.ID); err == nil {
+		t.Error("Expected the closed room to be deleted")
+	}
+	if server.GetRoomManager().GetRoomByPlayerID(91001) != nil {
+		t.Error("Expected players of the closed room to be unregistered")
+	}
+}
+
+func TestAdminKickPlayer(t *testing.T) {
+	h := newTestServer(t)
+	room := newTestRoom(t, 92001, 92002)
+	defer server.GetRoomManager().DeleteRoom(room.ID)
+
+	rec := doRequest(h, http.MethodPost, fmt.Sprintf("/admin/rooms/%d/kick", room.ID), `{"player_id":92002}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if room.GetPlayerCount() != 1 || server.GetRoomManager().GetRoomByPlayerID(92002) != nil {
+		t.Error("Expected player 92002 to be removed from the room")
+	}
+	if rec := doRequest(h, http.MethodPost, fmt.Sprintf("/admin/rooms/%d/kick", room.ID), `{"player_id":92002}`); rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 when kicking a player who is not in the room, got %d", rec.Code)
+	}
+}
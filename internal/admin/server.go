@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"github.com/aceld/zinx/ziface"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+	"xizexcample/internal/pkg/logger"
+)
+
+// Server 管理后台 HTTP 服务，与游戏服务使用不同的端口，所有接口都需要在
+// Authorization 请求头中携带管理令牌：Authorization: Bearer <token>
+type Server struct {
+	token string
+	game  ziface.IServer // 游戏服务，用于向所有连接推送通知
+	mux   *http.ServeMux
+	http  *http.Server
+}
+
+// NewServer 创建管理后台服务，管理令牌不能为空
+func NewServer(token string, game ziface.IServer) (*Server, error) {
+	if token == "" {
+		return nil, errors.New("admin token must not be empty")
+	}
+	s := &Server{
+		token: token,
+		game:  game,
+		mux:   http.NewServeMux(),
+	}
+	s.routes()
+	return s, nil
+}
+
+// routes 注册管理接口
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /admin/maintenance", s.getMaintenance)
+	s.mux.HandleFunc("PUT /admin/maintenance", s.setMaintenance)
+	s.mux.HandleFunc("POST /admin/announcements", s.announce)
+
+	s.mux.HandleFunc("GET /admin/rooms", s.listRooms)
+	s.mux.HandleFunc("GET /admin/rooms/{id}", s.getRoom)
+	s.mux.HandleFunc("DELETE /admin/rooms/{id}", s.closeRoom)
+	s.mux.HandleFunc("POST /admin/rooms/{id}/kick", s.kickPlayer)
+
+	s.mux.HandleFunc("GET /admin/players/{id}", s.getPlayer)
+	s.mux.HandleFunc("POST /admin/players/{id}/balance", s.adjustBalance)
+
+	s.mux.HandleFunc("GET /admin/log-level", s.getLogLevel)
+	s.mux.HandleFunc("PUT /admin/log-level", s.setLogLevel)
+
+	s.mux.HandleFunc("GET /metrics", s.serveMetrics)
+}
+
+// Handler 返回带令牌校验的 HTTP 处理器
+func (s *Server) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid admin token")
+			return
+		}
+		s.mux.ServeHTTP(w, r)
+	})
+}
+
+// Start 在指定地址上监听并在后台提供服务，监听失败时返回错误
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.http = &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := s.http.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.L().Error("Admin server stopped", logger.Err(err))
+		}
+	}()
+	logger.L().Info("Admin server listening", "addr", listener.Addr().String())
+	return nil
+}
+
+// Shutdown 停止管理后台服务，等待进行中的请求完成
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.http == nil {
+		return nil
+	}
+	return s.http.Shutdown(ctx)
+}
+
+// decodeJSON 解析请求体，失败时直接返回 400
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	return true
+}
+
+// writeJSON 以 JSON 格式写回响应
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError 以与游戏消息一致的格式写回错误响应
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"ret_code": 1,
+		"message":  message,
+	})
+}
@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"encoding/json"
+	"github.com/aceld/zinx/znet"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"xizexcample/internal/server"
+)
+
+const testToken = "secret"
+
+// newTestServer 创建一个管理后台服务，游戏服务不启动监听，没有任何连接
+func newTestServer(t *testing.T) http.Handler {
+	s, err := NewServer(testToken, znet.NewServer())
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	return s.Handler()
+}
+
+// doRequest 携带管理令牌发起请求
+func doRequest(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAdminRequiresToken(t *testing.T) {
+	if _, err := NewServer("", znet.NewServer()); err == nil {
+		t.Error("Expected an empty admin token to be rejected")
+	}
+
+	h := newTestServer(t)
+	for _, header := range []string{"", "Bearer wrong", testToken} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/maintenance", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected 401 for Authorization %q, got %d", header, rec.Code)
+		}
+	}
+	if rec := doRequest(h, http.MethodGet, "/admin/maintenance", ""); rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 with a valid token, got %d", rec.Code)
+	}
+}
+
+func TestAdminMaintenance(t *testing.T) {
+	h := newTestServer(t)
+	defer server.GetRoomManager().SetMaintenance(false, "")
+
+	rec := doRequest(h, http.MethodPut, "/admin/maintenance", `{"enabled":true,"message":"restarting in 10 minutes"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if !server.GetRoomManager().InMaintenance() {
+		t.Error("Expected maintenance mode to be enabled")
+	}
+
+	rec = doRequest(h, http.MethodGet, "/admin/maintenance", "")
+	var status server.Maintenance
+	json.Unmarshal(rec.Body.Bytes(), &status)
+	if !status.Enabled || status.Message != "restarting in 10 minutes" {
+		t.Errorf("Unexpected maintenance status: %+v", status)
+	}
+
+	doRequest(h, http.MethodPut, "/admin/maintenance", `{"enabled":false}`)
+	if server.GetRoomManager().InMaintenance() {
+		t.Error("Expected maintenance mode to be disabled")
+	}
+	if rec := doRequest(h, http.MethodPut, "/admin/maintenance", `not json`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid body, got %d", rec.Code)
+	}
+}
+
+func TestAdminAnnouncement(t *testing.T) {
+	h := newTestServer(t)
+
+	if rec := doRequest(h, http.MethodPost, "/admin/announcements", `{"message":""}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an empty announcement, got %d", rec.Code)
+	}
+	rec := doRequest(h, http.MethodPost, "/admin/announcements", `{"message":"server restarting in 10 minutes"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	var resp map[string]int
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["recipients"] != 0 {
+		t.Errorf("Expected no recipients without connections, got %d", resp["recipients"])
+	}
+}
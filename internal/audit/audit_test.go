@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"xizexcample/internal/logic"
+)
+
+// writeEntries 向审计日志文件追加若干事件
+func writeEntries(t *testing.T, path string, events ...Event) {
+	t.Helper()
+	l, err := NewFileLog(path)
+	if err != nil {
+		t.Fatalf("NewFileLog failed: %v", err)
+	}
+	defer l.Close()
+	for _, event := range events {
+		if _, err := l.Append(event); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+}
+
+func sampleEvents() []Event {
+	return []Event{
+		{Action: ACTION_JOIN, RoomID: 100001, PlayerID: 1, Detail: JoinDetail{Seat: 1}},
+		{Action: ACTION_JOIN, RoomID: 100001, PlayerID: 2, Detail: JoinDetail{Seat: 2}},
+		{Action: ACTION_READY, RoomID: 100001, PlayerID: 1, Detail: ReadyDetail{Ready: true}},
+		{Action: ACTION_DEAL, RoomID: 100001, RoundID: "100001-1", Detail: DealDetail{
+			ShuffleSeed: 42,
+			Hands:       []HandDetail{{PlayerID: 1, Seat: 1, Hand: []logic.Card{{Suit: logic.SUIT_SPADES, Rank: logic.RANK_ACE}}}},
+		}},
+		{Action: ACTION_BANKER, RoomID: 100001, RoundID: "100001-1", Detail: BankerDetail{BankerID: 1, Multiple: 2}},
+	}
+}
+
+func TestMemoryLogChain(t *testing.T) {
+	l := NewMemoryLog()
+	for _, event := range sampleEvents() {
+		if _, err := l.Append(event); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	entries := l.Entries()
+	if entries[0].Seq != 1 || entries[0].PrevHash != GenesisHash {
+		t.Errorf("Expected the first entry to start the chain, got seq %d prev %s", entries[0].Seq, entries[0].PrevHash)
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].PrevHash != entries[i-1].Hash {
+			t.Errorf("Expected entry %d to link to the previous entry", entries[i].Seq)
+		}
+	}
+	if entries[0].Actor != "player" || entries[3].Actor != "server" {
+		t.Errorf("Expected join by a player and deal by the server, got %s and %s", entries[0].Actor, entries[3].Actor)
+	}
+}
+
+func TestFileLogVerify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+	writeEntries(t, path, sampleEvents()...)
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer file.Close()
+	result, err := Verify(file)
+	if err != nil {
+		t.Fatalf("Expected an untouched log to verify, got %v", err)
+	}
+	if result.Entries != 5 || result.LastSeq != 5 || result.Head == GenesisHash {
+		t.Errorf("Unexpected verify result: %+v", result)
+	}
+}
+
+func TestFileLogResumesChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	events := sampleEvents()
+	writeEntries(t, path, events[:2]...)
+	writeEntries(t, path, events[2:]...)
+
+	data, _ := os.ReadFile(path)
+	result, err := Verify(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Expected the reopened log to continue the chain, got %v", err)
+	}
+	if result.Entries != len(events) {
+		t.Errorf("Expected %d entries, got %d", len(events), result.Entries)
+	}
+}
+
+// tamper 读取审计日志的各行，交给 modify 修改后返回新的内容
+func tamper(t *testing.T, modify func(lines []string) []string) []byte {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeEntries(t, path, sampleEvents()...)
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	return []byte(strings.Join(modify(lines), "\n") + "\n")
+}
+
+func expectChainError(t *testing.T, data []byte, line int) {
+	t.Helper()
+	_, err := Verify(bytes.NewReader(data))
+	var chainErr *ChainError
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("Expected a chain error, got %v", err)
+	}
+	if chainErr.Line != line {
+		t.Errorf("Expected the failure at line %d, got %v", line, chainErr)
+	}
+}
+
+func TestVerifyDetectsEditedEntry(t *testing.T) {
+	data := tamper(t, func(lines []string) []string {
+		lines[4] = strings.Replace(lines[4], `"banker_id":1`, `"banker_id":2`, 1)
+		return lines
+	})
+	expectChainError(t, data, 5)
+}
+
+func TestVerifyDetectsRehashedEntry(t *testing.T) {
+	// 修改记录后重新计算它自己的哈希，下一条记录的 PrevHash 不再匹配
+	data := tamper(t, func(lines []string) []string {
+		var entry Entry
+		json.Unmarshal([]byte(lines[2]), &entry)
+		entry.Detail = json.RawMessage(`{"ready":false}`)
+		entry.Hash = entry.ComputeHash()
+		edited, _ := json.Marshal(entry)
+		lines[2] = string(edited)
+		return lines
+	})
+	expectChainError(t, data, 4)
+}
+
+func TestVerifyDetectsDeletedEntry(t *testing.T) {
+	data := tamper(t, func(lines []string) []string {
+		return append(lines[:1], lines[2:]...)
+	})
+	expectChainError(t, data, 2)
+}
+
+func TestVerifyDetectsMalformedEntry(t *testing.T) {
+	data := tamper(t, func(lines []string) []string {
+		lines[1] = lines[1][:10]
+		return lines
+	})
+	expectChainError(t, data, 2)
+}
+
+func TestNewSettleEvent(t *testing.T) {
+	result := &logic.RoundResult{
+		RoundID:  "100001-1",
+		Round:    1,
+		BankerID: 1,
+		Results: []logic.PlayerResult{
+			{PlayerID: 1, ScoreChange: 20, FinalScore: 1020},
+			{PlayerID: 2, ScoreChange: -20, FinalScore: 980},
+		},
+	}
+	event := NewSettleEvent(100001, result)
+	detail := event.Detail.(SettleDetail)
+	if event.Action != ACTION_SETTLE || event.RoundID != "100001-1" || len(detail.Results) != 2 {
+		t.Errorf("Unexpected settle event: %+v", event)
+	}
+	if detail.Results[1].FinalScore != 980 {
+		t.Errorf("Expected final score 980, got %d", detail.Results[1].FinalScore)
+	}
+}
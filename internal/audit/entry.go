@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+	"xizexcample/internal/logic"
+)
+
+// GenesisHash 第一条审计记录的前一条哈希
+var GenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// Action 审计事件的类型
+type Action string
+
+// 玩家操作，只记录被服务器接受的操作
+const (
+	ACTION_JOIN  Action = "join"  // 入座、观战或重连
+	ACTION_READY Action = "ready" // 准备或取消准备
+	ACTION_BID   Action = "bid"   // 抢庄
+	ACTION_BET   Action = "bet"   // 下注
+	ACTION_SHOW  Action = "show"  // 摊牌
+	ACTION_LEAVE Action = "leave" // 离开房间
+)
+
+// 服务器决定
+const (
+	ACTION_DEAL   Action = "deal"   // 发牌
+	ACTION_BANKER Action = "banker" // 确定庄家
+	ACTION_SETTLE Action = "settle" // 结算
+)
+
+// Actor 返回事件的发起方：player 或 server
+func (a Action) Actor() string {
+	switch a {
+	case ACTION_DEAL, ACTION_BANKER, ACTION_SETTLE:
+		return "server"
+	default:
+		return "player"
+	}
+}
+
+// Event 一条待记录的审计事件
+type Event struct {
+	Action   Action
+	RoomID   int32
+	RoundID  string // 牌局内的事件才有牌局ID
+	PlayerID int64  // 玩家操作的发起者，服务器决定为 0
+	Detail   any    // 事件详情，序列化为 JSON
+}
+
+// Entry 审计日志中的一条记录。Hash 是除 Hash 外所有字段（包括 PrevHash）的 SHA-256，
+// 每条记录都通过 PrevHash 指向前一条，修改或删除任何一条都会使之后的校验失败
+type Entry struct {
+	Seq      uint64          `json:"seq"` // 从 1 开始连续编号
+	Time     time.Time       `json:"time"`
+	Actor    string          `json:"actor"`
+	Action   Action          `json:"action"`
+	RoomID   int32           `json:"room_id"`
+	RoundID  string          `json:"round_id,omitempty"`
+	PlayerID int64           `json:"player_id,omitempty"`
+	Detail   json.RawMessage `json:"detail,omitempty"`
+	PrevHash string          `json:"prev_hash"`
+	Hash     string          `json:"hash"`
+}
+
+// ComputeHash 计算记录的哈希
+func (e Entry) ComputeHash() string {
+	e.Hash = ""
+	data, _ := json.Marshal(e)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// chain 维护哈希链的末端，用于生成下一条记录
+type chain struct {
+	seq  uint64
+	head string
+}
+
+// next 生成链上的下一条记录并移动链的末端
+func (c *chain) next(event Event, now time.Time) (Entry, error) {
+	entry := Entry{
+		Seq:      c.seq + 1,
+		Time:     now.UTC(),
+		Actor:    event.Action.Actor(),
+		Action:   event.Action,
+		RoomID:   event.RoomID,
+		RoundID:  event.RoundID,
+		PlayerID: event.PlayerID,
+		PrevHash: c.head,
+	}
+	if event.Detail != nil {
+		detail, err := json.Marshal(event.Detail)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.Detail = detail
+	}
+	entry.Hash = entry.ComputeHash()
+	c.seq, c.head = entry.Seq, entry.Hash
+	return entry, nil
+}
+
+// JoinDetail 入座、观战或重连的详情
+type JoinDetail struct {
+	Seat      int  `json:"seat,omitempty"` // 观战者为 0
+	Spectator bool `json:"spectator,omitempty"`
+	Reconnect bool `json:"reconnect,omitempty"`
+}
+
+// ReadyDetail 准备状态的详情
+type ReadyDetail struct {
+	Ready bool `json:"ready"`
+}
+
+// MultipleDetail 抢庄或下注的倍数
+type MultipleDetail struct {
+	Multiple int32 `json:"multiple"`
+}
+
+// LeaveDetail 离开房间的详情
+type LeaveDetail struct {
+	Spectator bool `json:"spectator,omitempty"`
+}
+
+// HandDetail 一名玩家发到的手牌
+type HandDetail struct {
+	PlayerID int64        `json:"player_id"`
+	Seat     int          `json:"seat"`
+	Hand     []logic.Card `json:"hand"`
+}
+
+// DealDetail 发牌的详情，包含洗牌种子和每名参与玩家的手牌
+type DealDetail struct {
+	ShuffleSeed int64        `json:"shuffle_seed"`
+	Hands       []HandDetail `json:"hands"`
+}
+
+// BankerDetail 确定庄家的详情
+type BankerDetail struct {
+	BankerID int64 `json:"banker_id"`
+	Multiple int32 `json:"multiple"`
+}
+
+// SettleResult 一名玩家的结算结果
+type SettleResult struct {
+	PlayerID    int64          `json:"player_id"`
+	CardType    logic.CardType `json:"card_type"`
+	ScoreChange int64          `json:"score_change"` // 已扣除抽水
+	Rake        int64          `json:"rake"`
+	FinalScore  int64          `json:"final_score"`
+}
+
+// SettleDetail 结算的详情
+type SettleDetail struct {
+	Round    int            `json:"round"`
+	BankerID int64          `json:"banker_id"`
+	Results  []SettleResult `json:"results"`
+}
+
+// NewSettleEvent 根据一局的结算结果生成结算事件
+func NewSettleEvent(roomID int32, result *logic.RoundResult) Event {
+	detail := SettleDetail{
+		Round:    result.Round,
+		BankerID: result.BankerID,
+		Results:  make([]SettleResult, 0, len(result.Results)),
+	}
+	for _, r := range result.Results {
+		detail.Results = append(detail.Results, SettleResult{
+			PlayerID:    r.PlayerID,
+			CardType:    r.CardType,
+			ScoreChange: r.ScoreChange,
+			Rake:        r.Rake,
+			FinalScore:  r.FinalScore,
+		})
+	}
+	return Event{Action: ACTION_SETTLE, RoomID: roomID, RoundID: result.RoundID, Detail: detail}
+}
@@ -0,0 +1,145 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Log 只追加的审计日志
+type Log interface {
+	// Append 把事件接到哈希链的末端并写入日志，返回写入的记录
+	Append(event Event) (Entry, error)
+}
+
+// MemoryLog 内存中的审计日志
+type MemoryLog struct {
+	chain   chain
+	entries []Entry
+	mu      sync.Mutex
+}
+
+// NewMemoryLog 创建一个内存审计日志
+func NewMemoryLog() *MemoryLog {
+	return &MemoryLog{chain: chain{head: GenesisHash}}
+}
+
+// Append 把事件接到哈希链的末端
+func (m *MemoryLog) Append(event Event) (Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, err := m.chain.next(event, time.Now())
+	if err != nil {
+		return Entry{}, err
+	}
+	m.entries = append(m.entries, entry)
+	return entry, nil
+}
+
+// Entries 返回所有记录的副本
+func (m *MemoryLog) Entries() []Entry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Entry(nil), m.entries...)
+}
+
+// FileLog 写入 JSON Lines 文件的审计日志，每条记录写入后立即刷盘。
+// 打开已有的文件时从最后一条记录继续哈希链
+type FileLog struct {
+	file  *os.File
+	chain chain
+	mu    sync.Mutex
+}
+
+// NewFileLog 打开或创建审计日志文件
+func NewFileLog(path string) (*FileLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create audit directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+
+	c, err := lastLink(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &FileLog{file: file, chain: c}, nil
+}
+
+// lastLink 读取文件中的最后一条记录，返回哈希链的末端
+func lastLink(r io.Reader) (chain, error) {
+	c := chain{head: GenesisHash}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return c, fmt.Errorf("audit log line %d: %w", line, err)
+		}
+		c.seq, c.head = entry.Seq, entry.Hash
+	}
+	return c, scanner.Err()
+}
+
+// Append 把事件接到哈希链的末端，写入文件并刷盘
+func (f *FileLog) Append(event Event) (Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// 写入失败时不移动链的末端，下一条记录仍然接在最后一条成功写入的记录之后
+	c := f.chain
+	entry, err := c.next(event, time.Now())
+	if err != nil {
+		return Entry{}, err
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return Entry{}, err
+	}
+	if _, err := f.file.Write(append(line, '\n')); err != nil {
+		return Entry{}, fmt.Errorf("write audit log: %w", err)
+	}
+	if err := f.file.Sync(); err != nil {
+		return Entry{}, fmt.Errorf("sync audit log: %w", err)
+	}
+	f.chain = c
+	return entry, nil
+}
+
+// Close 关闭审计日志文件
+func (f *FileLog) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+var (
+	logInstance Log = NewMemoryLog()
+	logMu       sync.Mutex
+)
+
+// GetLog 获取全局审计日志，未通过 SetLog 指定时使用内存审计日志
+func GetLog() Log {
+	logMu.Lock()
+	defer logMu.Unlock()
+	return logInstance
+}
+
+// SetLog 替换全局审计日志，应在服务启动时调用
+func SetLog(l Log) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	logInstance = l
+}
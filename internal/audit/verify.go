@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ChainError 审计日志校验失败的位置和原因
+type ChainError struct {
+	Line   int    // 出错的行号，从 1 开始
+	Seq    uint64 // 出错记录的序号，无法解析时为 0
+	Reason string
+}
+
+func (e *ChainError) Error() string {
+	if e.Seq == 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Reason)
+	}
+	return fmt.Sprintf("line %d (seq %d): %s", e.Line, e.Seq, e.Reason)
+}
+
+// VerifyResult 审计日志的校验结果
+type VerifyResult struct {
+	Entries int    // 校验通过的记录数
+	LastSeq uint64 // 最后一条记录的序号
+	Head    string // 最后一条记录的哈希，与另外保存的值比对可以发现末尾记录被删除
+}
+
+// Verify 从头校验审计日志的哈希链：每条记录的哈希与内容一致、序号连续、PrevHash 等于前一条的哈希。
+// 遇到第一处不一致时返回 *ChainError，结果中包含此前校验通过的部分
+func Verify(r io.Reader) (VerifyResult, error) {
+	result := VerifyResult{Head: GenesisHash}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return result, &ChainError{Line: line, Reason: "malformed entry: " + err.Error()}
+		}
+		switch {
+		case entry.Hash != entry.ComputeHash():
+			return result, &ChainError{Line: line, Seq: entry.Seq, Reason: "hash does not match the entry, the entry was modified"}
+		case entry.Seq != result.LastSeq+1:
+			return result, &ChainError{Line: line, Seq: entry.Seq, Reason: fmt.Sprintf("expected seq %d, entries are missing or reordered", result.LastSeq+1)}
+		case entry.PrevHash != result.Head:
+			return result, &ChainError{Line: line, Seq: entry.Seq, Reason: "previous hash does not match, the chain is broken"}
+		}
+		result.Entries++
+		result.LastSeq, result.Head = entry.Seq, entry.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
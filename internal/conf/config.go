@@ -1,45 +1,289 @@
 package conf
 
 import (
-	"encoding/json"
-	"os"
+	"errors"
+	"fmt"
+	"xizexcample/internal/logic"
 	"xizexcample/internal/pkg/logger"
 )
 
-// Config 保存应用程序的所有配置
+// DefaultPath 默认的配置文件路径。conf/zinx.json 是 zinx 自己的配置，这里的设置会覆盖其中的监听地址和连接参数
+const DefaultPath = "conf/server.json"
+
+// Config 保存应用程序的所有配置。带有 reload:"hot" 标签的字段在收到 SIGHUP 时重新加载，
+// 其余字段修改后需要重启才能生效
 type Config struct {
-	ServerHost string `json:"server_host"`
-	ServerPort int    `json:"server_port"`
-	Timeout    int    `json:"timeout"`
+	Server  ServerConfig  `json:"server"`  // 游戏服务监听配置
+	Rooms   RoomsConfig   `json:"rooms"`   // 新建房间的默认设置
+	Rules   RulesConfig   `json:"rules"`   // 新建房间的默认玩法
+	Timers  TimersConfig  `json:"timers"`  // 各类时限
+	Economy EconomyConfig `json:"economy"` // 初始分数和平台抽水
+	Storage StorageConfig `json:"storage"` // 账目、快照、牌局记录、玩家档案和审计日志的存储位置
+	Log     LogConfig     `json:"log"`     // 日志配置
+	Auth    AuthConfig    `json:"auth"`    // 登录令牌配置
+	Admin   AdminConfig   `json:"admin"`   // 管理后台配置
+	Health  HealthConfig  `json:"health"`  // 健康检查配置
+
+	Announcement string `json:"announcement" reload:"hot"` // 系统公告，重新加载后内容有变化时推送给所有连接
+}
+
+// ServerConfig 游戏服务监听配置，启动时写入 zinx 的全局配置
+type ServerConfig struct {
+	Name             string `json:"name"`                // 服务名称
+	Host             string `json:"host"`                // 监听地址
+	Port             int    `json:"port"`                // 监听端口
+	MaxConn          int    `json:"max_conn"`            // 最大连接数
+	WorkerPoolSize   uint32 `json:"worker_pool_size"`    // 业务工作池的 worker 数量，0 表示每个连接一个协程
+	MaxWorkerTaskLen uint32 `json:"max_worker_task_len"` // 每个 worker 任务队列的长度
+}
+
+// RoomsConfig 新建房间的默认设置，客户端创建房间时未填写的字段使用这些值
+type RoomsConfig struct {
+	BaseBet       int64 `json:"base_bet"`       // 底注
+	MaxPlayers    int   `json:"max_players"`    // 座位数
+	MinPlayers    int   `json:"min_players"`    // 开局所需的最少玩家数
+	Rounds        int   `json:"rounds"`         // 局数，0 表示不限局数
+	MaxSpectators int   `json:"max_spectators"` // 最大观战人数
+}
+
+// RulesConfig 新建房间的默认玩法
+type RulesConfig struct {
+	RuleSet      string  `json:"rule_set"`      // banker_bid / free_bid / fixed_banker / all_compare
+	DissolveRule string  `json:"dissolve_rule"` // unanimous / majority
+	PayoutPolicy string  `json:"payout_policy"` // proportional / seat_order
+	BidMultiples []int32 `json:"bid_multiples"` // 允许的抢庄倍数，升序
+	BetMultiples []int32 `json:"bet_multiples"` // 允许的下注倍数，升序
+	PushBetCap   int32   `json:"push_bet_cap"`  // 推注倍数上限，0 表示不允许推注
+	PushCooldown int     `json:"push_cooldown"` // 推注后需要间隔的局数
+}
+
+// TimersConfig 各类时限（秒），运行中可以重新加载
+type TimersConfig struct {
+	SnapshotInterval    int `json:"snapshot_interval" reload:"hot"`     // 定期保存快照的间隔，0 表示只在状态转换时保存
+	ShutdownTimeout     int `json:"shutdown_timeout" reload:"hot"`      // 停服时等待进行中牌局结束的最长时间
+	BidTimeout          int `json:"bid_timeout" reload:"hot"`           // 抢庄阶段的时限，超时未抢庄的玩家视为不抢
+	DissolveVoteTimeout int `json:"dissolve_vote_timeout" reload:"hot"` // 解散投票的时限
+	MatchMaxWait        int `json:"match_max_wait" reload:"hot"`        // 快速匹配排队超过该时长仍未匹配时开新房间
+	ReconnectTimeout    int `json:"reconnect_timeout" reload:"hot"`     // 断线玩家超过该时长未重连则移出房间
+}
+
+// EconomyConfig 初始分数和平台抽水
+type EconomyConfig struct {
+	InitialScore int64      `json:"initial_score"` // 新玩家的初始分数
+	Rake         RakeConfig `json:"rake"`          // 平台抽水配置
+}
+
+// RakeConfig 平台抽水配置
+type RakeConfig struct {
+	Mode    string `json:"mode"`    // none / percent / flat_fee
+	Percent int64  `json:"percent"` // percent 模式下抽取净赢分的百分比
+	Cap     int64  `json:"cap"`     // percent 模式下每局抽水合计的上限，由本局赢家分摊，0 表示不设上限
+	Fee     int64  `json:"fee"`     // flat_fee 模式下每人每局的台费
 }
 
-// AppConfig 是全局应用程序配置
-var AppConfig *Config
+// StorageConfig 存储位置
+type StorageConfig struct {
+	LedgerPath  string `json:"ledger_path"`  // 钱包账目文件路径
+	SnapshotDir string `json:"snapshot_dir"` // 房间快照目录
+	HistoryDir  string `json:"history_dir"`  // 牌局记录目录，每天一个文件
+	ProfileDir  string `json:"profile_dir"`  // 玩家档案目录，每名玩家一个文件
+	AuditPath   string `json:"audit_path"`   // 审计日志文件路径
+}
+
+// LogConfig 日志配置
+type LogConfig struct {
+	Level      string `json:"level" reload:"hot"` // debug / info / warn / error，运行中也可通过管理后台修改
+	Format     string `json:"format"`             // text / json
+	File       string `json:"file"`               // 日志文件路径，为空时输出到标准输出
+	MaxSizeMB  int    `json:"max_size_mb"`        // 单个日志文件的最大大小（MB），超过后轮转
+	MaxBackups int    `json:"max_backups"`        // 保留的历史日志文件数
+}
 
-func init() {
-	// 为 AppConfig 提供默认值
-	AppConfig = &Config{
-		ServerHost: "0.0.0.0",
-		ServerPort: 8999,
-		Timeout:    5,
+// AuthConfig 登录令牌配置
+type AuthConfig struct {
+	LoginSecret string `json:"login_secret"` // 与账号服务共享的令牌签名密钥，为空时不允许登录，所有连接以游客身份游戏
+}
+
+// AdminConfig 管理后台配置
+type AdminConfig struct {
+	Addr  string `json:"addr"`  // 管理后台监听地址，与游戏端口分开
+	Token string `json:"token"` // 管理令牌，为空时不启动管理后台
+}
+
+// HealthConfig 健康检查配置
+type HealthConfig struct {
+	Addr              string `json:"addr"`                // 健康检查监听地址，为空时不启动
+	MaxRoomGoroutines int64  `json:"max_room_goroutines"` // 房间后台协程数超过该值时未就绪
+	MaxWorkerBacklog  int    `json:"max_worker_backlog"`  // zinx 工作池积压的请求数超过该值时未就绪
+}
+
+// Default 返回默认配置
+func Default() *Config {
+	rooms := logic.DefaultRoomSettings()
+	return &Config{
+		Server: ServerConfig{
+			Name:             "ZinxServerApp",
+			Host:             "0.0.0.0",
+			Port:             8999,
+			MaxConn:          12000,
+			WorkerPoolSize:   10,
+			MaxWorkerTaskLen: 1024,
+		},
+		Rooms: RoomsConfig{
+			BaseBet:       rooms.BaseBet,
+			MaxPlayers:    rooms.MaxPlayers,
+			MinPlayers:    rooms.MinPlayers,
+			Rounds:        rooms.Rounds,
+			MaxSpectators: rooms.MaxSpectators,
+		},
+		Rules: RulesConfig{
+			RuleSet:      "banker_bid",
+			DissolveRule: "unanimous",
+			PayoutPolicy: "proportional",
+			BidMultiples: rooms.BidMultiples,
+			BetMultiples: rooms.BetMultiples,
+		},
+		Timers: TimersConfig{
+			SnapshotInterval:    10,
+			ShutdownTimeout:     60,
+			BidTimeout:          int(logic.DefaultBidTimeout.Seconds()),
+			DissolveVoteTimeout: int(logic.DefaultDissolveVoteTimeout.Seconds()),
+			MatchMaxWait:        10,
+			ReconnectTimeout:    int(logic.DefaultReconnectTimeout.Seconds()),
+		},
+		Economy: EconomyConfig{
+			InitialScore: 1000,
+			Rake:         RakeConfig{Mode: "none"},
+		},
+		Storage: StorageConfig{
+			LedgerPath:  "data/ledger.jsonl",
+			SnapshotDir: "data/snapshots",
+			HistoryDir:  "data/history",
+			ProfileDir:  "data/profiles",
+			AuditPath:   "data/audit.jsonl",
+		},
+		Log:   LogConfig{Level: "info", Format: "text", File: "logs/server.log", MaxSizeMB: 100, MaxBackups: 5},
+		Admin: AdminConfig{Addr: "127.0.0.1:9090"},
+		Health: HealthConfig{
+			Addr:              "0.0.0.0:8080",
+			MaxRoomGoroutines: 10000,
+			MaxWorkerBacklog:  1024,
+		},
 	}
-	LoadConfig("conf/zinx.json")
 }
 
-// LoadConfig 从文件中加载配置
-func LoadConfig(path string) {
-	file, err := os.Open(path)
-	if err != nil {
-		logger.ErrorLogger.Printf("Failed to open config file: %v", err)
-		return
+// Validate 检查配置是否合法，返回所有不合法字段的错误
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, field, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(c.Server.Host != "", "server.host", "must not be empty")
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "server.port", "must be between 1 and 65535")
+	check(c.Server.MaxConn > 0, "server.max_conn", "must be positive")
+	check(c.Server.MaxWorkerTaskLen > 0, "server.max_worker_task_len", "must be positive")
+
+	if settings, err := c.RoomSettings(); err != nil {
+		errs = append(errs, err)
+	} else if err := settings.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("rooms: %w", err))
 	}
-	defer file.Close()
 
-	decoder := json.NewDecoder(file)
-	err = decoder.Decode(AppConfig)
-	if err != nil {
-		logger.ErrorLogger.Printf("Failed to decode config file: %v", err)
-		return
+	check(c.Timers.SnapshotInterval >= 0, "timers.snapshot_interval", "must not be negative")
+	check(c.Timers.ShutdownTimeout >= 0, "timers.shutdown_timeout", "must not be negative")
+	check(c.Timers.BidTimeout > 0, "timers.bid_timeout", "must be positive")
+	check(c.Timers.DissolveVoteTimeout > 0, "timers.dissolve_vote_timeout", "must be positive")
+	check(c.Timers.MatchMaxWait > 0, "timers.match_max_wait", "must be positive")
+	check(c.Timers.ReconnectTimeout > 0, "timers.reconnect_timeout", "must be positive")
+
+	check(c.Economy.InitialScore > 0, "economy.initial_score", "must be positive")
+	if policy, err := c.Economy.Rake.Policy(); err != nil {
+		errs = append(errs, fmt.Errorf("economy.rake: %w", err))
+	} else if err := policy.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("economy.rake: %w", err))
+	}
+
+	check(c.Storage.LedgerPath != "", "storage.ledger_path", "must not be empty")
+	check(c.Storage.SnapshotDir != "", "storage.snapshot_dir", "must not be empty")
+	check(c.Storage.HistoryDir != "", "storage.history_dir", "must not be empty")
+	check(c.Storage.ProfileDir != "", "storage.profile_dir", "must not be empty")
+	check(c.Storage.AuditPath != "", "storage.audit_path", "must not be empty")
+
+	_, err := logger.ParseLevel(c.Log.Level)
+	check(err == nil, "log.level", "must be one of debug, info, warn, error")
+	check(c.Log.Format == "text" || c.Log.Format == "json", "log.format", "must be text or json")
+	check(c.Log.MaxSizeMB >= 0, "log.max_size_mb", "must not be negative")
+	check(c.Log.MaxBackups >= 0, "log.max_backups", "must not be negative")
+
+	check(c.Admin.Token == "" || c.Admin.Addr != "", "admin.addr", "must not be empty when admin.token is set")
+	check(c.Health.MaxRoomGoroutines > 0, "health.max_room_goroutines", "must be positive")
+	check(c.Health.MaxWorkerBacklog > 0, "health.max_worker_backlog", "must be positive")
+
+	return errors.Join(errs...)
+}
+
+// RoomSettings 将房间和玩法配置转换为新建房间的默认设置，抽水策略由 RakeConfig.Policy 单独转换
+func (c *Config) RoomSettings() (logic.RoomSettings, error) {
+	settings := logic.RoomSettings{
+		BaseBet:       c.Rooms.BaseBet,
+		MaxPlayers:    c.Rooms.MaxPlayers,
+		MinPlayers:    c.Rooms.MinPlayers,
+		Rounds:        c.Rooms.Rounds,
+		MaxSpectators: c.Rooms.MaxSpectators,
+		BidMultiples:  c.Rules.BidMultiples,
+		BetMultiples:  c.Rules.BetMultiples,
+		PushBetCap:    c.Rules.PushBetCap,
+		PushCooldown:  c.Rules.PushCooldown,
+	}
+
+	switch c.Rules.RuleSet {
+	case "banker_bid":
+		settings.RuleSet = logic.RULE_BANKER_BID
+	case "free_bid":
+		settings.RuleSet = logic.RULE_FREE_BID
+	case "fixed_banker":
+		settings.RuleSet = logic.RULE_FIXED_BANKER
+	case "all_compare":
+		settings.RuleSet = logic.RULE_ALL_COMPARE
+	default:
+		return settings, fmt.Errorf("rules.rule_set: unknown rule set %q", c.Rules.RuleSet)
+	}
+
+	switch c.Rules.DissolveRule {
+	case "unanimous":
+		settings.DissolveRule = logic.DISSOLVE_UNANIMOUS
+	case "majority":
+		settings.DissolveRule = logic.DISSOLVE_MAJORITY
+	default:
+		return settings, fmt.Errorf("rules.dissolve_rule: unknown dissolve rule %q", c.Rules.DissolveRule)
+	}
+
+	switch c.Rules.PayoutPolicy {
+	case "proportional":
+		settings.PayoutPolicy = logic.PAYOUT_PROPORTIONAL
+	case "seat_order":
+		settings.PayoutPolicy = logic.PAYOUT_SEAT_ORDER
+	default:
+		return settings, fmt.Errorf("rules.payout_policy: unknown payout policy %q", c.Rules.PayoutPolicy)
+	}
+	return settings, nil
+}
+
+// Policy 将抽水配置转换为抽水策略
+func (c RakeConfig) Policy() (logic.RakePolicy, error) {
+	policy := logic.RakePolicy{Percent: c.Percent, Cap: c.Cap, Fee: c.Fee}
+	switch c.Mode {
+	case "", "none":
+		policy.Mode = logic.RAKE_NONE
+	case "percent":
+		policy.Mode = logic.RAKE_PERCENT
+	case "flat_fee":
+		policy.Mode = logic.RAKE_FLAT_FEE
+	default:
+		return policy, fmt.Errorf("unknown rake mode %q", c.Mode)
 	}
-	logger.InfoLogger.Printf("Configuration loaded from %s", path)
+	return policy, nil
 }
@@ -0,0 +1,154 @@
+package conf
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"xizexcample/internal/logic"
+)
+
+// writeConfig 在临时目录中写入配置文件并返回路径
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "server.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+// newTestLoader 创建一个不读取进程环境变量的加载器
+func newTestLoader(path string, env map[string]string) *Loader {
+	return &Loader{
+		Path: path,
+		LookupEnv: func(key string) (string, bool) {
+			value, ok := env[key]
+			return value, ok
+		},
+		Overrides: make(map[string]string),
+	}
+}
+
+func TestDefaultIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("Expected the default config to be valid, got %v", err)
+	}
+
+	settings, err := Default().RoomSettings()
+	if err != nil {
+		t.Fatalf("RoomSettings failed: %v", err)
+	}
+	if !reflect.DeepEqual(settings, logic.DefaultRoomSettings()) {
+		t.Errorf("Expected default room settings %+v, got %+v", logic.DefaultRoomSettings(), settings)
+	}
+}
+
+func TestShippedConfigMatchesDefault(t *testing.T) {
+	cfg, err := newTestLoader("../../conf/server.json", nil).Load()
+	if err != nil {
+		t.Fatalf("Failed to load conf/server.json: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("Expected conf/server.json to match the defaults, got %+v", cfg)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeConfig(t, `{"server":{"port":9000},"log":{"level":"warn"},"rules":{"bet_multiples":[1,2]}}`)
+	loader := newTestLoader(path, map[string]string{
+		"GAME_LOG_LEVEL":           "error",
+		"GAME_ADMIN_TOKEN":         "secret",
+		"GAME_RULES_BID_MULTIPLES": "1,3",
+	})
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	loader.RegisterFlags(fs)
+	if err := fs.Parse([]string{"-log.level", "debug", "-timers.match_max_wait", "30"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Server.Port != 9000 {
+		t.Errorf("Expected port 9000 from the file, got %d", cfg.Server.Port)
+	}
+	if cfg.Server.Host != "0.0.0.0" {
+		t.Errorf("Expected unset fields to keep their defaults, got host %q", cfg.Server.Host)
+	}
+	if !reflect.DeepEqual(cfg.Rules.BetMultiples, []int32{1, 2}) {
+		t.Errorf("Expected bet multiples [1 2] from the file, got %v", cfg.Rules.BetMultiples)
+	}
+	if cfg.Admin.Token != "secret" || !reflect.DeepEqual(cfg.Rules.BidMultiples, []int32{1, 3}) {
+		t.Errorf("Expected environment overrides to apply, got token %q and bid multiples %v", cfg.Admin.Token, cfg.Rules.BidMultiples)
+	}
+	if cfg.Log.Level != "debug" || cfg.Timers.MatchMaxWait != 30 {
+		t.Errorf("Expected flags to override the file and environment, got level %q and match wait %d", cfg.Log.Level, cfg.Timers.MatchMaxWait)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		env     map[string]string
+		wantErr string
+	}{
+		{"unknown field", `{"server":{"prot":9000}}`, nil, "unknown field"},
+		{"bad port", `{"server":{"port":70000}}`, nil, "server.port"},
+		{"bad rule set", `{"rules":{"rule_set":"poker"}}`, nil, "rules.rule_set"},
+		{"bad room settings", `{"rooms":{"min_players":8}}`, nil, "rooms: min players"},
+		{"bad rake", `{"economy":{"rake":{"mode":"percent","percent":150}}}`, nil, "economy.rake"},
+		{"bad log level", `{}`, map[string]string{"GAME_LOG_LEVEL": "verbose"}, "log.level"},
+		{"bad env value", `{}`, map[string]string{"GAME_SERVER_PORT": "abc"}, "GAME_SERVER_PORT"},
+	}
+	for _, tt := range tests {
+		_, err := newTestLoader(writeConfig(t, tt.config), tt.env).Load()
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+
+	// 所有不合法的字段一起报告
+	cfg := Default()
+	cfg.Server.Port = 0
+	cfg.Timers.MatchMaxWait = 0
+	cfg.Log.Format = "xml"
+	err := cfg.Validate()
+	for _, field := range []string{"server.port", "timers.match_max_wait", "log.format"} {
+		if err == nil || !strings.Contains(err.Error(), field) {
+			t.Errorf("Expected the validation error to mention %s, got %v", field, err)
+		}
+	}
+}
+
+func TestRejectsInvalidFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(new(strings.Builder))
+	newTestLoader("", nil).RegisterFlags(fs)
+	if err := fs.Parse([]string{"-server.port", "abc"}); err == nil {
+		t.Error("Expected a non-numeric port flag to be rejected")
+	}
+}
+
+func TestRestartRequired(t *testing.T) {
+	old := Default()
+	next := Default()
+	next.Timers.SnapshotInterval = 30
+	next.Log.Level = "debug"
+	next.Announcement = "tournament at 8pm"
+	if changed := RestartRequired(old, next); len(changed) != 0 {
+		t.Errorf("Expected timers, log level and announcement to reload, got %v", changed)
+	}
+
+	next.Server.Port = 9000
+	next.Log.Format = "json"
+	next.Rules.BetMultiples = []int32{1, 2}
+	changed := RestartRequired(old, next)
+	want := []string{"log.format", "rules.bet_multiples", "server.port"}
+	if !reflect.DeepEqual(changed, want) {
+		t.Errorf("Expected %v to require a restart, got %v", want, changed)
+	}
+}
@@ -0,0 +1,194 @@
+package conf
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix 环境变量覆盖的前缀，配置路径中的点换成下划线并转为大写，例如 GAME_SERVER_PORT、GAME_LOG_LEVEL
+const EnvPrefix = "GAME_"
+
+// Loader 按顺序合并配置的各个来源：默认值、配置文件、环境变量、命令行参数，后者覆盖前者。
+// 每次 Load 都重新读取所有来源，可用于重新加载配置
+type Loader struct {
+	Path      string                          // 配置文件路径，为空时不读取配置文件
+	LookupEnv func(key string) (string, bool) // 读取环境变量，默认为 os.LookupEnv
+	Overrides map[string]string               // 命令行参数指定的值，key 为配置路径，例如 server.port
+}
+
+// NewLoader 创建一个读取默认配置文件和进程环境变量的加载器
+func NewLoader() *Loader {
+	return &Loader{
+		Path:      DefaultPath,
+		LookupEnv: os.LookupEnv,
+		Overrides: make(map[string]string),
+	}
+}
+
+// RegisterFlags 注册 -config 参数和每个配置项对应的参数（例如 -server.port 9000、-log.level debug），
+// 解析后的值在 Load 时覆盖配置文件和环境变量
+func (l *Loader) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&l.Path, "config", l.Path, "path to the server config file")
+	defaults := Default()
+	for _, f := range fields(reflect.ValueOf(defaults).Elem(), "") {
+		path := f.path
+		fs.Func(path, fmt.Sprintf("override %s (default %v)", path, formatValue(f.value)), func(value string) error {
+			if err := setValue(reflect.New(f.value.Type()).Elem(), value); err != nil {
+				return err
+			}
+			l.Overrides[path] = value
+			return nil
+		})
+	}
+}
+
+// Load 读取并合并所有来源的配置，然后校验
+func (l *Loader) Load() (*Config, error) {
+	cfg := Default()
+	if l.Path != "" {
+		data, err := os.ReadFile(l.Path)
+		if err != nil {
+			return nil, fmt.Errorf("read config file: %w", err)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(cfg); err != nil {
+			return nil, fmt.Errorf("decode config file %s: %w", l.Path, err)
+		}
+	}
+
+	lookupEnv := l.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	for _, f := range fields(reflect.ValueOf(cfg).Elem(), "") {
+		if value, ok := lookupEnv(EnvName(f.path)); ok {
+			if err := setValue(f.value, value); err != nil {
+				return nil, fmt.Errorf("environment variable %s: %w", EnvName(f.path), err)
+			}
+		}
+		if value, ok := l.Overrides[f.path]; ok {
+			if err := setValue(f.value, value); err != nil {
+				return nil, fmt.Errorf("flag -%s: %w", f.path, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// EnvName 返回配置路径对应的环境变量名
+func EnvName(path string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+}
+
+// RestartRequired 返回两份配置之间需要重启才能生效的变化，按配置路径排序
+func RestartRequired(old, new *Config) []string {
+	oldFields := fields(reflect.ValueOf(old).Elem(), "")
+	newFields := fields(reflect.ValueOf(new).Elem(), "")
+
+	var changed []string
+	for i, f := range oldFields {
+		if !f.hot && !reflect.DeepEqual(f.value.Interface(), newFields[i].value.Interface()) {
+			changed = append(changed, f.path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// field 一个配置项
+type field struct {
+	path  string        // 配置路径，由 json 标签以点连接，例如 log.level
+	value reflect.Value // 配置项的值，可写
+	hot   bool          // 是否可以在运行中重新加载
+}
+
+// fields 按声明顺序列出结构体中的所有配置项，嵌套的结构体展开为其字段
+func fields(v reflect.Value, prefix string) []field {
+	var result []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		path := prefix + name
+		if sf.Type.Kind() == reflect.Struct {
+			nested := fields(v.Field(i), path+".")
+			if sf.Tag.Get("reload") == "hot" {
+				for j := range nested {
+					nested[j].hot = true
+				}
+			}
+			result = append(result, nested...)
+			continue
+		}
+		result = append(result, field{path: path, value: v.Field(i), hot: sf.Tag.Get("reload") == "hot"})
+	}
+	return result
+}
+
+// setValue 将字符串解析为配置项的类型并赋值，切片以逗号分隔
+func setValue(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		v.SetInt(n)
+	case reflect.Uint32:
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", value)
+		}
+		v.SetUint(n)
+	case reflect.Slice:
+		var parts []string
+		if strings.TrimSpace(value) != "" {
+			parts = strings.Split(value, ",")
+		}
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setValue(slice.Index(i), part); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+	return nil
+}
+
+// formatValue 将配置项的值格式化为命令行参数的写法
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Slice {
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
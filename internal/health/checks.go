@@ -0,0 +1,82 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aceld/zinx/ziface"
+	"github.com/aceld/zinx/znet"
+	"os"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/server"
+)
+
+// Check 一项就绪检查，Run 返回错误表示未就绪
+type Check struct {
+	Name string
+	Run  func() error
+}
+
+// NotDraining 停服排空中的实例未就绪
+func NotDraining(rm *server.RoomManager) Check {
+	return Check{Name: "draining", Run: func() error {
+		if rm.IsDraining() {
+			return errors.New("server is draining")
+		}
+		return nil
+	}}
+}
+
+// NotInMaintenance 维护模式中的实例未就绪
+func NotInMaintenance(rm *server.RoomManager) Check {
+	return Check{Name: "maintenance", Run: func() error {
+		if rm.InMaintenance() {
+			return errors.New("server is in maintenance mode")
+		}
+		return nil
+	}}
+}
+
+// WritableDir 检查存储目录可写：在目录中创建并删除一个临时文件
+func WritableDir(name, dir string) Check {
+	return Check{Name: "storage:" + name, Run: func() error {
+		file, err := os.CreateTemp(dir, ".health-*")
+		if err != nil {
+			return fmt.Errorf("%s is not writable: %w", dir, err)
+		}
+		file.Close()
+		return os.Remove(file.Name())
+	}}
+}
+
+// RoomGoroutines 房间后台协程数超过上限时未就绪，通常说明有房间没有被关闭
+func RoomGoroutines(max int64) Check {
+	return Check{Name: "room_goroutines", Run: func() error {
+		if n := logic.RoomGoroutines(); n > max {
+			return fmt.Errorf("%d room goroutines exceed the limit of %d", n, max)
+		}
+		return nil
+	}}
+}
+
+// WorkerBacklog zinx 工作池任务队列中积压的请求数超过上限时未就绪
+func WorkerBacklog(game ziface.IServer, max int) Check {
+	return Check{Name: "worker_backlog", Run: func() error {
+		if n := workerBacklog(game); n > max {
+			return fmt.Errorf("%d queued requests exceed the limit of %d", n, max)
+		}
+		return nil
+	}}
+}
+
+// workerBacklog 返回 zinx 工作池任务队列中积压的请求总数，未使用工作池时为 0
+func workerBacklog(game ziface.IServer) int {
+	handler, ok := game.GetMsgHandler().(*znet.MsgHandle)
+	if !ok {
+		return 0
+	}
+	backlog := 0
+	for _, queue := range handler.TaskQueue {
+		backlog += len(queue)
+	}
+	return backlog
+}
@@ -0,0 +1,133 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+	"xizexcample/internal/pkg/logger"
+)
+
+// Server 健康检查 HTTP 服务，供编排系统探测，与游戏端口和管理后台分开，不需要令牌。
+// GET /healthz 只要进程能响应就返回 200；GET /readyz 在所有就绪检查都通过时返回 200，否则返回 503
+type Server struct {
+	version string
+	commit  string
+	started time.Time
+	checks  []Check
+	mux     *http.ServeMux
+	http    *http.Server
+}
+
+// Status 健康检查的响应
+type Status struct {
+	Status  string        `json:"status"` // ok / ready / not_ready
+	Version string        `json:"version"`
+	Commit  string        `json:"commit"`
+	Uptime  int64         `json:"uptime_seconds"`
+	Checks  []CheckResult `json:"checks,omitempty"`
+}
+
+// CheckResult 一项就绪检查的结果
+type CheckResult struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message,omitempty"` // 未通过的原因
+}
+
+// NewServer 创建健康检查服务，version 和 commit 来自编译时注入的版本信息
+func NewServer(version, commit string, checks ...Check) *Server {
+	s := &Server{
+		version: version,
+		commit:  commit,
+		started: time.Now(),
+		checks:  checks,
+		mux:     http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /healthz", s.healthz)
+	s.mux.HandleFunc("GET /readyz", s.readyz)
+	return s
+}
+
+// Handler 返回健康检查的 HTTP 处理器
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// Start 在指定地址上启动健康检查服务，监听失败时返回错误
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.http = &http.Server{
+		Handler:           s.mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := s.http.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.L().Error("Health server stopped", logger.Err(err))
+		}
+	}()
+	logger.L().Info("Health server listening", "addr", listener.Addr().String())
+	return nil
+}
+
+// Shutdown 停止健康检查服务
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.http == nil {
+		return nil
+	}
+	return s.http.Shutdown(ctx)
+}
+
+// Ready 运行所有就绪检查，返回是否全部通过和每一项的结果
+func (s *Server) Ready() (bool, []CheckResult) {
+	ready := true
+	results := make([]CheckResult, len(s.checks))
+	for i, check := range s.checks {
+		results[i] = CheckResult{Name: check.Name, OK: true}
+		if err := check.Run(); err != nil {
+			ready = false
+			results[i].OK = false
+			results[i].Message = err.Error()
+		}
+	}
+	return ready, results
+}
+
+// healthz 存活检查，进程能响应即为存活
+func (s *Server) healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.status("ok", nil))
+}
+
+// readyz 就绪检查，任何一项未通过时返回 503，编排系统不再把流量导向本实例
+func (s *Server) readyz(w http.ResponseWriter, r *http.Request) {
+	ready, results := s.Ready()
+	if !ready {
+		writeJSON(w, http.StatusServiceUnavailable, s.status("not_ready", results))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.status("ready", results))
+}
+
+// status 构建响应
+func (s *Server) status(status string, checks []CheckResult) Status {
+	return Status{
+		Status:  status,
+		Version: s.version,
+		Commit:  s.commit,
+		Uptime:  int64(time.Since(s.started).Seconds()),
+		Checks:  checks,
+	}
+}
+
+// writeJSON 以 JSON 格式写回响应
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,111 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/aceld/zinx/ziface"
+	"github.com/aceld/zinx/znet"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"xizexcample/internal/server"
+)
+
+// get 发起请求并解析响应
+func get(t *testing.T, h http.Handler, path string) (int, Status) {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var status Status
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatalf("Failed to decode %s response: %v", path, err)
+	}
+	return rec.Code, status
+}
+
+func TestHealthzAndReadyz(t *testing.T) {
+	var failure error
+	s := NewServer("1.2.3", "abc123",
+		Check{Name: "always", Run: func() error { return nil }},
+		Check{Name: "toggle", Run: func() error { return failure }},
+	)
+	h := s.Handler()
+
+	code, status := get(t, h, "/healthz")
+	if code != http.StatusOK || status.Status != "ok" || status.Version != "1.2.3" || status.Commit != "abc123" {
+		t.Errorf("Expected healthz 200 with version info, got %d %+v", code, status)
+	}
+
+	code, status = get(t, h, "/readyz")
+	if code != http.StatusOK || status.Status != "ready" || len(status.Checks) != 2 {
+		t.Errorf("Expected readyz 200 with 2 checks, got %d %+v", code, status)
+	}
+
+	failure = errors.New("backend down")
+	code, status = get(t, h, "/readyz")
+	if code != http.StatusServiceUnavailable || status.Status != "not_ready" {
+		t.Errorf("Expected readyz 503 when a check fails, got %d %+v", code, status)
+	}
+	if !status.Checks[0].OK || status.Checks[1].OK || status.Checks[1].Message != "backend down" {
+		t.Errorf("Expected only the toggle check to fail, got %+v", status.Checks)
+	}
+
+	// 未就绪不影响存活检查
+	if code, _ := get(t, h, "/healthz"); code != http.StatusOK {
+		t.Errorf("Expected healthz 200 while not ready, got %d", code)
+	}
+}
+
+func TestMaintenanceCheck(t *testing.T) {
+	rm := server.GetRoomManager()
+	check := NotInMaintenance(rm)
+	defer rm.SetMaintenance(false, "")
+
+	if err := check.Run(); err != nil {
+		t.Errorf("Expected the maintenance check to pass, got %v", err)
+	}
+	rm.SetMaintenance(true, "upgrade")
+	if err := check.Run(); err == nil {
+		t.Error("Expected the maintenance check to fail in maintenance mode")
+	}
+	if err := NotDraining(rm).Run(); err != nil {
+		t.Errorf("Expected the draining check to pass, got %v", err)
+	}
+}
+
+func TestWritableDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := WritableDir("data", dir).Run(); err != nil {
+		t.Errorf("Expected a temp dir to be writable, got %v", err)
+	}
+	if err := WritableDir("data", filepath.Join(dir, "missing")).Run(); err == nil {
+		t.Error("Expected a missing directory to fail the storage check")
+	}
+}
+
+func TestThresholdChecks(t *testing.T) {
+	if err := RoomGoroutines(-1).Run(); err == nil {
+		t.Error("Expected the room goroutine check to fail above the limit")
+	}
+	if err := RoomGoroutines(1000).Run(); err != nil {
+		t.Errorf("Expected the room goroutine check to pass, got %v", err)
+	}
+
+	game := znet.NewServer()
+	if err := WorkerBacklog(game, 0).Run(); err != nil {
+		t.Errorf("Expected an idle server to have no backlog, got %v", err)
+	}
+
+	// 模拟一个 worker 的任务队列中积压了两个请求
+	handler := game.GetMsgHandler().(*znet.MsgHandle)
+	queue := make(chan ziface.IRequest, 2)
+	queue <- nil
+	queue <- nil
+	handler.TaskQueue[0] = queue
+	if err := WorkerBacklog(game, 1).Run(); err == nil {
+		t.Error("Expected the worker backlog check to fail above the limit")
+	}
+	if err := WorkerBacklog(game, 2).Run(); err != nil {
+		t.Errorf("Expected the worker backlog check to pass at the limit, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package history
+
+import (
+	"time"
+	"xizexcample/internal/logic"
+)
+
+// PlayerRecord 一名玩家在一局中的牌局记录
+type PlayerRecord struct {
+	PlayerID    int64          `json:"player_id"`
+	Seat        int            `json:"seat"`
+	IsBanker    bool           `json:"is_banker"`
+	BidMultiple int32          `json:"bid_multiple"` // 抢庄倍数，0 表示不抢
+	BetMultiple int32          `json:"bet_multiple"` // 下注倍数，庄家为0
+	Hand        []logic.Card   `json:"hand"`
+	CardType    logic.CardType `json:"card_type"`
+	ScoreChange int64          `json:"score_change"` // 已扣除抽水
+	Rake        int64          `json:"rake"`
+	FinalScore  int64          `json:"final_score"`
+}
+
+// Record 一局的牌局记录，包含复盘一局所需的全部信息
+type Record struct {
+	RoundID      string             `json:"round_id"`
+	RoomID       int32              `json:"room_id"`
+	Round        int                `json:"round"`
+	RuleSet      logic.RuleSet      `json:"rule_set"`
+	BaseBet      int64              `json:"base_bet"`
+	MaxPlayers   int                `json:"max_players"`
+	PayoutPolicy logic.PayoutPolicy `json:"payout_policy"`
+	Rake         logic.RakePolicy   `json:"rake"`
+	BankerID     int64              `json:"banker_id"` // 通比牛牛为0
+	ShuffleSeed  int64              `json:"shuffle_seed"`
+	SettledAt    time.Time          `json:"settled_at"`
+	Players      []PlayerRecord     `json:"players"` // 按座位号排列
+}
+
+// NewRecord 根据房间设置和一局的结算结果生成牌局记录
+func NewRecord(roomID int32, settings logic.RoomSettings, result *logic.RoundResult) Record {
+	record := Record{
+		RoundID:      result.RoundID,
+		RoomID:       roomID,
+		Round:        result.Round,
+		RuleSet:      settings.RuleSet,
+		BaseBet:      settings.BaseBet,
+		MaxPlayers:   settings.MaxPlayers,
+		PayoutPolicy: settings.PayoutPolicy,
+		Rake:         settings.Rake,
+		BankerID:     result.BankerID,
+		ShuffleSeed:  result.ShuffleSeed,
+		SettledAt:    result.SettledAt,
+		Players:      make([]PlayerRecord, 0, len(result.Results)),
+	}
+	for _, r := range result.Results {
+		record.Players = append(record.Players, PlayerRecord{
+			PlayerID:    r.PlayerID,
+			Seat:        r.Seat,
+			IsBanker:    r.PlayerID == result.BankerID,
+			BidMultiple: r.BidMultiple,
+			BetMultiple: r.BetMultiple,
+			Hand:        r.Hand,
+			CardType:    r.CardType,
+			ScoreChange: r.ScoreChange,
+			Rake:        r.Rake,
+			FinalScore:  r.FinalScore,
+		})
+	}
+	return record
+}
+
+// HasPlayer 检查玩家是否参与了这一局
+func (r *Record) HasPlayer(playerID int64) bool {
+	for _, p := range r.Players {
+		if p.PlayerID == playerID {
+			return true
+		}
+	}
+	return false
+}
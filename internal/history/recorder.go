@@ -0,0 +1,202 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// filePrefix 牌局记录文件名前缀，文件名形如 history-2006-01-02.jsonl
+const filePrefix = "history-"
+
+// Recorder 牌局记录器
+type Recorder interface {
+	// Record 追加一条牌局记录
+	Record(record Record) error
+}
+
+// MemoryRecorder 内存中的牌局记录器
+type MemoryRecorder struct {
+	records []Record
+	mu      sync.Mutex
+}
+
+// NewMemoryRecorder 创建一个内存牌局记录器
+func NewMemoryRecorder() *MemoryRecorder {
+	return &MemoryRecorder{}
+}
+
+// Record 追加一条牌局记录
+func (m *MemoryRecorder) Record(record Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records = append(m.records, record)
+	return nil
+}
+
+// Records 返回所有牌局记录的副本
+func (m *MemoryRecorder) Records() []Record {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Record(nil), m.records...)
+}
+
+// FileRecorder 按天写入 JSON Lines 文件的牌局记录器，只追加不修改。
+// 记录按结算时间（UTC）的日期写入对应的文件
+type FileRecorder struct {
+	dir  string
+	day  string
+	file *os.File
+	mu   sync.Mutex
+}
+
+// NewFileRecorder 打开或创建牌局记录目录
+func NewFileRecorder(dir string) (*FileRecorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create history directory: %w", err)
+	}
+	return &FileRecorder{dir: dir}, nil
+}
+
+// fileName 返回某一天的牌局记录文件名
+func fileName(t time.Time) string {
+	return filePrefix + t.UTC().Format("2006-01-02") + ".jsonl"
+}
+
+// Record 将牌局记录追加到当天的文件并刷盘
+func (f *FileRecorder) Record(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	settledAt := record.SettledAt
+	if settledAt.IsZero() {
+		settledAt = time.Now()
+	}
+	if day := fileName(settledAt); day != f.day {
+		file, err := os.OpenFile(filepath.Join(f.dir, day), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("open history file: %w", err)
+		}
+		if f.file != nil {
+			f.file.Close()
+		}
+		f.file, f.day = file, day
+	}
+
+	if _, err := f.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write history file: %w", err)
+	}
+	return f.file.Sync()
+}
+
+// Close 关闭当前的牌局记录文件
+func (f *FileRecorder) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file, f.day = nil, ""
+	return err
+}
+
+var (
+	recorderInstance Recorder = NewMemoryRecorder()
+	recorderMu       sync.Mutex
+)
+
+// GetRecorder 获取全局牌局记录器，未通过 SetRecorder 指定时使用内存记录器
+func GetRecorder() Recorder {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	return recorderInstance
+}
+
+// SetRecorder 替换全局牌局记录器，应在服务启动时调用
+func SetRecorder(r Recorder) {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	recorderInstance = r
+}
+
+// Filter 牌局记录的查询条件，零值字段表示不过滤
+type Filter struct {
+	PlayerID int64
+	RoundID  string
+}
+
+// match 检查牌局记录是否满足查询条件
+func (f Filter) match(record *Record) bool {
+	if f.RoundID != "" && record.RoundID != f.RoundID {
+		return false
+	}
+	if f.PlayerID != 0 && !record.HasPlayer(f.PlayerID) {
+		return false
+	}
+	return true
+}
+
+// Query 按日期顺序读取目录中的所有牌局记录文件，返回满足条件的记录
+func Query(dir string, filter Filter) ([]Record, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read history directory: %w", err)
+	}
+	var names []string
+	for _, file := range files {
+		if !file.IsDir() && strings.HasPrefix(file.Name(), filePrefix) && strings.HasSuffix(file.Name(), ".jsonl") {
+			names = append(names, file.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var result []Record
+	for _, name := range names {
+		records, err := readFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		for i := range records {
+			if filter.match(&records[i]) {
+				result = append(result, records[i])
+			}
+		}
+	}
+	return result, nil
+}
+
+// readFile 读取一个牌局记录文件
+func readFile(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open history file: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", filepath.Base(path), line, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
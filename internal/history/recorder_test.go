@@ -0,0 +1,84 @@
+package history
+
+import (
+	"os"
+	"testing"
+	"time"
+	"xizexcample/internal/logic"
+)
+
+func TestNewRecord(t *testing.T) {
+	settings := logic.DefaultRoomSettings()
+	settings.BaseBet = 5
+	result := &logic.RoundResult{
+		RoundID:     "101-1",
+		Round:       2,
+		BankerID:    1,
+		ShuffleSeed: 42,
+		Results: []logic.PlayerResult{
+			{PlayerID: 1, Seat: 1, BidMultiple: 2, ScoreChange: -30, FinalScore: 970},
+			{PlayerID: 2, Seat: 2, BetMultiple: 3, ScoreChange: 30, FinalScore: 1030},
+		},
+	}
+
+	record := NewRecord(101, settings, result)
+	if record.RoomID != 101 || record.BaseBet != 5 || record.ShuffleSeed != 42 || record.Round != 2 {
+		t.Errorf("Unexpected record header: %+v", record)
+	}
+	if !record.Players[0].IsBanker || record.Players[1].IsBanker {
+		t.Error("Expected only player 1 to be marked as the banker")
+	}
+	if record.Players[1].BetMultiple != 3 || record.Players[0].BidMultiple != 2 {
+		t.Errorf("Expected bids and bets to be recorded, got %+v", record.Players)
+	}
+}
+
+func TestFileRecorderAndQuery(t *testing.T) {
+	dir := t.TempDir()
+	recorder, err := NewFileRecorder(dir)
+	if err != nil {
+		t.Fatalf("NewFileRecorder failed: %v", err)
+	}
+
+	day1 := time.Date(2026, 3, 1, 23, 59, 0, 0, time.UTC)
+	day2 := day1.Add(2 * time.Minute)
+	records := []Record{
+		{RoundID: "r1", SettledAt: day1, Players: []PlayerRecord{{PlayerID: 1}, {PlayerID: 2}}},
+		{RoundID: "r2", SettledAt: day1, Players: []PlayerRecord{{PlayerID: 2}, {PlayerID: 3}}},
+		{RoundID: "r3", SettledAt: day2, Players: []PlayerRecord{{PlayerID: 1}, {PlayerID: 3}}},
+	}
+	for _, r := range records {
+		if err := recorder.Record(r); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+	recorder.Close()
+
+	// 每天一个文件
+	files, _ := os.ReadDir(dir)
+	if len(files) != 2 || files[0].Name() != "history-2026-03-01.jsonl" || files[1].Name() != "history-2026-03-02.jsonl" {
+		t.Fatalf("Expected one history file per day, got %v", files)
+	}
+
+	found, err := Query(dir, Filter{PlayerID: 1})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(found) != 2 || found[0].RoundID != "r1" || found[1].RoundID != "r3" {
+		t.Errorf("Expected rounds r1 and r3 for player 1, got %v", found)
+	}
+
+	found, _ = Query(dir, Filter{RoundID: "r2"})
+	if len(found) != 1 || !found[0].HasPlayer(3) {
+		t.Errorf("Expected round r2, got %v", found)
+	}
+
+	// 重新打开后继续追加到同一天的文件
+	recorder, _ = NewFileRecorder(dir)
+	recorder.Record(Record{RoundID: "r4", SettledAt: day2, Players: []PlayerRecord{{PlayerID: 1}}})
+	recorder.Close()
+	found, _ = Query(dir, Filter{PlayerID: 1})
+	if len(found) != 3 || found[2].RoundID != "r4" {
+		t.Errorf("Expected appended round r4, got %v", found)
+	}
+}
@@ -0,0 +1,139 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+	"xizexcample/internal/wallet"
+)
+
+// maxLoss 一手牌按最大牌型倍数计算可能输掉的最大分数
+func maxLoss(baseBet, bankerMultiple, betMultiple int64) int64 {
+	if bankerMultiple < 1 {
+		bankerMultiple = 1
+	}
+	if betMultiple < 1 {
+		betMultiple = 1
+	}
+	return baseBet * bankerMultiple * betMultiple * MaxCardTypeMultiplier
+}
+
+// insufficient 构造余额不足的错误
+func insufficient(balance, required int64) error {
+	return fmt.Errorf("%w: need %d, have %d", wallet.ErrInsufficientBalance, required, balance)
+}
+
+// CheckPlayBalance 检查玩家的余额是否足够参与一局，至少要输得起一倍底注的最大牌型
+func (r *Room) CheckPlayBalance(player *Player) error {
+	balance := r.GetWallet().Balance(player.ID)
+	if required := maxLoss(r.Settings.BaseBet, 1, 1); balance < required {
+		return insufficient(balance, required)
+	}
+	return nil
+}
+
+// CheckBidBalance 检查玩家的余额是否足够以指定倍数抢庄，
+// 庄家至少要赔得起每位闲家以一倍下注拿到最大牌型
+func (r *Room) CheckBidBalance(player *Player, multiple int32) error {
+	if multiple <= 0 {
+		return nil
+	}
+	opponents := int64(0)
+	for _, p := range r.GetPlayers() {
+		if p.ID != player.ID && len(p.GetHand()) > 0 {
+			opponents++
+		}
+	}
+	balance := r.GetWallet().Balance(player.ID)
+	if required := maxLoss(r.Settings.BaseBet, int64(multiple), 1) * opponents; balance < required {
+		return insufficient(balance, required)
+	}
+	return nil
+}
+
+// CheckBetBalance 检查闲家能否以指定倍数下注：闲家要输得起这一注，
+// 庄家也要赔得起包括这一注在内的所有已下注
+func (r *Room) CheckBetBalance(player *Player, multiple int32) error {
+	var banker *Player
+	exposure := int64(0)
+	for _, p := range r.GetPlayers() {
+		if p.IsBanker() {
+			banker = p
+		}
+	}
+	if banker == nil {
+		return nil
+	}
+	bankerMultiple := int64(banker.GetBidMultiple())
+	for _, p := range r.GetPlayers() {
+		if p.ID != player.ID && p.HasBet() {
+			exposure += maxLoss(r.Settings.BaseBet, bankerMultiple, int64(p.GetBetAmount()))
+		}
+	}
+
+	w := r.GetWallet()
+	stake := maxLoss(r.Settings.BaseBet, bankerMultiple, int64(multiple))
+	if balance := w.Balance(player.ID); balance < stake {
+		return insufficient(balance, stake)
+	}
+	if balance := w.Balance(banker.ID); balance < exposure+stake {
+		return fmt.Errorf("banker cannot cover this bet: %w", insufficient(balance, exposure+stake))
+	}
+	return nil
+}
+
+// CheckBidMultiple 检查抢庄倍数是否为玩法允许的倍数且余额足够，0 表示不抢庄
+func (r *Room) CheckBidMultiple(player *Player, multiple int32) error {
+	if multiple == 0 {
+		return nil
+	}
+	if !slices.Contains(r.bidMultiples(), multiple) {
+		return fmt.Errorf("bid multiple %d is not allowed in this room", multiple)
+	}
+	return r.CheckBidBalance(player, multiple)
+}
+
+// bidMultiples 返回玩法允许的抢庄倍数，自由抢庄只能以一倍抢庄
+func (r *Room) bidMultiples() []int32 {
+	if r.Settings.RuleSet == RULE_FREE_BID {
+		return freeBidMultiples
+	}
+	return r.Settings.BidMultiples
+}
+
+// AllowedBidMultiples 返回玩家当前可以选择的抢庄倍数，即玩法允许且余额足够坐庄的倍数
+func (r *Room) AllowedBidMultiples(player *Player) []int32 {
+	bidMultiples := r.bidMultiples()
+	allowed := make([]int32, 0, len(bidMultiples))
+	for _, multiple := range bidMultiples {
+		if r.CheckBidBalance(player, multiple) == nil {
+			allowed = append(allowed, multiple)
+		}
+	}
+	return allowed
+}
+
+// CheckBetMultiple 检查下注倍数是否为房间允许的倍数或玩家本局可用的推注倍数，且闲家和庄家的余额都足够
+func (r *Room) CheckBetMultiple(player *Player, multiple int32) error {
+	if !slices.Contains(r.Settings.BetMultiples, multiple) && (multiple == 0 || multiple != r.PushBetMultiple(player)) {
+		return fmt.Errorf("bet multiple %d is not allowed in this room", multiple)
+	}
+	return r.CheckBetBalance(player, multiple)
+}
+
+// AllowedBetMultiples 返回闲家当前可以选择的下注倍数，可以推注时推注倍数排在最后；庄家不下注，返回空列表
+func (r *Room) AllowedBetMultiples(player *Player) []int32 {
+	allowed := make([]int32, 0, len(r.Settings.BetMultiples)+1)
+	if player.IsBanker() {
+		return allowed
+	}
+	candidates := r.Settings.BetMultiples
+	if push := r.PushBetMultiple(player); push > 0 {
+		candidates = append(slices.Clone(candidates), push)
+	}
+	for _, multiple := range candidates {
+		if r.CheckBetBalance(player, multiple) == nil {
+			allowed = append(allowed, multiple)
+		}
+	}
+	return allowed
+}
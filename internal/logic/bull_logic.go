@@ -1,6 +1,7 @@
 package logic
 
 import (
+	"fmt"
 	"sort"
 )
 
@@ -24,6 +25,26 @@ const (
 	CARD_TYPE_GOLDEN_FLOWER                 // 金花 (同花顺)
 )
 
+// String 返回牌型的名称
+func (t CardType) String() string {
+	switch {
+	case t == CARD_TYPE_NO_BULL:
+		return "No Bull"
+	case t >= CARD_TYPE_BULL_1 && t <= CARD_TYPE_BULL_9:
+		return fmt.Sprintf("Bull %d", int(t))
+	case t == CARD_TYPE_BULL_BOMB:
+		return "Bull Bull"
+	case t == CARD_TYPE_FIVE_SMALL:
+		return "Five Small"
+	case t == CARD_TYPE_BOMB:
+		return "Bomb"
+	case t == CARD_TYPE_GOLDEN_FLOWER:
+		return "Golden Flower"
+	default:
+		return "Unknown"
+	}
+}
+
 // CalculateBull 计算牛牛牌型和牛值
 func CalculateBull(cards []*Card) (CardType, uint32) {
 	if len(cards) != 5 {
@@ -131,7 +131,12 @@ func (d *Deck) Reset() {
 
 // Shuffle 洗牌
 func (d *Deck) Shuffle() {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	d.ShuffleWithSeed(time.Now().UnixNano())
+}
+
+// ShuffleWithSeed 使用指定的种子洗牌，相同的种子对一副新牌总是洗出相同的顺序，用于复盘
+func (d *Deck) ShuffleWithSeed(seed int64) {
+	r := rand.New(rand.NewSource(seed))
 	r.Shuffle(len(d.cards), func(i, j int) {
 		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
 	})
@@ -163,6 +168,16 @@ func (d *Deck) DealCards(num int) ([]Card, error) {
 	return cards, nil
 }
 
+// Cards 获取牌堆中剩余的牌的副本，按发牌顺序排列
+func (d *Deck) Cards() []Card {
+	return append([]Card(nil), d.cards...)
+}
+
+// NewDeckFromCards 按给定的顺序创建牌堆，用于从快照恢复
+func NewDeckFromCards(cards []Card) *Deck {
+	return &Deck{cards: append(make([]Card, 0, 52), cards...)}
+}
+
 // GetCardCount 获取牌堆中剩余的牌数
 func (d *Deck) GetCardCount() int {
 	return len(d.cards)
@@ -46,6 +46,24 @@ func TestDeckShuffle(t *testing.T) {
 	}
 }
 
+func TestDeckShuffleWithSeed(t *testing.T) {
+	deck1 := NewDeck()
+	deck2 := NewDeck()
+	deck3 := NewDeck()
+
+	// 相同的种子洗出相同的顺序
+	deck1.ShuffleWithSeed(42)
+	deck2.ShuffleWithSeed(42)
+	if !reflect.DeepEqual(deck1.cards, deck2.cards) {
+		t.Error("Expected the same seed to produce the same order")
+	}
+
+	deck3.ShuffleWithSeed(43)
+	if reflect.DeepEqual(deck1.cards, deck3.cards) {
+		t.Error("Expected different seeds to produce different orders")
+	}
+}
+
 func TestDeckDeal(t *testing.T) {
 	deck := NewDeck()
 
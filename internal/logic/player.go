@@ -5,18 +5,35 @@ import (
 	"sync"
 )
 
+// InitialScore 新玩家的初始分数，启动时按配置设置，运行中不再修改
+var InitialScore int64 = 1000
+
+// IsGuest 检查玩家ID是否属于未登录的游客。游客ID为负数，只在本次启动内有效：
+// 游客不随快照恢复，重启后也不能重连
+func IsGuest(playerID int64) bool {
+	return playerID < 0
+}
+
 // Player 表示一个玩家
 type Player struct {
 	ID       int64
 	Nickname string
 	Score    int64
 	RoomID   int32 // 0 表示不在任何房间中
+	Seat     int   // 座位号，从1开始，0 表示未入座
 
 	// 游戏相关状态
-	Hand      []Card
-	BetAmount int32
-	Status    PlayerStatus
-	isBanker  bool
+	Hand        []Card
+	BetAmount   int32
+	Status      PlayerStatus
+	isBanker    bool
+	bidMultiple int32 // 抢庄倍数，0 表示不抢
+	hasBid      bool  // 本局是否已抢庄（包括选择不抢）
+	hasShown    bool  // 本局是否已摊牌
+
+	// 推注相关状态，跨局保留
+	lastWin      int64 // 上一局作为闲家赢得的分数，没赢或没参与为0
+	pushCooldown int   // 还需等待几局才能再次推注
 
 	// 连接相关
 	isOnline       bool
@@ -31,7 +48,7 @@ func NewPlayer(id int64, nickname string, conn ziface.IConnection) *Player {
 	return &Player{
 		ID:       id,
 		Nickname: nickname,
-		Score:    1000, // 初始分数
+		Score:    InitialScore,
 		RoomID:   0,
 		Hand:     make([]Card, 0),
 		Status:   STATUS_WAITING,
@@ -75,6 +92,15 @@ func (p *Player) AddCard(card Card) {
 	p.Hand = append(p.Hand, card)
 }
 
+// GetHand 获取手牌的副本
+func (p *Player) GetHand() []Card {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	hand := make([]Card, len(p.Hand))
+	copy(hand, p.Hand)
+	return hand
+}
+
 // ClearHand 清空手牌
 func (p *Player) ClearHand() {
 	p.mu.Lock()
@@ -96,6 +122,20 @@ func (p *Player) GetRoomID() int32 {
 	return p.RoomID
 }
 
+// SetSeat 设置玩家的座位号
+func (p *Player) SetSeat(seat int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Seat = seat
+}
+
+// GetSeat 获取玩家的座位号
+func (p *Player) GetSeat() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Seat
+}
+
 // SetBanker 设置玩家为庄家
 func (p *Player) SetBanker(isBanker bool) {
 	p.mu.Lock()
@@ -110,6 +150,93 @@ func (p *Player) IsBanker() bool {
 	return p.isBanker
 }
 
+// SetBidMultiple 记录抢庄倍数
+func (p *Player) SetBidMultiple(multiple int32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.bidMultiple = multiple
+}
+
+// PlaceBid 记录玩家本局的抢庄倍数，0 表示不抢
+func (p *Player) PlaceBid(multiple int32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.bidMultiple = multiple
+	p.hasBid = true
+}
+
+// HasBid 检查玩家本局是否已抢庄（包括选择不抢）
+func (p *Player) HasBid() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.hasBid
+}
+
+// GetBidMultiple 获取抢庄倍数
+func (p *Player) GetBidMultiple() int32 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.bidMultiple
+}
+
+// SetShown 标记玩家本局已摊牌
+func (p *Player) SetShown() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hasShown = true
+}
+
+// HasShown 检查玩家本局是否已摊牌
+func (p *Player) HasShown() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.hasShown
+}
+
+// SetScore 同步玩家分数，分数以钱包余额为准
+func (p *Player) SetScore(score int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Score = score
+}
+
+// GetScore 获取玩家分数
+func (p *Player) GetScore() int64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Score
+}
+
+// resetRound 清除玩家上一局的牌局状态
+func (p *Player) resetRound() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Hand = make([]Card, 0)
+	p.BetAmount = 0
+	p.isBanker = false
+	p.bidMultiple = 0
+	p.hasBid = false
+	p.hasShown = false
+	if p.Status == STATUS_PLAYING {
+		p.Status = STATUS_WAITING
+	}
+}
+
+// setPushState 记录本局结束后的推注状态
+func (p *Player) setPushState(lastWin int64, cooldown int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastWin = lastWin
+	p.pushCooldown = cooldown
+}
+
+// pushState 获取上一局作为闲家赢得的分数和剩余的推注冷却局数
+func (p *Player) pushState() (int64, int) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.lastWin, p.pushCooldown
+}
+
 // PlaceBet 下注
 func (p *Player) PlaceBet(amount int32) {
 	p.mu.Lock()
@@ -148,3 +275,19 @@ const (
 	STATUS_PLAYING
 	STATUS_OFFLINE
 )
+
+// String 返回玩家状态的名称
+func (s PlayerStatus) String() string {
+	switch s {
+	case STATUS_WAITING:
+		return "waiting"
+	case STATUS_READY:
+		return "ready"
+	case STATUS_PLAYING:
+		return "playing"
+	case STATUS_OFFLINE:
+		return "offline"
+	default:
+		return "unknown"
+	}
+}
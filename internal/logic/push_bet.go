@@ -0,0 +1,54 @@
+package logic
+
+import (
+	"slices"
+)
+
+// PushBetMultiple 返回玩家本局可以推注的倍数，不能推注时返回0。
+// 上一局作为闲家赢分的玩家可以把赢得的分数折算成倍数（赢分 / 底注）押上，不超过推注上限；
+// 推注倍数不大于普通下注的最大倍数时没有意义，不提供推注；推注后需要间隔设定的局数才能再次推注
+func (r *Room) PushBetMultiple(player *Player) int32 {
+	if r.Settings.PushBetCap <= 0 || player.IsBanker() {
+		return 0
+	}
+	lastWin, cooldown := player.pushState()
+	if lastWin <= 0 || cooldown > 0 {
+		return 0
+	}
+
+	multiple := int32(min(lastWin/r.Settings.BaseBet, int64(r.Settings.PushBetCap)))
+	if multiple <= r.Settings.BetMultiples[len(r.Settings.BetMultiples)-1] {
+		return 0
+	}
+	return multiple
+}
+
+// isPushBet 检查下注倍数是否为推注，不在普通下注倍数中的下注只能是推注
+func (r *Room) isPushBet(multiple int32) bool {
+	return multiple > 0 && !slices.Contains(r.Settings.BetMultiples, multiple)
+}
+
+// recordPushState 在结算后、重置本局状态前记录每名玩家的推注状态：
+// 作为闲家赢分的玩家下一局可以推注，本局推注的玩家进入冷却，没有参与本局的玩家不能推注
+func (r *Room) recordPushState(round RoundResult) {
+	changes := make(map[int64]int64, len(round.Results))
+	for _, result := range round.Results {
+		changes[result.PlayerID] = result.ScoreChange
+	}
+
+	for _, p := range r.GetPlayers() {
+		_, cooldown := p.pushState()
+		if cooldown > 0 {
+			cooldown--
+		}
+		if r.isPushBet(p.GetBetAmount()) {
+			cooldown = r.Settings.PushCooldown
+		}
+
+		lastWin := int64(0)
+		if change, played := changes[p.ID]; played && change > 0 && !p.IsBanker() {
+			lastWin = change
+		}
+		p.setPushState(lastWin, cooldown)
+	}
+}
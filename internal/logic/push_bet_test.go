@@ -0,0 +1,81 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPushBet(t *testing.T) {
+	settings := DefaultRoomSettings()
+	settings.PushBetCap = 10
+	settings.PushCooldown = 1
+	room := NewRoomWithSettings(721, settings)
+	p1 := NewPlayer(1, "p1", nil)
+	p2 := NewPlayer(2, "p2", nil)
+	room.AddPlayer(p1)
+	room.AddPlayer(p2)
+
+	// 赢 1 × 1 × 1 × 4 = 4，折算4倍，不超过普通下注的最大倍数5，不能推注
+	playRoundWithBet(t, room, noBullHand, bullBullHand, 1)
+	if got := room.PushBetMultiple(p2); got != 0 {
+		t.Errorf("Expected no push bet for a small win, got %d", got)
+	}
+
+	// 赢 1 × 1 × 3 × 4 = 12，折算12倍，封顶10倍
+	playRoundWithBet(t, room, noBullHand, bullBullHand, 3)
+	if got := room.PushBetMultiple(p2); got != 10 {
+		t.Errorf("Expected push bet of 10, got %d", got)
+	}
+	if got := room.PushBetMultiple(p1); got != 0 {
+		t.Errorf("Expected the banker not to push, got %d", got)
+	}
+	if !slices.Contains(room.AllowedBetMultiples(p2), 10) {
+		t.Errorf("Expected push bet in bet options, got %v", room.AllowedBetMultiples(p2))
+	}
+	if err := room.CheckBetMultiple(p2, 10); err != nil {
+		t.Errorf("Expected push bet to be allowed, got %v", err)
+	}
+	if err := room.CheckBetMultiple(p2, 9); err == nil {
+		t.Error("Expected error for a multiple that is neither a bet option nor the push bet, but got nil")
+	}
+
+	// 推注后冷却一局
+	playRoundWithBet(t, room, noBullHand, bullBullHand, 10)
+	if got := room.PushBetMultiple(p2); got != 0 {
+		t.Errorf("Expected push bet to be on cooldown, got %d", got)
+	}
+	if err := room.CheckBetMultiple(p2, 10); err == nil {
+		t.Error("Expected error for a push bet during cooldown, but got nil")
+	}
+
+	// 冷却结束后可以再次推注
+	playRoundWithBet(t, room, noBullHand, bullBullHand, 3)
+	if got := room.PushBetMultiple(p2); got != 10 {
+		t.Errorf("Expected push bet of 10 after cooldown, got %d", got)
+	}
+
+	// 输了的玩家下一局不能推注
+	playRoundWithBet(t, room, bullBullHand, noBullHand, 1)
+	if got := room.PushBetMultiple(p2); got != 0 {
+		t.Errorf("Expected no push bet after a loss, got %d", got)
+	}
+}
+
+func TestPushBetDisabled(t *testing.T) {
+	room := NewRoom(722)
+	p1 := NewPlayer(1, "p1", nil)
+	p2 := NewPlayer(2, "p2", nil)
+	room.AddPlayer(p1)
+	room.AddPlayer(p2)
+
+	playRoundWithBet(t, room, noBullHand, bullBullHand, 5)
+	if got := room.PushBetMultiple(p2); got != 0 {
+		t.Errorf("Expected no push bet when the room does not allow it, got %d", got)
+	}
+
+	settings := DefaultRoomSettings()
+	settings.PushBetCap = 5
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for a push bet cap not above the largest bet multiple, but got nil")
+	}
+}
@@ -0,0 +1,88 @@
+package logic
+
+import (
+	"errors"
+)
+
+// RakeMode 抽水方式
+type RakeMode int
+
+const (
+	RAKE_NONE     RakeMode = iota // 不抽水
+	RAKE_PERCENT                  // 按赢分的百分比抽水
+	RAKE_FLAT_FEE                 // 每局向每位参与者收取固定台费
+)
+
+// RakePolicy 抽水策略，由运营方配置，抽水记入平台账户
+type RakePolicy struct {
+	Mode    RakeMode `json:"mode"`
+	Percent int64    `json:"percent"` // RAKE_PERCENT：抽取净赢分的百分比
+	Cap     int64    `json:"cap"`     // RAKE_PERCENT：每局抽水合计的上限，由本局赢家按各自的抽水分摊，0 表示不设上限
+	Fee     int64    `json:"fee"`     // RAKE_FLAT_FEE：每人每局的台费
+}
+
+// Validate 检查抽水策略是否合法
+func (p RakePolicy) Validate() error {
+	switch p.Mode {
+	case RAKE_NONE:
+		return nil
+	case RAKE_PERCENT:
+		if p.Percent <= 0 || p.Percent > 100 {
+			return errors.New("rake percent must be between 1 and 100")
+		}
+		if p.Cap < 0 {
+			return errors.New("rake cap must not be negative")
+		}
+		return nil
+	case RAKE_FLAT_FEE:
+		if p.Fee <= 0 {
+			return errors.New("rake fee must be positive")
+		}
+		return nil
+	default:
+		return errors.New("unknown rake mode")
+	}
+}
+
+// rakeFor 计算玩家本局应交的抽水（不计整局的上限），netWin 为本局净输赢，不会超过玩家结算后的余额
+func (p RakePolicy) rakeFor(netWin int64, balance int64) int64 {
+	var rake int64
+	switch p.Mode {
+	case RAKE_PERCENT:
+		if netWin > 0 {
+			rake = netWin * p.Percent / 100
+		}
+	case RAKE_FLAT_FEE:
+		rake = p.Fee
+	}
+	return max(min(rake, balance), 0)
+}
+
+// roundRakes 计算本局每位参与者应交的抽水，netWins 和 balances 按参与者顺序排列。
+// 按百分比抽水时上限针对整局：赢家的抽水合计超过上限时按各自的抽水比例分摊上限，
+// 除不尽的部分按参与者顺序由赢家各多交 1
+func (p RakePolicy) roundRakes(netWins []int64, balances []int64) []int64 {
+	rakes := make([]int64, len(netWins))
+	var total int64
+	for i, netWin := range netWins {
+		rakes[i] = p.rakeFor(netWin, balances[i])
+		total += rakes[i]
+	}
+	if p.Mode != RAKE_PERCENT || p.Cap <= 0 || total <= p.Cap {
+		return rakes
+	}
+
+	capped := make([]int64, len(rakes))
+	remaining := p.Cap
+	for i, rake := range rakes {
+		capped[i] = rake * p.Cap / total
+		remaining -= capped[i]
+	}
+	for i := 0; remaining > 0; i++ {
+		if rakes[i] > 0 {
+			capped[i]++
+			remaining--
+		}
+	}
+	return capped
+}
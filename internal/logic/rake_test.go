@@ -0,0 +1,171 @@
+package logic
+
+import (
+	"testing"
+	"xizexcample/internal/wallet"
+)
+
+func TestRakePolicyValidate(t *testing.T) {
+	valid := []RakePolicy{
+		{},
+		{Mode: RAKE_PERCENT, Percent: 5},
+		{Mode: RAKE_PERCENT, Percent: 5, Cap: 10},
+		{Mode: RAKE_FLAT_FEE, Fee: 1},
+	}
+	for _, p := range valid {
+		if err := p.Validate(); err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", p, err)
+		}
+	}
+
+	invalid := []RakePolicy{
+		{Mode: RAKE_PERCENT},
+		{Mode: RAKE_PERCENT, Percent: 101},
+		{Mode: RAKE_PERCENT, Percent: 5, Cap: -1},
+		{Mode: RAKE_FLAT_FEE},
+		{Mode: RakeMode(9)},
+	}
+	for _, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", p)
+		}
+	}
+}
+
+func TestPercentRakeWithCap(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	settings := DefaultRoomSettings()
+	settings.BaseBet = 100
+	settings.Rake = RakePolicy{Mode: RAKE_PERCENT, Percent: 10, Cap: 30}
+	room := NewRoomWithSettings(711, settings)
+	room.SetWallet(w)
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	houseBefore := w.Balance(wallet.HouseAccountID)
+
+	playRound(t, room, noBullHand, bullBullHand)
+
+	// p2 牛牛赢庄家 p1：100 × 4 = 400，抽水 10% 为 40，封顶 30；输家不抽水
+	results := room.FSM.GetLastResult().Results
+	for _, r := range results {
+		switch r.PlayerID {
+		case 1:
+			if r.Rake != 0 || r.ScoreChange != -400 {
+				t.Errorf("Expected loser to pay no rake and lose 400, got rake %d change %d", r.Rake, r.ScoreChange)
+			}
+		case 2:
+			if r.Rake != 30 || r.ScoreChange != 370 {
+				t.Errorf("Expected winner to pay rake 30 and win 370, got rake %d change %d", r.Rake, r.ScoreChange)
+			}
+		}
+	}
+	if got := w.Balance(wallet.HouseAccountID) - houseBefore; got != 30 {
+		t.Errorf("Expected house to collect 30, got %d", got)
+	}
+	if sum, _ := w.SumByReason(wallet.HouseAccountID, wallet.REASON_RAKE); sum != 30 {
+		t.Errorf("Expected rake report of 30, got %d", sum)
+	}
+	if err := w.Reconcile(); err != nil {
+		t.Errorf("Reconcile failed: %v", err)
+	}
+}
+
+func TestPercentRakeCapIsPerRound(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	settings := DefaultRoomSettings()
+	settings.BaseBet = 100
+	settings.Rake = RakePolicy{Mode: RAKE_PERCENT, Percent: 10, Cap: 30}
+	room := NewRoomWithSettings(713, settings)
+	room.SetWallet(w)
+	for id := int64(1); id <= 3; id++ {
+		room.AddPlayer(NewPlayer(id, "p", nil))
+	}
+	houseBefore := w.Balance(wallet.HouseAccountID)
+
+	// p2 和 p3 都以牛牛赢庄家 p1，各赢 400，抽水各 40 合计 80，整局封顶 30，两人各分摊 15
+	fsm := room.GetFSM()
+	players := room.GetPlayers()
+	for _, p := range players {
+		p.SetStatus(STATUS_READY)
+	}
+	fsm.StartGame()
+	fsm.DealCards()
+	setHand(players[0], noBullHand)
+	setHand(players[1], bullBullHand)
+	setHand(players[2], bullBullHand)
+	room.SetBanker(1)
+	fsm.BidBanker()
+	players[1].PlaceBet(1)
+	players[2].PlaceBet(1)
+	fsm.PlaceBet()
+	fsm.Showdown()
+	if err := fsm.Settlement(); err != nil {
+		t.Fatalf("Settlement failed: %v", err)
+	}
+
+	var total int64
+	for _, r := range fsm.GetLastResult().Results {
+		total += r.Rake
+		if r.PlayerID != 1 && (r.Rake != 15 || r.ScoreChange != 385) {
+			t.Errorf("Expected winner %d to pay rake 15 and win 385, got rake %d change %d", r.PlayerID, r.Rake, r.ScoreChange)
+		}
+	}
+	if total != 30 {
+		t.Errorf("Expected the round's total rake to be capped at 30, got %d", total)
+	}
+	if got := w.Balance(wallet.HouseAccountID) - houseBefore; got != 30 {
+		t.Errorf("Expected house to collect 30, got %d", got)
+	}
+	if err := w.Reconcile(); err != nil {
+		t.Errorf("Reconcile failed: %v", err)
+	}
+}
+
+func TestRoundRakesSplitCap(t *testing.T) {
+	// 抽水 30 和 10 合计 40，封顶 25：按比例分摊为 18.75 和 6.25，取整后余下的 1 由先结算的赢家交
+	p := RakePolicy{Mode: RAKE_PERCENT, Percent: 10, Cap: 25}
+	rakes := p.roundRakes([]int64{-400, 300, 100}, []int64{600, 1300, 1100})
+	if rakes[0] != 0 || rakes[1] != 19 || rakes[2] != 6 {
+		t.Errorf("Expected rakes [0 19 6], got %v", rakes)
+	}
+
+	// 未超过上限时按各自的抽水收取
+	p.Cap = 100
+	rakes = p.roundRakes([]int64{-400, 300, 100}, []int64{600, 1300, 1100})
+	if rakes[0] != 0 || rakes[1] != 30 || rakes[2] != 10 {
+		t.Errorf("Expected rakes [0 30 10], got %v", rakes)
+	}
+}
+
+func TestFlatFeeRake(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	settings := DefaultRoomSettings()
+	settings.Rake = RakePolicy{Mode: RAKE_FLAT_FEE, Fee: 2}
+	room := NewRoomWithSettings(712, settings)
+	room.SetWallet(w)
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+
+	playRound(t, room, noBullHand, bullBullHand)
+
+	// 每人交 2 台费：p1 -4-2，p2 +4-2
+	if w.Balance(1) != InitialScore-6 || w.Balance(2) != InitialScore+2 {
+		t.Errorf("Expected balances %d and %d, got %d and %d", InitialScore-6, InitialScore+2, w.Balance(1), w.Balance(2))
+	}
+	if sum, _ := w.SumByReason(wallet.HouseAccountID, wallet.REASON_RAKE); sum != 4 {
+		t.Errorf("Expected rake report of 4, got %d", sum)
+	}
+	if err := w.Reconcile(); err != nil {
+		t.Errorf("Reconcile failed: %v", err)
+	}
+}
+
+func TestRakeNeverExceedsBalance(t *testing.T) {
+	p := RakePolicy{Mode: RAKE_FLAT_FEE, Fee: 10}
+	if got := p.rakeFor(-100, 3); got != 3 {
+		t.Errorf("Expected rake capped at balance 3, got %d", got)
+	}
+	if got := p.rakeFor(0, 0); got != 0 {
+		t.Errorf("Expected no rake from an empty balance, got %d", got)
+	}
+}
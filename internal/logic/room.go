@@ -1,62 +1,290 @@
 package logic
 
 import (
+	"crypto/subtle"
 	"errors"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/wallet"
 )
 
 // Room 表示一个游戏房间
 type Room struct {
-	ID      int32
-	Players map[int64]*Player // key: playerID
-	Deck    *Deck
-	FSM     *RoomFSM
-	mu      sync.RWMutex
+	ID           int32
+	Settings     RoomSettings
+	InviteCode   string            // 私人房间邀请码，公开房间为空
+	Players      map[int64]*Player // key: playerID
+	Spectators   map[int64]*Player // key: playerID，观战者不占座位
+	Deck         *Deck
+	FSM          *RoomFSM
+	password     string
+	seats        []int64               // seats[i] 为座位号 i+1 上的玩家ID，0 表示空座
+	lockedSeats  []bool                // lockedSeats[i] 表示座位号 i+1 被房主锁定
+	ownerID      int64                 // 房主ID，0 表示没有房主
+	dissolveVote *DissolveVote         // 进行中的解散投票，nil 表示没有投票
+	dissolved    bool                  // 房间已通过投票解散
+	session      *Session              // 本场对局的分数记录
+	shuffleSeed  int64                 // 本局洗牌使用的种子
+	seedSource   func() int64          // 生成洗牌种子，nil 时使用当前时间
+	dissolveHook func(DissolveOutcome) // 投票者离开使解散投票有了结果时调用
+	wallet       *wallet.Wallet
+	closed       chan struct{}
+	actionMu     sync.Mutex // 串行执行房间内的操作，见 Do
+	mu           sync.RWMutex
 }
 
-// NewRoom 创建一个新房间
+// NewRoom 使用默认设置创建一个新房间
 func NewRoom(roomID int32) *Room {
+	return NewRoomWithSettings(roomID, DefaultRoomSettings())
+}
+
+// NewRoomWithSettings 使用指定设置创建一个新房间
+func NewRoomWithSettings(roomID int32, settings RoomSettings) *Room {
 	r := &Room{
-		ID:      roomID,
-		Players: make(map[int64]*Player),
-		Deck:    NewDeck(),
+		ID:          roomID,
+		Settings:    settings,
+		Players:     make(map[int64]*Player),
+		Spectators:  make(map[int64]*Player),
+		Deck:        NewDeck(),
+		seats:       make([]int64, settings.MaxPlayers),
+		lockedSeats: make([]bool, settings.MaxPlayers),
+		session:     newSession(settings.Rounds),
+		wallet:      wallet.GetWallet(),
+		closed:      make(chan struct{}),
 	}
 	r.FSM = NewRoomFSM(r)
 	go r.startCleanupTimer()
 	return r
 }
 
-// AddPlayer 添加一个玩家到房间
+// SetPassword 设置房间密码，空字符串表示不需要密码
+func (r *Room) SetPassword(password string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.password = password
+}
+
+// HasPassword 检查房间是否设置了密码
+func (r *Room) HasPassword() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.password != ""
+}
+
+// CheckPassword 校验房间密码
+func (r *Room) CheckPassword(password string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return subtle.ConstantTimeCompare([]byte(r.password), []byte(password)) == 1
+}
+
+// AddPlayer 添加一个玩家到房间，自动分配座位号最小的空座
 func (r *Room) AddPlayer(player *Player) error {
+	return r.AddPlayerAtSeat(player, 0)
+}
+
+// AddPlayerAtSeat 添加一个玩家到房间的指定座位，seat 为 0 时自动分配
+func (r *Room) AddPlayerAtSeat(player *Player, seat int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if len(r.Players) >= 5 {
+	if len(r.Players) >= r.capacityLocked() {
 		return errors.New("room is full")
 	}
 
 	if _, exists := r.Players[player.ID]; exists {
 		return errors.New("player already in room")
 	}
+	if _, exists := r.Spectators[player.ID]; exists {
+		return errors.New("player is spectating this room")
+	}
+
+	seat, err := r.pickSeatLocked(seat)
+	if err != nil {
+		return err
+	}
 
 	player.SetRoomID(r.ID)
+	r.seatPlayerLocked(player, seat)
+	return nil
+}
+
+// pickSeatLocked 校验或选择一个空座位，调用方需持有写锁
+func (r *Room) pickSeatLocked(seat int) (int, error) {
+	if seat == 0 {
+		for i, occupant := range r.seats {
+			if occupant == 0 && !r.lockedSeats[i] {
+				return i + 1, nil
+			}
+		}
+		return 0, errors.New("no free seat")
+	}
+	if seat < 1 || seat > len(r.seats) {
+		return 0, errors.New("invalid seat")
+	}
+	if r.lockedSeats[seat-1] {
+		return 0, errors.New("seat is locked")
+	}
+	if r.seats[seat-1] != 0 {
+		return 0, errors.New("seat is taken")
+	}
+	return seat, nil
+}
+
+// capacityLocked 返回当前可容纳的玩家数，即未锁定的座位数，调用方需持有锁
+func (r *Room) capacityLocked() int {
+	capacity := len(r.seats)
+	for _, locked := range r.lockedSeats {
+		if locked {
+			capacity--
+		}
+	}
+	return capacity
+}
+
+// seatPlayerLocked 让玩家坐到指定座位并从钱包同步分数，首次入座的玩家会获得初始分数，调用方需持有写锁
+func (r *Room) seatPlayerLocked(player *Player, seat int) {
+	r.seats[seat-1] = player.ID
+	player.SetSeat(seat)
 	r.Players[player.ID] = player
+
+	balance, err := r.wallet.OpenAccount(player.ID, InitialScore)
+	if err != nil {
+		r.Logger().Error("Failed to open wallet account", logger.PlayerID(player.ID), logger.Err(err))
+		return
+	}
+	player.SetScore(balance)
+}
+
+// removePlayerLocked 将玩家移出房间并空出座位，调用方需持有写锁。
+// 玩家是进行中的解散投票的投票者时重新判断投票结果，返回投票因此结束时的结果，否则返回 DISSOLVE_PENDING
+func (r *Room) removePlayerLocked(playerID int64) DissolveOutcome {
+	player, exists := r.Players[playerID]
+	if !exists {
+		return DISSOLVE_PENDING
+	}
+	if seat := player.GetSeat(); seat > 0 && r.seats[seat-1] == playerID {
+		r.seats[seat-1] = 0
+	}
+	player.SetSeat(0)
+	delete(r.Players, playerID)
+
+	if r.ownerID == playerID {
+		r.passOwnershipLocked()
+	}
+
+	if r.dissolveVote == nil {
+		return DISSOLVE_PENDING
+	}
+	r.dissolveVote.removeVoter(playerID)
+	return r.settleDissolveVoteLocked(false)
+}
+
+// AddSpectator 以观战者身份加入房间，观战者不计入玩家人数
+func (r *Room) AddSpectator(player *Player) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.Spectators) >= r.Settings.MaxSpectators {
+		return errors.New("spectator limit reached")
+	}
+	if _, exists := r.Players[player.ID]; exists {
+		return errors.New("player already in room")
+	}
+	if _, exists := r.Spectators[player.ID]; exists {
+		return errors.New("player already spectating")
+	}
+
+	player.SetRoomID(r.ID)
+	r.Spectators[player.ID] = player
 	return nil
 }
 
-// RemovePlayer 从房间移除一个玩家
-func (r *Room) RemovePlayer(playerID int64) error {
+// RemoveSpectator 移除一个观战者
+func (r *Room) RemoveSpectator(playerID int64) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	spectator, exists := r.Spectators[playerID]
+	if !exists {
+		return errors.New("spectator not in room")
+	}
+	spectator.SetRoomID(0)
+	delete(r.Spectators, playerID)
+	return nil
+}
+
+// IsSpectator 检查玩家是否是该房间的观战者
+func (r *Room) IsSpectator(playerID int64) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, exists := r.Spectators[playerID]
+	return exists
+}
+
+// GetSpectators 获取房间内所有观战者
+func (r *Room) GetSpectators() []*Player {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	spectators := make([]*Player, 0, len(r.Spectators))
+	for _, p := range r.Spectators {
+		spectators = append(spectators, p)
+	}
+	return spectators
+}
+
+// GetSpectatorCount 获取观战人数
+func (r *Room) GetSpectatorCount() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.Spectators)
+}
+
+// TakeSeat 观战者在两局之间入座成为玩家，seat 为 0 时自动分配
+func (r *Room) TakeSeat(playerID int64, seat int) error {
+	if !r.FSM.CanAct(STATE_WAITING_FOR_PLAYERS) {
+		return errors.New("can only take a seat between rounds")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spectator, exists := r.Spectators[playerID]
+	if !exists {
+		return errors.New("spectator not in room")
+	}
+	if len(r.Players) >= r.capacityLocked() {
+		return errors.New("room is full")
+	}
+	seat, err := r.pickSeatLocked(seat)
+	if err != nil {
+		return err
+	}
+
+	spectator.SetStatus(STATUS_WAITING)
+	delete(r.Spectators, playerID)
+	r.seatPlayerLocked(spectator, seat)
+	return nil
+}
+
+// RemovePlayer 从房间移除一个玩家，移除使解散投票有了结果时调用解散投票回调
+func (r *Room) RemovePlayer(playerID int64) error {
+	r.mu.Lock()
 	if _, exists := r.Players[playerID]; !exists {
+		r.mu.Unlock()
 		return errors.New("player not in room")
 	}
 
 	player := r.Players[playerID]
 	player.SetRoomID(0) // 从房间中移除
-	delete(r.Players, playerID)
+	outcome := r.removePlayerLocked(playerID)
+	r.mu.Unlock()
+
+	r.dissolveDecided(outcome)
 	return nil
 }
 
@@ -88,14 +316,16 @@ func (r *Room) GetPlayer(playerID int64) (*Player, error) {
 	return player, nil
 }
 
-// GetPlayers 获取房间内所有玩家
+// GetPlayers 获取房间内所有玩家，按座位号排序
 func (r *Room) GetPlayers() []*Player {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	players := make([]*Player, 0, len(r.Players))
-	for _, p := range r.Players {
-		players = append(players, p)
+	for _, playerID := range r.seats {
+		if playerID != 0 {
+			players = append(players, r.Players[playerID])
+		}
 	}
 	return players
 }
@@ -107,19 +337,56 @@ func (r *Room) GetPlayerCount() int {
 	return len(r.Players)
 }
 
+// GetReadyPlayerCount 获取已准备的玩家数量
+func (r *Room) GetReadyPlayerCount() int {
+	count := 0
+	for _, p := range r.GetPlayers() {
+		if p.GetStatus() == STATUS_READY {
+			count++
+		}
+	}
+	return count
+}
+
 // IsFull 检查房间是否已满
 func (r *Room) IsFull() bool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	return len(r.Players) >= 5
+	return len(r.Players) >= r.capacityLocked()
+}
+
+// FreeSeatCount 返回还能入座的玩家数，即未锁定的空座位数
+func (r *Room) FreeSeatCount() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return max(r.capacityLocked()-len(r.Players), 0)
 }
 
-// ResetDeck 重置并洗牌
+// ResetDeck 重置并洗牌，记录洗牌使用的种子
 func (r *Room) ResetDeck() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if r.seedSource != nil {
+		r.shuffleSeed = r.seedSource()
+	} else {
+		r.shuffleSeed = time.Now().UnixNano()
+	}
 	r.Deck.Reset()
-	r.Deck.Shuffle()
+	r.Deck.ShuffleWithSeed(r.shuffleSeed)
+}
+
+// SetSeedSource 指定洗牌种子的来源，用于复盘时按记录的种子重新发牌
+func (r *Room) SetSeedSource(source func() int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seedSource = source
+}
+
+// GetShuffleSeed 获取本局洗牌使用的种子
+func (r *Room) GetShuffleSeed() int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.shuffleSeed
 }
 
 // DealCardsToPlayer 给指定玩家发牌
@@ -148,8 +415,6 @@ func (r *Room) DealCardsToPlayer(playerID int64, num int) error {
 func (r *Room) SetBanker(playerID int64) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	// TODO: 可以在这里添加更复杂的庄家选择逻辑
-	// 例如，比较所有玩家的抢庄倍数
 	r.Players[playerID].SetBanker(true)
 }
 
@@ -177,34 +442,131 @@ func (r *Room) HasBanker() bool {
 	return false
 }
 
+// SetWallet 指定房间使用的钱包，应在玩家入座之前调用
+func (r *Room) SetWallet(w *wallet.Wallet) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.wallet = w
+}
+
+// GetWallet 获取房间使用的钱包
+func (r *Room) GetWallet() *wallet.Wallet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.wallet
+}
+
+// GetSession 获取本场对局记录的副本
+func (r *Room) GetSession() *Session {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.session.clone()
+}
+
+// recordRound 记录一局的结算结果，返回本场对局是否已打满局数
+func (r *Room) recordRound(round RoundResult) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	nicknames := make(map[int64]string, len(r.Players))
+	for playerID, player := range r.Players {
+		nicknames[playerID] = player.Nickname
+	}
+	r.session.record(round, nicknames)
+	return r.session.IsOver()
+}
+
+// nextRound 返回即将结算的局数
+func (r *Room) nextRound() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.session.RoundsPlayed() + 1
+}
+
+// resetRound 清除所有玩家上一局的牌局状态，准备下一局
+func (r *Room) resetRound() {
+	for _, player := range r.GetPlayers() {
+		player.resetRound()
+	}
+}
+
+// Do 串行执行房间内的一次操作。客户端请求、投票截止和管理后台的修改都通过 Do 执行，
+// 同一房间的检查和状态转换不会与其他操作交错。fn 中不能再调用同一房间的 Do
+func (r *Room) Do(fn func()) {
+	r.actionMu.Lock()
+	defer r.actionMu.Unlock()
+	fn()
+}
+
 // GetFSM 获取房间的状态机
 func (r *Room) GetFSM() *RoomFSM {
 	return r.FSM
 }
 
+// Logger 返回带有房间ID和当前牌局ID的日志记录器
+func (r *Room) Logger() *slog.Logger {
+	l := logger.L().With(logger.RoomID(r.ID))
+	if r.FSM != nil && r.FSM.GetRoundID() != "" {
+		l = l.With(logger.RoundID(r.FSM.GetRoundID()))
+	}
+	return l
+}
+
+// Close 关闭房间，停止房间的后台任务，重复调用是安全的
+func (r *Room) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	select {
+	case <-r.closed:
+	default:
+		close(r.closed)
+	}
+}
+
+// roomGoroutines 正在运行的房间后台协程数，房间关闭后应当归零，持续增长说明有房间没有关闭
+var roomGoroutines atomic.Int64
+
+// RoomGoroutines 返回正在运行的房间后台协程数
+func RoomGoroutines() int64 {
+	return roomGoroutines.Load()
+}
+
 // startCleanupTimer 启动一个定时器，定期清理断线的玩家
 func (r *Room) startCleanupTimer() {
+	roomGoroutines.Add(1)
+	defer roomGoroutines.Add(-1)
+
 	ticker := time.NewTicker(1 * time.Minute) // 每分钟检查一次
 	defer ticker.Stop()
 
-	for range ticker.C {
-		r.cleanupDisconnectedPlayers()
+	for {
+		select {
+		case <-ticker.C:
+			r.Do(r.cleanupDisconnectedPlayers)
+		case <-r.closed:
+			return
+		}
 	}
 }
 
 // cleanupDisconnectedPlayers 清理长时间未重连的玩家
 func (r *Room) cleanupDisconnectedPlayers() {
 	r.mu.Lock()
-	defer r.mu.Unlock()
-
 	now := time.Now().Unix()
-	timeout := int64(5 * 60) // 5分钟超时
+	timeout := int64(ReconnectTimeout() / time.Second)
 
+	outcome := DISSOLVE_PENDING
 	for playerID, player := range r.Players {
 		if !player.IsOnline() && (now-player.DisconnectTime) > timeout {
 			// 超时，移除玩家
-			delete(r.Players, playerID)
+			if decided := r.removePlayerLocked(playerID); decided != DISSOLVE_PENDING {
+				outcome = decided
+			}
 			// TODO: 广播玩家被移除的通知
 		}
 	}
+	r.mu.Unlock()
+
+	r.dissolveDecided(outcome)
 }
@@ -0,0 +1,217 @@
+package logic
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultDissolveVoteTimeout 解散投票的默认时限
+const DefaultDissolveVoteTimeout = 60 * time.Second
+
+// DissolveOutcome 解散投票的结果
+type DissolveOutcome int
+
+const (
+	DISSOLVE_PENDING  DissolveOutcome = iota // 投票进行中
+	DISSOLVE_PASSED                          // 通过，房间解散
+	DISSOLVE_REJECTED                        // 被拒绝，牌局继续
+)
+
+// DissolveVote 一次解散房间投票
+type DissolveVote struct {
+	ProposerID int64
+	Deadline   time.Time
+	Rule       DissolveRule
+	Voters     []int64        // 发起投票时在座的玩家，按座位顺序
+	Ballots    map[int64]bool // key: playerID，value: 是否同意
+}
+
+// Pending 返回尚未投票的玩家ID
+func (v *DissolveVote) Pending() []int64 {
+	pending := make([]int64, 0)
+	for _, playerID := range v.Voters {
+		if _, voted := v.Ballots[playerID]; !voted {
+			pending = append(pending, playerID)
+		}
+	}
+	return pending
+}
+
+// removeVoter 离开房间的玩家不再参与投票
+func (v *DissolveVote) removeVoter(playerID int64) {
+	for i, voter := range v.Voters {
+		if voter == playerID {
+			v.Voters = append(v.Voters[:i], v.Voters[i+1:]...)
+			break
+		}
+	}
+	delete(v.Ballots, playerID)
+}
+
+// outcome 根据当前票数判断投票结果，expired 为 true 时未投票的玩家视为同意
+func (v *DissolveVote) outcome(expired bool) DissolveOutcome {
+	agreed, refused := 0, 0
+	for _, playerID := range v.Voters {
+		agree, voted := v.Ballots[playerID]
+		switch {
+		case voted && agree, !voted && expired:
+			agreed++
+		case voted:
+			refused++
+		}
+	}
+	total := len(v.Voters)
+
+	if v.Rule == DISSOLVE_MAJORITY {
+		if agreed*2 > total {
+			return DISSOLVE_PASSED
+		}
+		if (total-refused)*2 <= total {
+			return DISSOLVE_REJECTED
+		}
+		return DISSOLVE_PENDING
+	}
+
+	if refused > 0 {
+		return DISSOLVE_REJECTED
+	}
+	if agreed == total {
+		return DISSOLVE_PASSED
+	}
+	return DISSOLVE_PENDING
+}
+
+// ProposeDissolve 在座玩家发起解散房间投票，发起者自动投同意票，投票期间牌局暂停
+func (r *Room) ProposeDissolve(playerID int64, timeout time.Duration) (DissolveOutcome, error) {
+	if r.Settings.Rounds <= 0 {
+		return DISSOLVE_PENDING, errors.New("only round-limited rooms can be dissolved by vote")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.Players[playerID]; !exists {
+		return DISSOLVE_PENDING, errors.New("only seated players can propose dissolving the room")
+	}
+	if r.dissolved {
+		return DISSOLVE_PENDING, errors.New("room has already been dissolved")
+	}
+	if r.dissolveVote != nil {
+		return DISSOLVE_PENDING, errors.New("a dissolve vote is already in progress")
+	}
+
+	vote := &DissolveVote{
+		ProposerID: playerID,
+		Deadline:   time.Now().Add(timeout),
+		Rule:       r.Settings.DissolveRule,
+		Ballots:    map[int64]bool{playerID: true},
+	}
+	for _, seated := range r.seats {
+		if seated != 0 {
+			vote.Voters = append(vote.Voters, seated)
+		}
+	}
+	r.dissolveVote = vote
+	r.FSM.Pause()
+
+	return r.settleDissolveVoteLocked(false), nil
+}
+
+// CastDissolveVote 玩家对进行中的解散投票表态
+func (r *Room) CastDissolveVote(playerID int64, agree bool) (DissolveOutcome, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	vote := r.dissolveVote
+	if vote == nil {
+		return DISSOLVE_PENDING, errors.New("no dissolve vote in progress")
+	}
+	isVoter := false
+	for _, voter := range vote.Voters {
+		if voter == playerID {
+			isVoter = true
+			break
+		}
+	}
+	if !isVoter {
+		return DISSOLVE_PENDING, errors.New("player is not eligible to vote")
+	}
+	if _, voted := vote.Ballots[playerID]; voted {
+		return DISSOLVE_PENDING, errors.New("player has already voted")
+	}
+
+	vote.Ballots[playerID] = agree
+	return r.settleDissolveVoteLocked(false), nil
+}
+
+// ExpireDissolveVote 投票到期时结束投票，未投票的玩家视为同意；
+// 投票已结束或尚未到期（例如已开始了新一轮投票）时返回 DISSOLVE_PENDING
+func (r *Room) ExpireDissolveVote(now time.Time) DissolveOutcome {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.dissolveVote == nil || now.Before(r.dissolveVote.Deadline) {
+		return DISSOLVE_PENDING
+	}
+	return r.settleDissolveVoteLocked(true)
+}
+
+// settleDissolveVoteLocked 判断投票结果，投票结束时清除投票并恢复牌局，调用方需持有写锁
+func (r *Room) settleDissolveVoteLocked(expired bool) DissolveOutcome {
+	outcome := r.dissolveVote.outcome(expired)
+	if outcome == DISSOLVE_PENDING {
+		return outcome
+	}
+
+	r.dissolveVote = nil
+	r.FSM.Resume()
+	if outcome == DISSOLVE_PASSED {
+		r.dissolved = true
+	}
+	return outcome
+}
+
+// SetDissolveHook 设置投票者离开（主动离开、被踢出或断线超时）使解散投票有了结果时的回调，
+// 调用时不持有房间的锁，回调负责广播结果和解散房间
+func (r *Room) SetDissolveHook(hook func(DissolveOutcome)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dissolveHook = hook
+}
+
+// dissolveDecided 解散投票因投票者离开而结束时调用回调，outcome 为 DISSOLVE_PENDING 时不做任何事
+func (r *Room) dissolveDecided(outcome DissolveOutcome) {
+	if outcome == DISSOLVE_PENDING {
+		return
+	}
+	r.mu.RLock()
+	hook := r.dissolveHook
+	r.mu.RUnlock()
+	if hook != nil {
+		hook(outcome)
+	}
+}
+
+// GetDissolveVote 获取进行中的解散投票的副本，没有投票时返回 nil
+func (r *Room) GetDissolveVote() *DissolveVote {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.dissolveVote == nil {
+		return nil
+	}
+	vote := *r.dissolveVote
+	vote.Voters = append([]int64(nil), r.dissolveVote.Voters...)
+	vote.Ballots = make(map[int64]bool, len(r.dissolveVote.Ballots))
+	for playerID, agree := range r.dissolveVote.Ballots {
+		vote.Ballots[playerID] = agree
+	}
+	return &vote
+}
+
+// IsDissolved 检查房间是否已经通过投票解散
+func (r *Room) IsDissolved() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.dissolved
+}
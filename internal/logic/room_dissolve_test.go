@@ -0,0 +1,169 @@
+package logic
+
+import (
+	"testing"
+	"time"
+)
+
+// newDissolveTestRoom 创建一个有局数限制、坐满指定人数的房间
+func newDissolveTestRoom(roomID int32, rule DissolveRule, players int) *Room {
+	settings := DefaultRoomSettings()
+	settings.Rounds = 10
+	settings.DissolveRule = rule
+	room := NewRoomWithSettings(roomID, settings)
+	for i := 1; i <= players; i++ {
+		room.AddPlayer(NewPlayer(int64(i), "p", nil))
+	}
+	return room
+}
+
+func TestDissolveRequiresRoundLimit(t *testing.T) {
+	room := NewRoom(501)
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	if _, err := room.ProposeDissolve(1, time.Minute); err == nil {
+		t.Error("Expected error for dissolving a room without a round limit, but got nil")
+	}
+}
+
+func TestDissolveUnanimous(t *testing.T) {
+	room := newDissolveTestRoom(502, DISSOLVE_UNANIMOUS, 3)
+
+	outcome, err := room.ProposeDissolve(1, time.Minute)
+	if err != nil {
+		t.Fatalf("ProposeDissolve failed: %v", err)
+	}
+	if outcome != DISSOLVE_PENDING || !room.GetFSM().IsPaused() {
+		t.Fatal("Expected vote to be pending and the FSM paused")
+	}
+	if _, err := room.ProposeDissolve(2, time.Minute); err == nil {
+		t.Error("Expected error for a second concurrent vote, but got nil")
+	}
+	if _, err := room.CastDissolveVote(1, true); err == nil {
+		t.Error("Expected error for voting twice, but got nil")
+	}
+
+	outcome, _ = room.CastDissolveVote(2, true)
+	if outcome != DISSOLVE_PENDING {
+		t.Errorf("Expected vote to be pending with one voter left, got %d", outcome)
+	}
+	outcome, _ = room.CastDissolveVote(3, true)
+	if outcome != DISSOLVE_PASSED || !room.IsDissolved() {
+		t.Errorf("Expected unanimous vote to dissolve the room, got %d", outcome)
+	}
+}
+
+func TestDissolveRejectedResumesPlay(t *testing.T) {
+	room := newDissolveTestRoom(503, DISSOLVE_UNANIMOUS, 3)
+	room.ProposeDissolve(1, time.Minute)
+
+	outcome, _ := room.CastDissolveVote(2, false)
+	if outcome != DISSOLVE_REJECTED {
+		t.Fatalf("Expected a single refusal to reject the vote, got %d", outcome)
+	}
+	if room.GetFSM().IsPaused() || room.GetDissolveVote() != nil || room.IsDissolved() {
+		t.Error("Expected play to resume after a rejected vote")
+	}
+}
+
+func TestDissolveMajority(t *testing.T) {
+	room := newDissolveTestRoom(504, DISSOLVE_MAJORITY, 4)
+	room.ProposeDissolve(1, time.Minute)
+
+	outcome, _ := room.CastDissolveVote(2, false)
+	if outcome != DISSOLVE_PENDING {
+		t.Errorf("Expected vote to be pending at 1-1, got %d", outcome)
+	}
+	outcome, _ = room.CastDissolveVote(3, true)
+	if outcome != DISSOLVE_PENDING {
+		t.Errorf("Expected 2 of 4 not to be a majority, got %d", outcome)
+	}
+	outcome, _ = room.CastDissolveVote(4, true)
+	if outcome != DISSOLVE_PASSED {
+		t.Errorf("Expected 3 of 4 to pass a majority vote, got %d", outcome)
+	}
+
+	// 一半人拒绝时无法再过半，直接拒绝
+	room = newDissolveTestRoom(505, DISSOLVE_MAJORITY, 4)
+	room.ProposeDissolve(1, time.Minute)
+	room.CastDissolveVote(2, false)
+	outcome, _ = room.CastDissolveVote(3, false)
+	if outcome != DISSOLVE_REJECTED {
+		t.Errorf("Expected 2 refusals of 4 to reject a majority vote, got %d", outcome)
+	}
+}
+
+func TestDissolveExpiry(t *testing.T) {
+	room := newDissolveTestRoom(506, DISSOLVE_UNANIMOUS, 3)
+	room.ProposeDissolve(1, time.Minute)
+	room.CastDissolveVote(2, true)
+
+	if outcome := room.ExpireDissolveVote(time.Now()); outcome != DISSOLVE_PENDING {
+		t.Errorf("Expected vote not to expire before its deadline, got %d", outcome)
+	}
+	// 截止时未投票的玩家视为同意
+	if outcome := room.ExpireDissolveVote(time.Now().Add(2 * time.Minute)); outcome != DISSOLVE_PASSED {
+		t.Errorf("Expected abstaining players to count as agreeing at the deadline, got %d", outcome)
+	}
+}
+
+func TestDissolvePausesFSM(t *testing.T) {
+	room := newDissolveTestRoom(507, DISSOLVE_UNANIMOUS, 2)
+	room.ProposeDissolve(1, time.Minute)
+
+	fsm := room.GetFSM()
+	if fsm.CanStartGame() || fsm.CanAct(STATE_WAITING_FOR_PLAYERS) {
+		t.Error("Expected no actions to be allowed while the vote is open")
+	}
+	if err := fsm.TransitionTo(STATE_DEALING); err == nil {
+		t.Error("Expected transition to fail while paused, but got nil")
+	}
+
+	room.CastDissolveVote(2, false)
+	if !fsm.CanStartGame() {
+		t.Error("Expected the game to be startable after the vote is rejected")
+	}
+}
+
+func TestDissolveVoterLeaves(t *testing.T) {
+	room := newDissolveTestRoom(508, DISSOLVE_UNANIMOUS, 3)
+	var decided []DissolveOutcome
+	room.SetDissolveHook(func(outcome DissolveOutcome) {
+		decided = append(decided, outcome)
+	})
+	room.ProposeDissolve(1, time.Minute)
+	room.CastDissolveVote(2, true)
+
+	// 唯一未投票的玩家离开后，剩下的玩家都已同意，投票立即通过
+	if err := room.RemovePlayer(3); err != nil {
+		t.Fatalf("RemovePlayer failed: %v", err)
+	}
+	if len(decided) != 1 || decided[0] != DISSOLVE_PASSED {
+		t.Fatalf("Expected the vote to pass once the last pending voter left, got %v", decided)
+	}
+	if !room.IsDissolved() || room.GetDissolveVote() != nil || room.GetFSM().IsPaused() {
+		t.Error("Expected the vote to be closed and the room dissolved")
+	}
+}
+
+func TestDissolveVoterLeavesMajority(t *testing.T) {
+	room := newDissolveTestRoom(509, DISSOLVE_MAJORITY, 4)
+	var decided []DissolveOutcome
+	room.SetDissolveHook(func(outcome DissolveOutcome) {
+		decided = append(decided, outcome)
+	})
+	room.ProposeDissolve(1, time.Minute)
+	room.CastDissolveVote(2, true)
+
+	// 2 票同意、4 人投票时未过半；一名未投票的玩家离开后 2/3 过半
+	room.RemovePlayer(4)
+	if len(decided) != 1 || decided[0] != DISSOLVE_PASSED {
+		t.Errorf("Expected 2 of 3 to pass after a voter left, got %v", decided)
+	}
+
+	// 没有投票时移除玩家不调用回调
+	room = newDissolveTestRoom(510, DISSOLVE_MAJORITY, 3)
+	room.SetDissolveHook(func(DissolveOutcome) {
+		t.Error("Expected no callback without a vote in progress")
+	})
+	room.RemovePlayer(3)
+}
@@ -3,6 +3,8 @@ package logic
 import (
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 	"xizexcample/internal/pkg/logger"
 )
 
@@ -17,12 +19,43 @@ const (
 	STATE_BETTING
 	STATE_SHOWDOWN
 	STATE_SETTLEMENT
+	STATE_CLOSED // 本场对局已结束，房间即将关闭
 )
 
-// RoomFSM 房间状态机
+// String 返回状态的名称
+func (s GameState) String() string {
+	switch s {
+	case STATE_WAITING_FOR_PLAYERS:
+		return "waiting_for_players"
+	case STATE_DEALING:
+		return "dealing"
+	case STATE_BIDDING:
+		return "bidding"
+	case STATE_BETTING:
+		return "betting"
+	case STATE_SHOWDOWN:
+		return "showdown"
+	case STATE_SETTLEMENT:
+		return "settlement"
+	case STATE_CLOSED:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// RoomFSM 房间状态机。状态机的字段由自己的锁保护，可以在管理后台、快照等其他协程中读取；
+// 持有状态机的锁时不会调用房间的方法，因此可以在持有房间的锁时调用状态机
 type RoomFSM struct {
 	currentState GameState
+	paused       bool      // 暂停期间不允许任何牌局操作和状态转换，例如解散投票进行中
+	roundID      string    // 当前牌局的ID，开局时生成
+	startedAt    time.Time // 当前牌局的开局时间
+	lastResult   *RoundResult
 	room         *Room
+	onTransition func(GameState) // 每次状态转换成功后调用，例如保存房间快照
+	startGate    func() bool     // 返回 false 时不允许开始新的一局，例如服务器停服排空期间
+	mu           sync.RWMutex
 }
 
 // NewRoomFSM 创建一个新的房间状态机
@@ -35,41 +68,144 @@ func NewRoomFSM(room *Room) *RoomFSM {
 
 // GetCurrentState 获取当前状态
 func (fsm *RoomFSM) GetCurrentState() GameState {
+	fsm.mu.RLock()
+	defer fsm.mu.RUnlock()
 	return fsm.currentState
 }
 
+// Pause 暂停当前阶段
+func (fsm *RoomFSM) Pause() {
+	fsm.mu.Lock()
+	fsm.paused = true
+	state := fsm.currentState
+	fsm.mu.Unlock()
+	fsm.room.Logger().Info("FSM paused", "state", state.String())
+}
+
+// Resume 恢复当前阶段
+func (fsm *RoomFSM) Resume() {
+	fsm.mu.Lock()
+	fsm.paused = false
+	state := fsm.currentState
+	fsm.mu.Unlock()
+	fsm.room.Logger().Info("FSM resumed", "state", state.String())
+}
+
+// IsPaused 检查状态机是否处于暂停中
+func (fsm *RoomFSM) IsPaused() bool {
+	fsm.mu.RLock()
+	defer fsm.mu.RUnlock()
+	return fsm.paused
+}
+
+// CanAct 检查当前是否可以执行属于指定阶段的操作
+func (fsm *RoomFSM) CanAct(state GameState) bool {
+	fsm.mu.RLock()
+	defer fsm.mu.RUnlock()
+	return !fsm.paused && fsm.currentState == state
+}
+
 // TransitionTo 转换到新状态
 func (fsm *RoomFSM) TransitionTo(newState GameState) error {
+	fsm.mu.Lock()
 	oldState := fsm.currentState
+	if fsm.paused {
+		fsm.mu.Unlock()
+		err := fmt.Errorf("cannot transition from %d to %d while paused", oldState, newState)
+		fsm.room.Logger().Error("FSM transition rejected", logger.Err(err))
+		return err
+	}
 	// 定义状态转换规则
 	validTransitions := map[GameState][]GameState{
 		STATE_WAITING_FOR_PLAYERS: {STATE_DEALING},
-		STATE_DEALING:             {STATE_BIDDING},
+		STATE_DEALING:             {STATE_BIDDING, STATE_BETTING, STATE_SHOWDOWN}, // 按玩法决定是否抢庄、是否下注
 		STATE_BIDDING:             {STATE_BETTING},
 		STATE_BETTING:             {STATE_SHOWDOWN},
 		STATE_SHOWDOWN:            {STATE_SETTLEMENT},
-		STATE_SETTLEMENT:          {STATE_WAITING_FOR_PLAYERS}, // 一局结束后，等待下一局
+		STATE_SETTLEMENT:          {STATE_WAITING_FOR_PLAYERS, STATE_CLOSED}, // 一局结束后，等待下一局或结束本场对局
 	}
 
 	// 检查转换是否有效
-	if allowedStates, exists := validTransitions[fsm.currentState]; exists {
+	if allowedStates, exists := validTransitions[oldState]; exists {
 		for _, allowedState := range allowedStates {
 			if newState == allowedState {
 				fsm.currentState = newState
-				logger.InfoLogger.Printf("Room %d FSM transitioned from %d to %d", fsm.room.ID, oldState, newState)
+				hook := fsm.onTransition
+				fsm.mu.Unlock()
+				fsm.room.Logger().Info("FSM transitioned", "from", oldState.String(), "to", newState.String())
+				if hook != nil {
+					hook(newState)
+				}
 				return nil
 			}
 		}
 	}
+	fsm.mu.Unlock()
 
-	err := fmt.Errorf("invalid state transition from %d to %d", fsm.currentState, newState)
-	logger.ErrorLogger.Printf("Room %d FSM error: %v", fsm.room.ID, err)
+	err := fmt.Errorf("invalid state transition from %d to %d", oldState, newState)
+	fsm.room.Logger().Error("FSM transition rejected", logger.Err(err))
 	return err
 }
 
+// Abort 作废当前牌局并回到等待阶段：清除牌局ID、开局时间和暂停状态，然后调用状态转换回调。
+// 不经过 TransitionTo 的转换规则，只用于管理员关闭房间等需要立即中止牌局的情况
+func (fsm *RoomFSM) Abort() {
+	fsm.mu.Lock()
+	oldState := fsm.currentState
+	fsm.currentState = STATE_WAITING_FOR_PLAYERS
+	fsm.paused = false
+	fsm.roundID = ""
+	fsm.startedAt = time.Time{}
+	hook := fsm.onTransition
+	fsm.mu.Unlock()
+
+	fsm.room.Logger().Info("FSM aborted round", "from", oldState.String())
+	if hook != nil {
+		hook(STATE_WAITING_FOR_PLAYERS)
+	}
+}
+
+// roundState 同时获取当前阶段、牌局ID和开局时间，用于生成一致的快照
+func (fsm *RoomFSM) roundState() (GameState, string, time.Time) {
+	fsm.mu.RLock()
+	defer fsm.mu.RUnlock()
+	return fsm.currentState, fsm.roundID, fsm.startedAt
+}
+
+// restore 从快照恢复状态机的阶段和牌局
+func (fsm *RoomFSM) restore(state GameState, roundID string, startedAt time.Time) {
+	fsm.mu.Lock()
+	defer fsm.mu.Unlock()
+	fsm.currentState = state
+	fsm.roundID = roundID
+	fsm.startedAt = startedAt
+}
+
+// SetTransitionHook 设置状态转换成功后的回调，调用时不持有房间和状态机的锁
+func (fsm *RoomFSM) SetTransitionHook(hook func(GameState)) {
+	fsm.mu.Lock()
+	defer fsm.mu.Unlock()
+	fsm.onTransition = hook
+}
+
+// SetStartGate 设置开局前的检查，返回 false 时不允许开始新的一局
+func (fsm *RoomFSM) SetStartGate(gate func() bool) {
+	fsm.mu.Lock()
+	defer fsm.mu.Unlock()
+	fsm.startGate = gate
+}
+
+// startAllowed 检查开局检查是否允许开始新的一局
+func (fsm *RoomFSM) startAllowed() bool {
+	fsm.mu.RLock()
+	gate := fsm.startGate
+	fsm.mu.RUnlock()
+	return gate == nil || gate()
+}
+
 // CanStartGame 检查是否可以开始游戏
 func (fsm *RoomFSM) CanStartGame() bool {
-	return fsm.currentState == STATE_WAITING_FOR_PLAYERS && fsm.room.GetPlayerCount() >= 2
+	return fsm.CanAct(STATE_WAITING_FOR_PLAYERS) && fsm.startAllowed() && fsm.room.GetPlayerCount() >= fsm.room.Settings.MinPlayers
 }
 
 // StartGame 开始游戏，转换到发牌状态
@@ -81,23 +217,62 @@ func (fsm *RoomFSM) StartGame() error {
 	// TODO: 广播 S2C_GameStartNtf 给所有玩家
 	// broadcastGameStart(fsm.room)
 
+	return fsm.beginRound()
+}
+
+// beginRound 为新的一局生成牌局ID并进入发牌阶段，牌局ID先于状态转换生成，以便随快照一起保存
+func (fsm *RoomFSM) beginRound() error {
+	fsm.mu.Lock()
+	fsm.startedAt = time.Now()
+	fsm.roundID = fmt.Sprintf("%d-%d", fsm.room.ID, fsm.startedAt.UnixNano())
+	fsm.mu.Unlock()
 	return fsm.TransitionTo(STATE_DEALING)
 }
 
+// GetRoundID 获取当前牌局的ID
+func (fsm *RoomFSM) GetRoundID() string {
+	fsm.mu.RLock()
+	defer fsm.mu.RUnlock()
+	return fsm.roundID
+}
+
+// GetRoundStart 获取当前牌局的开局时间
+func (fsm *RoomFSM) GetRoundStart() time.Time {
+	fsm.mu.RLock()
+	defer fsm.mu.RUnlock()
+	return fsm.startedAt
+}
+
+// CanForceStart 检查是否可以强制开局：已准备的玩家达到最少开局人数即可
+func (fsm *RoomFSM) CanForceStart() bool {
+	return fsm.CanAct(STATE_WAITING_FOR_PLAYERS) && fsm.startAllowed() && fsm.room.GetReadyPlayerCount() >= fsm.room.Settings.MinPlayers
+}
+
+// ForceStartGame 强制开局，未准备的玩家不参与本局
+func (fsm *RoomFSM) ForceStartGame() error {
+	if !fsm.CanForceStart() {
+		return errors.New("not enough ready players to start")
+	}
+	return fsm.beginRound()
+}
+
 // DealCards 发牌
 func (fsm *RoomFSM) DealCards() error {
-	if fsm.currentState != STATE_DEALING {
+	if fsm.GetCurrentState() != STATE_DEALING {
 		return errors.New("cannot deal cards in current state")
 	}
 
 	// 重置并洗牌
 	fsm.room.ResetDeck()
 
-	// 给每个玩家发5张牌
+	// 给每个已准备的玩家发5张牌
 	for _, player := range fsm.room.GetPlayers() {
+		if player.GetStatus() != STATUS_READY {
+			continue
+		}
 		err := fsm.room.DealCardsToPlayer(player.ID, 5)
 		if err != nil {
-			logger.ErrorLogger.Printf("Failed to deal cards to player %d in room %d: %v", player.ID, fsm.room.ID, err)
+			fsm.room.Logger().Error("Failed to deal cards", logger.PlayerID(player.ID), logger.Err(err))
 			continue
 		}
 		// 更新玩家状态为 PLAYING
@@ -107,13 +282,23 @@ func (fsm *RoomFSM) DealCards() error {
 		// broadcastDealCards(player, player.GetHand())
 	}
 
-	// 发牌完成后，转换到抢庄状态
-	return fsm.TransitionTo(STATE_BIDDING)
+	// 发牌完成后按玩法进入下一阶段：抢庄玩法进入抢庄，固定庄家确定庄家后直接下注，通比牛牛直接摊牌
+	switch rules := fsm.room.Settings.RuleSet; {
+	case rules.HasBidding():
+		return fsm.TransitionTo(STATE_BIDDING)
+	case rules.HasBanker():
+		if fsm.room.assignFixedBanker() == nil {
+			return errors.New("no player to be the fixed banker")
+		}
+		return fsm.TransitionTo(STATE_BETTING)
+	default:
+		return fsm.TransitionTo(STATE_SHOWDOWN)
+	}
 }
 
 // BidBanker 抢庄
 func (fsm *RoomFSM) BidBanker() error {
-	if fsm.currentState != STATE_BIDDING {
+	if fsm.GetCurrentState() != STATE_BIDDING {
 		return errors.New("cannot bid banker in current state")
 	}
 	// TODO: 实现抢庄逻辑，例如选择抢庄倍数最高的玩家
@@ -123,7 +308,7 @@ func (fsm *RoomFSM) BidBanker() error {
 
 // PlaceBet 下注
 func (fsm *RoomFSM) PlaceBet() error {
-	if fsm.currentState != STATE_BETTING {
+	if fsm.GetCurrentState() != STATE_BETTING {
 		return errors.New("cannot place bet in current state")
 	}
 	// TODO: 实现下注逻辑
@@ -133,7 +318,7 @@ func (fsm *RoomFSM) PlaceBet() error {
 
 // Showdown 摊牌
 func (fsm *RoomFSM) Showdown() error {
-	if fsm.currentState != STATE_SHOWDOWN {
+	if fsm.GetCurrentState() != STATE_SHOWDOWN {
 		return errors.New("cannot showdown in current state")
 	}
 	// TODO: 实现摊牌和结算逻辑
@@ -143,10 +328,53 @@ func (fsm *RoomFSM) Showdown() error {
 
 // Settlement 结算
 func (fsm *RoomFSM) Settlement() error {
-	if fsm.currentState != STATE_SETTLEMENT {
+	if fsm.GetCurrentState() != STATE_SETTLEMENT {
 		return errors.New("cannot settlement in current state")
 	}
-	// TODO: 实现结算逻辑，更新玩家分数
-	// 结算完成后，转换到等待玩家状态，准备下一局
+
+	// 计算参与本局的玩家输赢并更新分数
+	var participants []*Player
+	var banker *Player
+	for _, player := range fsm.room.GetPlayers() {
+		if len(player.GetHand()) != 5 {
+			continue
+		}
+		participants = append(participants, player)
+		if player.IsBanker() {
+			banker = player
+		}
+	}
+	roundID := fsm.GetRoundID()
+	results, payments := settleRound(fsm.room.Settings.BaseBet, participants, banker)
+	applySettlement(fsm.room.GetWallet(), roundID, fsm.room.Settings, participants, results, payments)
+	result := RoundResult{
+		RoundID:     roundID,
+		Round:       fsm.room.nextRound(),
+		ShuffleSeed: fsm.room.GetShuffleSeed(),
+		StartedAt:   fsm.GetRoundStart(),
+		SettledAt:   time.Now(),
+		Results:     results,
+	}
+	if banker != nil {
+		result.BankerID = banker.ID
+	}
+	fsm.mu.Lock()
+	fsm.lastResult = &result
+	fsm.mu.Unlock()
+	fsm.room.recordPushState(result)
+	sessionOver := fsm.room.recordRound(result)
+	fsm.room.resetRound()
+
+	// 结算完成后，打满局数则结束本场对局，否则等待下一局
+	if sessionOver {
+		return fsm.TransitionTo(STATE_CLOSED)
+	}
 	return fsm.TransitionTo(STATE_WAITING_FOR_PLAYERS)
 }
+
+// GetLastResult 获取最近一局的结算结果，还没有结算过时返回 nil
+func (fsm *RoomFSM) GetLastResult() *RoundResult {
+	fsm.mu.RLock()
+	defer fsm.mu.RUnlock()
+	return fsm.lastResult
+}
@@ -15,8 +15,8 @@ func TestRoomFSMBidBanker(t *testing.T) {
 	}
 
 	// 模拟完整的状态流
-	room.AddPlayer(NewPlayer(1, "p1"))
-	room.AddPlayer(NewPlayer(2, "p2"))
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
 	err = fsm.StartGame()
 	if err != nil {
 		t.Fatalf("StartGame failed: %v", err)
@@ -48,8 +48,8 @@ func TestRoomFSMPlaceBet(t *testing.T) {
 	}
 
 	// 模拟完整的状态流
-	room.AddPlayer(NewPlayer(1, "p1"))
-	room.AddPlayer(NewPlayer(2, "p2"))
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
 	fsm.StartGame()
 	fsm.DealCards()
 	fsm.BidBanker()
@@ -76,8 +76,8 @@ func TestRoomFSMShowdown(t *testing.T) {
 	}
 
 	// 模拟完整的状态流
-	room.AddPlayer(NewPlayer(1, "p1"))
-	room.AddPlayer(NewPlayer(2, "p2"))
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
 	fsm.StartGame()
 	fsm.DealCards()
 	fsm.BidBanker()
@@ -105,8 +105,8 @@ func TestRoomFSMSettlement(t *testing.T) {
 	}
 
 	// 模拟完整的状态流
-	room.AddPlayer(NewPlayer(1, "p1"))
-	room.AddPlayer(NewPlayer(2, "p2"))
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
 	fsm.StartGame()
 	fsm.DealCards()
 	fsm.BidBanker()
@@ -124,6 +124,65 @@ func TestRoomFSMSettlement(t *testing.T) {
 	}
 }
 
+func TestRoomFSMRuleSetFlow(t *testing.T) {
+	tests := []struct {
+		ruleSet  RuleSet
+		state    GameState // 发牌后进入的阶段
+		bankerID int64
+	}{
+		{RULE_BANKER_BID, STATE_BIDDING, 0},
+		{RULE_FREE_BID, STATE_BIDDING, 0},
+		{RULE_FIXED_BANKER, STATE_BETTING, 2}, // 房主坐庄
+		{RULE_ALL_COMPARE, STATE_SHOWDOWN, 0},
+	}
+	for _, tt := range tests {
+		settings := DefaultRoomSettings()
+		settings.RuleSet = tt.ruleSet
+		room := NewRoomWithSettings(308, settings)
+		for i := int64(1); i <= 3; i++ {
+			p := NewPlayer(i, "p", nil)
+			room.AddPlayer(p)
+			p.SetStatus(STATUS_READY)
+		}
+		room.SetOwner(2)
+		fsm := room.GetFSM()
+		fsm.StartGame()
+		if err := fsm.DealCards(); err != nil {
+			t.Fatalf("rule set %d: DealCards failed: %v", tt.ruleSet, err)
+		}
+		if fsm.GetCurrentState() != tt.state {
+			t.Errorf("rule set %d: expected state %s after dealing, got %s", tt.ruleSet, tt.state, fsm.GetCurrentState())
+		}
+		if room.GetBankerID() != tt.bankerID {
+			t.Errorf("rule set %d: expected banker %d after dealing, got %d", tt.ruleSet, tt.bankerID, room.GetBankerID())
+		}
+		room.Close()
+	}
+}
+
+func TestRoomFSMFixedBankerWithoutOwner(t *testing.T) {
+	settings := DefaultRoomSettings()
+	settings.RuleSet = RULE_FIXED_BANKER
+	room := NewRoomWithSettings(309, settings)
+	defer room.Close()
+	for i := int64(1); i <= 3; i++ {
+		p := NewPlayer(i, "p", nil)
+		room.AddPlayerAtSeat(p, int(4-i))
+		p.SetStatus(STATUS_READY)
+	}
+
+	// 没有房主时由座位顺序中第一位参与本局的玩家以一倍坐庄
+	fsm := room.GetFSM()
+	fsm.StartGame()
+	if err := fsm.DealCards(); err != nil {
+		t.Fatalf("DealCards failed: %v", err)
+	}
+	banker, err := room.GetPlayer(room.GetBankerID())
+	if err != nil || banker.GetSeat() != 1 || banker.GetBidMultiple() != 1 {
+		t.Errorf("Expected the player in seat 1 to be the banker at 1x, got %v", room.GetBankerID())
+	}
+}
+
 func TestRoomFSMInvalidTransition(t *testing.T) {
 	room := NewRoom(305)
 	fsm := NewRoomFSM(room)
@@ -134,3 +193,62 @@ func TestRoomFSMInvalidTransition(t *testing.T) {
 		t.Error("Expected error for invalid transition, but got nil")
 	}
 }
+
+func TestRoomFSMMinPlayers(t *testing.T) {
+	settings := DefaultRoomSettings()
+	settings.MinPlayers = 3
+	room := NewRoomWithSettings(306, settings)
+	fsm := room.GetFSM()
+
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	if fsm.CanStartGame() {
+		t.Error("Expected game not to start below the minimum player count")
+	}
+
+	room.AddPlayer(NewPlayer(3, "p3", nil))
+	if !fsm.CanStartGame() {
+		t.Error("Expected game to start once the minimum player count is reached")
+	}
+}
+
+func TestRoomFSMConcurrentAccess(t *testing.T) {
+	room := NewRoom(307)
+	defer room.Close()
+	fsm := room.GetFSM()
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+
+	// 其他协程（管理后台、快照循环）读取状态机的同时，牌局在房间的 Do 中进行，需在 -race 下无数据竞争
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			_ = fsm.GetCurrentState()
+			_ = fsm.GetRoundID()
+			_ = fsm.IsPaused()
+			_ = room.Snapshot()
+		}
+	}()
+
+	for i := 0; i < 5; i++ {
+		room.Do(func() {
+			for _, p := range room.GetPlayers() {
+				p.SetStatus(STATUS_READY)
+			}
+			fsm.StartGame()
+			fsm.DealCards()
+			fsm.Pause()
+			fsm.Resume()
+			fsm.BidBanker()
+			fsm.PlaceBet()
+			fsm.Showdown()
+			fsm.Settlement()
+		})
+	}
+	<-done
+
+	if fsm.GetCurrentState() != STATE_WAITING_FOR_PLAYERS || fsm.GetLastResult() == nil {
+		t.Errorf("Expected every round to settle, got state %s", fsm.GetCurrentState())
+	}
+}
@@ -0,0 +1,179 @@
+package logic
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// ErrNotOwner 非房主执行房主操作时返回
+var ErrNotOwner = errors.New("only the room owner can do this")
+
+// SetOwner 设置房主
+func (r *Room) SetOwner(playerID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ownerID = playerID
+}
+
+// GetOwnerID 获取房主ID，0 表示没有房主
+func (r *Room) GetOwnerID() int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.ownerID
+}
+
+// IsOwner 检查玩家是否是房主
+func (r *Room) IsOwner(playerID int64) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.ownerID != 0 && r.ownerID == playerID
+}
+
+// assignFixedBanker 固定庄家玩法中由房主以一倍坐庄；房主不参与本局或房间没有房主时，
+// 由座位顺序中第一位参与本局的玩家坐庄。返回庄家，没有参与本局的玩家时返回 nil
+func (r *Room) assignFixedBanker() *Player {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	banker := r.Players[r.ownerID]
+	if banker == nil || banker.GetStatus() != STATUS_PLAYING {
+		banker = nil
+		for _, playerID := range r.seats {
+			if p := r.Players[playerID]; p != nil && p.GetStatus() == STATUS_PLAYING {
+				banker = p
+				break
+			}
+		}
+	}
+	if banker != nil {
+		banker.SetBidMultiple(1)
+		banker.SetBanker(true)
+	}
+	return banker
+}
+
+// AllBidsPlaced 检查参与本局的玩家是否都已抢庄（包括选择不抢）
+func (r *Room) AllBidsPlaced() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.Players {
+		if p.GetStatus() == STATUS_PLAYING && !p.HasBid() {
+			return false
+		}
+	}
+	return true
+}
+
+// ChooseBanker 抢庄结束时由抢庄倍数最高的玩家坐庄，倍数相同时随机选出一位；
+// 所有人都不抢（超时未抢庄视为不抢）时从参与本局的玩家中随机选出一位以一倍坐庄。
+// 返回庄家，没有参与本局的玩家时返回 nil
+func (r *Room) ChooseBanker() *Player {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var candidates []*Player
+	highest := int32(0)
+	for _, playerID := range r.seats {
+		p := r.Players[playerID]
+		if p == nil || p.GetStatus() != STATUS_PLAYING {
+			continue
+		}
+		switch multiple := p.GetBidMultiple(); {
+		case multiple > highest:
+			highest = multiple
+			candidates = []*Player{p}
+		case multiple == highest:
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	banker := candidates[rand.Intn(len(candidates))]
+	if highest == 0 {
+		banker.SetBidMultiple(1)
+	}
+	banker.SetBanker(true)
+	return banker
+}
+
+// passOwnershipLocked 房主离开后，将房主身份交给座位顺序中的下一位玩家，调用方需持有写锁
+func (r *Room) passOwnershipLocked() {
+	r.ownerID = 0
+	for _, playerID := range r.seats {
+		if playerID != 0 {
+			r.ownerID = playerID
+			return
+		}
+	}
+}
+
+// TransferOwnership 房主将房主身份转让给房间内的另一名玩家
+func (r *Room) TransferOwnership(actorID, targetID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ownerID == 0 || r.ownerID != actorID {
+		return ErrNotOwner
+	}
+	if _, exists := r.Players[targetID]; !exists {
+		return errors.New("target player not in room")
+	}
+	r.ownerID = targetID
+	return nil
+}
+
+// KickPlayer 房主在两局之间将玩家踢出房间
+func (r *Room) KickPlayer(actorID, targetID int64) error {
+	if !r.IsOwner(actorID) {
+		return ErrNotOwner
+	}
+	if actorID == targetID {
+		return errors.New("owner cannot kick themselves")
+	}
+	if !r.FSM.CanAct(STATE_WAITING_FOR_PLAYERS) {
+		return errors.New("can only kick players between rounds")
+	}
+	return r.RemovePlayer(targetID)
+}
+
+// LockSeat 房主锁定或解锁一个空座位，锁定的座位不能入座
+func (r *Room) LockSeat(actorID int64, seat int, locked bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ownerID == 0 || r.ownerID != actorID {
+		return ErrNotOwner
+	}
+	if seat < 1 || seat > len(r.seats) {
+		return errors.New("invalid seat")
+	}
+	if r.seats[seat-1] != 0 {
+		return errors.New("seat is occupied")
+	}
+	r.lockedSeats[seat-1] = locked
+	return nil
+}
+
+// GetLockedSeats 获取被锁定的座位号列表
+func (r *Room) GetLockedSeats() []int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seats := make([]int, 0)
+	for i, locked := range r.lockedSeats {
+		if locked {
+			seats = append(seats, i+1)
+		}
+	}
+	return seats
+}
+
+// ForceStart 房主在已准备人数达到最少开局人数时强制开局，无需等待所有人准备
+func (r *Room) ForceStart(actorID int64) error {
+	if !r.IsOwner(actorID) {
+		return ErrNotOwner
+	}
+	return r.FSM.ForceStartGame()
+}
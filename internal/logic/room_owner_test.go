@@ -0,0 +1,178 @@
+package logic
+
+import (
+	"testing"
+)
+
+func TestRoomOwnerPassesOnLeave(t *testing.T) {
+	room := NewRoom(401)
+	room.AddPlayerAtSeat(NewPlayer(1, "owner", nil), 3)
+	room.AddPlayerAtSeat(NewPlayer(2, "p2", nil), 5)
+	room.AddPlayerAtSeat(NewPlayer(3, "p3", nil), 1)
+	room.SetOwner(1)
+
+	// 房主离开后由座位顺序中的第一位玩家接任
+	room.RemovePlayer(1)
+	if room.GetOwnerID() != 3 {
+		t.Errorf("Expected ownership to pass to player 3, got %d", room.GetOwnerID())
+	}
+
+	// 最后一名玩家离开后房间没有房主
+	room.RemovePlayer(3)
+	room.RemovePlayer(2)
+	if room.GetOwnerID() != 0 {
+		t.Errorf("Expected empty room to have no owner, got %d", room.GetOwnerID())
+	}
+}
+
+func TestRoomTransferOwnership(t *testing.T) {
+	room := NewRoom(402)
+	room.AddPlayer(NewPlayer(1, "owner", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	room.SetOwner(1)
+
+	if err := room.TransferOwnership(2, 2); err != ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner, got %v", err)
+	}
+	if err := room.TransferOwnership(1, 99); err == nil {
+		t.Error("Expected error for transferring to a player not in the room, but got nil")
+	}
+	if err := room.TransferOwnership(1, 2); err != nil {
+		t.Fatalf("TransferOwnership failed: %v", err)
+	}
+	if !room.IsOwner(2) || room.IsOwner(1) {
+		t.Error("Expected player 2 to be the new owner")
+	}
+}
+
+func TestRoomKickPlayer(t *testing.T) {
+	room := NewRoom(403)
+	room.AddPlayer(NewPlayer(1, "owner", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	room.AddPlayer(NewPlayer(3, "p3", nil))
+	room.SetOwner(1)
+
+	if err := room.KickPlayer(2, 3); err != ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner, got %v", err)
+	}
+	if err := room.KickPlayer(1, 1); err == nil {
+		t.Error("Expected error for owner kicking themselves, but got nil")
+	}
+	if err := room.KickPlayer(1, 3); err != nil {
+		t.Fatalf("KickPlayer failed: %v", err)
+	}
+	if _, err := room.GetPlayer(3); err == nil {
+		t.Error("Expected kicked player to be removed from the room")
+	}
+
+	// 牌局进行中不能踢人
+	room.GetFSM().StartGame()
+	if err := room.KickPlayer(1, 2); err == nil {
+		t.Error("Expected error for kicking during a round, but got nil")
+	}
+}
+
+func TestRoomLockSeat(t *testing.T) {
+	settings := DefaultRoomSettings()
+	settings.MaxPlayers = 3
+	room := NewRoomWithSettings(404, settings)
+	room.AddPlayer(NewPlayer(1, "owner", nil))
+	room.SetOwner(1)
+
+	if err := room.LockSeat(1, 1, true); err == nil {
+		t.Error("Expected error for locking an occupied seat, but got nil")
+	}
+	if err := room.LockSeat(1, 2, true); err != nil {
+		t.Fatalf("LockSeat failed: %v", err)
+	}
+
+	// 锁定的座位不能入座，自动分配会跳过
+	if err := room.AddPlayerAtSeat(NewPlayer(2, "p2", nil), 2); err == nil {
+		t.Error("Expected error for taking a locked seat, but got nil")
+	}
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	p2, _ := room.GetPlayer(2)
+	if p2.GetSeat() != 3 {
+		t.Errorf("Expected auto-assignment to skip locked seat, got seat %d", p2.GetSeat())
+	}
+	if !room.IsFull() {
+		t.Error("Expected room to be full with its only free seat locked")
+	}
+
+	// 解锁后可以入座
+	room.LockSeat(1, 2, false)
+	if err := room.AddPlayer(NewPlayer(3, "p3", nil)); err != nil {
+		t.Errorf("Expected unlocked seat to be available, got %v", err)
+	}
+}
+
+func TestRoomForceStart(t *testing.T) {
+	room := NewRoom(405)
+	owner := NewPlayer(1, "owner", nil)
+	p2 := NewPlayer(2, "p2", nil)
+	p3 := NewPlayer(3, "p3", nil)
+	room.AddPlayer(owner)
+	room.AddPlayer(p2)
+	room.AddPlayer(p3)
+	room.SetOwner(1)
+
+	owner.SetStatus(STATUS_READY)
+	if err := room.ForceStart(1); err == nil {
+		t.Error("Expected error when fewer than MinPlayers are ready, but got nil")
+	}
+
+	p2.SetStatus(STATUS_READY)
+	if err := room.ForceStart(2); err != ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner, got %v", err)
+	}
+	if err := room.ForceStart(1); err != nil {
+		t.Fatalf("ForceStart failed: %v", err)
+	}
+
+	// 只有已准备的玩家参与本局
+	room.GetFSM().DealCards()
+	if len(owner.GetHand()) != 5 || len(p2.GetHand()) != 5 {
+		t.Error("Expected ready players to be dealt 5 cards")
+	}
+	if len(p3.GetHand()) != 0 || p3.GetStatus() == STATUS_PLAYING {
+		t.Error("Expected unready player to sit out the round")
+	}
+}
+
+func TestRoomChooseBanker(t *testing.T) {
+	room := NewRoom(406)
+	for id := int64(1); id <= 3; id++ {
+		p := NewPlayer(id, "p", nil)
+		room.AddPlayer(p)
+		p.SetStatus(STATUS_PLAYING)
+	}
+	p1, _ := room.GetPlayer(1)
+	p2, _ := room.GetPlayer(2)
+	p3, _ := room.GetPlayer(3)
+
+	// 先抢的玩家选择不抢，后抢的玩家倍数更高，由倍数最高的玩家坐庄
+	p1.PlaceBid(0)
+	if room.AllBidsPlaced() {
+		t.Error("Expected bidding to wait for the remaining players")
+	}
+	p2.PlaceBid(2)
+	p3.PlaceBid(1)
+	if !room.AllBidsPlaced() {
+		t.Error("Expected all bids to be placed")
+	}
+	banker := room.ChooseBanker()
+	if banker == nil || banker.ID != 2 || banker.GetBidMultiple() != 2 || room.GetBankerID() != 2 {
+		t.Errorf("Expected player 2 to become the banker at 2x, got %v", banker)
+	}
+
+	// 所有人都不抢时随机选出一位以一倍坐庄
+	for _, p := range []*Player{p1, p2, p3} {
+		p.resetRound()
+		p.SetStatus(STATUS_PLAYING)
+		p.PlaceBid(0)
+	}
+	banker = room.ChooseBanker()
+	if banker == nil || banker.GetBidMultiple() != 1 || room.GetBankerID() != banker.ID {
+		t.Errorf("Expected a random banker at 1x, got %v", banker)
+	}
+}
@@ -0,0 +1,151 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// MinSeats 房间最少座位数
+	MinSeats = 2
+	// MaxSeats 房间最多座位数，一副牌52张，每人5张最多支持10人
+	MaxSeats = 10
+)
+
+// RuleSet 玩法规则
+type RuleSet int
+
+const (
+	RULE_SET_UNKNOWN  RuleSet = iota
+	RULE_BANKER_BID           // 看牌抢庄
+	RULE_FREE_BID             // 自由抢庄
+	RULE_FIXED_BANKER         // 固定庄家
+	RULE_ALL_COMPARE          // 通比牛牛
+)
+
+// HasBidding 检查玩法是否有抢庄阶段，固定庄家和通比牛牛发牌后不抢庄
+func (r RuleSet) HasBidding() bool {
+	return r != RULE_FIXED_BANKER && r != RULE_ALL_COMPARE
+}
+
+// HasBanker 检查玩法是否有庄家，通比牛牛没有庄家也不下注，所有人直接比牌
+func (r RuleSet) HasBanker() bool {
+	return r != RULE_ALL_COMPARE
+}
+
+// freeBidMultiples 自由抢庄不加倍，抢到即以一倍坐庄
+var freeBidMultiples = []int32{1}
+
+// DissolveRule 解散房间的投票规则
+type DissolveRule int
+
+const (
+	DISSOLVE_UNANIMOUS DissolveRule = iota // 全员同意
+	DISSOLVE_MAJORITY                      // 过半数同意
+)
+
+// PayoutPolicy 庄家余额不足以赔付所有赢家时的赔付策略
+type PayoutPolicy int
+
+const (
+	PAYOUT_PROPORTIONAL PayoutPolicy = iota // 按应得比例缩减
+	PAYOUT_SEAT_ORDER                       // 从庄家下家开始按座位顺序赔付，赔完为止
+)
+
+// RoomSettings 房间设置，创建房间时确定
+type RoomSettings struct {
+	BaseBet       int64        // 底注
+	MaxPlayers    int          // 最大玩家数，即座位数
+	MinPlayers    int          // 开局所需的最少玩家数
+	RuleSet       RuleSet      // 玩法规则
+	Rounds        int          // 局数，0 表示不限局数
+	IsPrivate     bool         // 是否私人房间
+	MaxSpectators int          // 最大观战人数，0 表示不允许观战
+	DissolveRule  DissolveRule // 解散房间的投票规则
+	PayoutPolicy  PayoutPolicy // 庄家不够赔时的赔付策略
+	Rake          RakePolicy   // 抽水策略，由服务器配置，不接受客户端设置
+	BidMultiples  []int32      // 允许的抢庄倍数，升序
+	BetMultiples  []int32      // 允许的下注倍数，升序
+	PushBetCap    int32        // 推注倍数上限，0 表示不允许推注
+	PushCooldown  int          // 推注后需要间隔的局数
+}
+
+// DefaultRoomSettings 返回默认的房间设置
+func DefaultRoomSettings() RoomSettings {
+	return RoomSettings{
+		BaseBet:       1,
+		MaxPlayers:    5,
+		MinPlayers:    2,
+		RuleSet:       RULE_BANKER_BID,
+		Rounds:        0,
+		IsPrivate:     false,
+		MaxSpectators: 10,
+		DissolveRule:  DISSOLVE_UNANIMOUS,
+		PayoutPolicy:  PAYOUT_PROPORTIONAL,
+		BidMultiples:  []int32{1, 2, 3, 4},
+		BetMultiples:  []int32{1, 2, 3, 5},
+	}
+}
+
+// Validate 检查房间设置是否合法
+func (s RoomSettings) Validate() error {
+	if s.BaseBet <= 0 {
+		return errors.New("base bet must be positive")
+	}
+	if s.MaxPlayers < MinSeats || s.MaxPlayers > MaxSeats {
+		return fmt.Errorf("max players must be between %d and %d", MinSeats, MaxSeats)
+	}
+	if s.MinPlayers < MinSeats || s.MinPlayers > s.MaxPlayers {
+		return fmt.Errorf("min players must be between %d and max players", MinSeats)
+	}
+	if s.RuleSet <= RULE_SET_UNKNOWN || s.RuleSet > RULE_ALL_COMPARE {
+		return errors.New("unknown rule set")
+	}
+	if s.Rounds < 0 {
+		return errors.New("rounds must not be negative")
+	}
+	if s.MaxSpectators < 0 {
+		return errors.New("max spectators must not be negative")
+	}
+	if s.DissolveRule < DISSOLVE_UNANIMOUS || s.DissolveRule > DISSOLVE_MAJORITY {
+		return errors.New("unknown dissolve rule")
+	}
+	if s.PayoutPolicy < PAYOUT_PROPORTIONAL || s.PayoutPolicy > PAYOUT_SEAT_ORDER {
+		return errors.New("unknown payout policy")
+	}
+	if err := s.Rake.Validate(); err != nil {
+		return err
+	}
+	if err := validateMultiples("bid", s.BidMultiples); err != nil {
+		return err
+	}
+	if err := validateMultiples("bet", s.BetMultiples); err != nil {
+		return err
+	}
+	if s.PushBetCap < 0 {
+		return errors.New("push bet cap must not be negative")
+	}
+	if s.PushBetCap > 0 && s.PushBetCap <= s.BetMultiples[len(s.BetMultiples)-1] {
+		return errors.New("push bet cap must exceed the largest bet multiple")
+	}
+	if s.PushCooldown < 0 {
+		return errors.New("push cooldown must not be negative")
+	}
+	return nil
+}
+
+// validateMultiples 检查倍数列表非空、均为正数且严格升序
+func validateMultiples(name string, multiples []int32) error {
+	if len(multiples) == 0 {
+		return fmt.Errorf("%s multiples must not be empty", name)
+	}
+	for i, m := range multiples {
+		if m <= 0 {
+			return fmt.Errorf("%s multiples must be positive", name)
+		}
+		if i > 0 && m <= multiples[i-1] {
+			return fmt.Errorf("%s multiples must be strictly ascending", name)
+		}
+	}
+	return nil
+}
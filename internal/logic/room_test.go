@@ -6,8 +6,8 @@ import (
 
 func TestRoomAddPlayer(t *testing.T) {
 	room := NewRoom(201)
-	player1 := NewPlayer(1001, "Player1")
-	player2 := NewPlayer(1002, "Player2")
+	player1 := NewPlayer(1001, "Player1", nil)
+	player2 := NewPlayer(1002, "Player2", nil)
 
 	// 测试添加第一个玩家
 	err := room.AddPlayer(player1)
@@ -40,10 +40,10 @@ func TestRoomAddPlayer(t *testing.T) {
 	}
 
 	// 测试房间已满
-	player3 := NewPlayer(1003, "Player3")
-	player4 := NewPlayer(1004, "Player4")
-	player5 := NewPlayer(1005, "Player5")
-	player6 := NewPlayer(1006, "Player6")
+	player3 := NewPlayer(1003, "Player3", nil)
+	player4 := NewPlayer(1004, "Player4", nil)
+	player5 := NewPlayer(1005, "Player5", nil)
+	player6 := NewPlayer(1006, "Player6", nil)
 
 	room.AddPlayer(player3)
 	room.AddPlayer(player4)
@@ -57,8 +57,8 @@ func TestRoomAddPlayer(t *testing.T) {
 
 func TestRoomRemovePlayer(t *testing.T) {
 	room := NewRoom(202)
-	player1 := NewPlayer(1007, "Player1")
-	player2 := NewPlayer(1008, "Player2")
+	player1 := NewPlayer(1007, "Player1", nil)
+	player2 := NewPlayer(1008, "Player2", nil)
 
 	room.AddPlayer(player1)
 	room.AddPlayer(player2)
@@ -96,7 +96,7 @@ func TestRoomRemovePlayer(t *testing.T) {
 
 func TestRoomGetPlayer(t *testing.T) {
 	room := NewRoom(203)
-	player1 := NewPlayer(1009, "Player1")
+	player1 := NewPlayer(1009, "Player1", nil)
 
 	room.AddPlayer(player1)
 
@@ -116,3 +116,119 @@ func TestRoomGetPlayer(t *testing.T) {
 		t.Error("Expected error for getting non-existent player, but got nil")
 	}
 }
+
+func TestRoomPassword(t *testing.T) {
+	room := NewRoom(204)
+
+	// 未设置密码时任何输入都不需要校验
+	if room.HasPassword() {
+		t.Error("New room should not have a password")
+	}
+
+	room.SetPassword("8888")
+	if !room.HasPassword() {
+		t.Error("Expected room to have a password")
+	}
+	if !room.CheckPassword("8888") {
+		t.Error("Expected correct password to pass")
+	}
+	if room.CheckPassword("1234") {
+		t.Error("Expected wrong password to fail")
+	}
+}
+
+func TestRoomSpectators(t *testing.T) {
+	settings := DefaultRoomSettings()
+	settings.MaxPlayers = 2
+	settings.MaxSpectators = 1
+	room := NewRoomWithSettings(205, settings)
+
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+
+	// 观战者不占用座位
+	spectator := NewPlayer(3, "s1", nil)
+	if err := room.AddSpectator(spectator); err != nil {
+		t.Fatalf("AddSpectator failed: %v", err)
+	}
+	if room.GetPlayerCount() != 2 || !room.IsSpectator(3) {
+		t.Errorf("Expected 2 players and 1 spectator, got %d players", room.GetPlayerCount())
+	}
+	if spectator.GetRoomID() != room.ID {
+		t.Errorf("Spectator's room ID is not set correctly, got %d", spectator.GetRoomID())
+	}
+
+	// 超过观战人数上限
+	if err := room.AddSpectator(NewPlayer(4, "s2", nil)); err == nil {
+		t.Error("Expected error when spectator limit is reached, but got nil")
+	}
+
+	// 座位已满时不能入座
+	if err := room.TakeSeat(3, 0); err == nil {
+		t.Error("Expected error when taking a seat in a full room, but got nil")
+	}
+
+	// 有空位后在两局之间入座
+	room.RemovePlayer(2)
+	if err := room.TakeSeat(3, 0); err != nil {
+		t.Fatalf("TakeSeat failed: %v", err)
+	}
+	if room.IsSpectator(3) || room.GetPlayerCount() != 2 {
+		t.Error("Expected spectator to become a seated player")
+	}
+}
+
+func TestRoomTakeSeatDuringRound(t *testing.T) {
+	room := NewRoom(206)
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	room.AddSpectator(NewPlayer(3, "s1", nil))
+
+	room.GetFSM().StartGame()
+
+	// 牌局进行中不能入座
+	if err := room.TakeSeat(3, 0); err == nil {
+		t.Error("Expected error when taking a seat during a round, but got nil")
+	}
+}
+
+func TestRoomSeats(t *testing.T) {
+	settings := DefaultRoomSettings()
+	settings.MaxPlayers = 10
+	room := NewRoomWithSettings(207, settings)
+
+	// 指定座位
+	if err := room.AddPlayerAtSeat(NewPlayer(1, "p1", nil), 7); err != nil {
+		t.Fatalf("AddPlayerAtSeat failed: %v", err)
+	}
+	if err := room.AddPlayerAtSeat(NewPlayer(2, "p2", nil), 7); err == nil {
+		t.Error("Expected error for taking an occupied seat, but got nil")
+	}
+	if err := room.AddPlayerAtSeat(NewPlayer(2, "p2", nil), 11); err == nil {
+		t.Error("Expected error for an invalid seat, but got nil")
+	}
+
+	// 自动分配座位号最小的空座
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	room.AddPlayer(NewPlayer(3, "p3", nil))
+	p2, _ := room.GetPlayer(2)
+	if p2.GetSeat() != 1 {
+		t.Errorf("Expected auto-assigned seat 1, got %d", p2.GetSeat())
+	}
+
+	// 玩家顺序按座位号排列，多次调用结果稳定
+	for i := 0; i < 10; i++ {
+		players := room.GetPlayers()
+		if players[0].ID != 2 || players[1].ID != 3 || players[2].ID != 1 {
+			t.Fatalf("Expected players ordered by seat [2 3 1], got [%d %d %d]", players[0].ID, players[1].ID, players[2].ID)
+		}
+	}
+
+	// 离开后座位空出，可被重新分配
+	room.RemovePlayer(2)
+	room.AddPlayer(NewPlayer(4, "p4", nil))
+	p4, _ := room.GetPlayer(4)
+	if p4.GetSeat() != 1 {
+		t.Errorf("Expected freed seat 1 to be reused, got %d", p4.GetSeat())
+	}
+}
@@ -0,0 +1,85 @@
+package logic
+
+// SessionStats 一名玩家在本场对局中的累计数据
+type SessionStats struct {
+	PlayerID     int64
+	Nickname     string
+	RoundsPlayed int
+	Wins         int      // 赢分的局数
+	NetScore     int64    // 本场累计输赢
+	BestHand     []Card   // 本场最大的一手牌
+	BestCardType CardType // BestHand 的牌型
+}
+
+// Session 房间的一场对局，记录每局的分数表和每名玩家的累计数据；
+// 统计数据按玩家ID保存，玩家断线重连后仍然保留
+type Session struct {
+	TotalRounds int // 总局数，0 表示不限局数
+	Rounds      []RoundResult
+	stats       map[int64]*SessionStats
+	order       []int64 // 玩家首次参与对局的顺序
+}
+
+// newSession 创建一场新的对局
+func newSession(totalRounds int) *Session {
+	return &Session{
+		TotalRounds: totalRounds,
+		stats:       make(map[int64]*SessionStats),
+	}
+}
+
+// RoundsPlayed 返回已完成的局数
+func (s *Session) RoundsPlayed() int {
+	return len(s.Rounds)
+}
+
+// IsOver 检查是否已打满设定的局数
+func (s *Session) IsOver() bool {
+	return s.TotalRounds > 0 && len(s.Rounds) >= s.TotalRounds
+}
+
+// record 记录一局的结算结果并累计每名玩家的数据
+func (s *Session) record(round RoundResult, nicknames map[int64]string) {
+	s.Rounds = append(s.Rounds, round)
+	for _, result := range round.Results {
+		stats, exists := s.stats[result.PlayerID]
+		if !exists {
+			stats = &SessionStats{PlayerID: result.PlayerID, Nickname: nicknames[result.PlayerID]}
+			s.stats[result.PlayerID] = stats
+			s.order = append(s.order, result.PlayerID)
+		}
+		stats.RoundsPlayed++
+		stats.NetScore += result.ScoreChange
+		if result.ScoreChange > 0 {
+			stats.Wins++
+		}
+		if stats.BestHand == nil || CompareHands(handCards(result.Hand), handCards(stats.BestHand)) > 0 {
+			stats.BestHand = append([]Card(nil), result.Hand...)
+			stats.BestCardType = result.CardType
+		}
+	}
+}
+
+// Standings 返回每名玩家的累计数据，按首次参与对局的顺序排列
+func (s *Session) Standings() []SessionStats {
+	standings := make([]SessionStats, 0, len(s.order))
+	for _, playerID := range s.order {
+		standings = append(standings, *s.stats[playerID])
+	}
+	return standings
+}
+
+// clone 返回对局记录的副本
+func (s *Session) clone() *Session {
+	c := &Session{
+		TotalRounds: s.TotalRounds,
+		Rounds:      append([]RoundResult(nil), s.Rounds...),
+		stats:       make(map[int64]*SessionStats, len(s.stats)),
+		order:       append([]int64(nil), s.order...),
+	}
+	for playerID, stats := range s.stats {
+		copied := *stats
+		c.stats[playerID] = &copied
+	}
+	return c
+}
@@ -0,0 +1,101 @@
+package logic
+
+import (
+	"testing"
+)
+
+// playRound 按给定手牌打完一局，p1 坐庄，p2 下注1倍
+func playRound(t *testing.T, room *Room, hand1, hand2 []Card) {
+	t.Helper()
+	playRoundWithBet(t, room, hand1, hand2, 1)
+}
+
+// playRoundWithBet 按给定手牌和 p2 的下注倍数打完一局，p1 坐庄
+func playRoundWithBet(t *testing.T, room *Room, hand1, hand2 []Card, bet int32) {
+	t.Helper()
+	fsm := room.GetFSM()
+	p1, _ := room.GetPlayer(1)
+	p2, _ := room.GetPlayer(2)
+
+	p1.SetStatus(STATUS_READY)
+	p2.SetStatus(STATUS_READY)
+	if err := fsm.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	fsm.DealCards()
+	setHand(p1, hand1)
+	setHand(p2, hand2)
+	room.SetBanker(1)
+	fsm.BidBanker()
+	p2.PlaceBet(bet)
+	fsm.PlaceBet()
+	fsm.Showdown()
+	if err := fsm.Settlement(); err != nil {
+		t.Fatalf("Settlement failed: %v", err)
+	}
+}
+
+func TestSessionRunsFixedRounds(t *testing.T) {
+	settings := DefaultRoomSettings()
+	settings.Rounds = 2
+	room := NewRoomWithSettings(601, settings)
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+
+	playRound(t, room, noBullHand, bullBullHand)
+	if room.GetFSM().GetCurrentState() != STATE_WAITING_FOR_PLAYERS {
+		t.Fatalf("Expected WAITING_FOR_PLAYERS after round 1, got %d", room.GetFSM().GetCurrentState())
+	}
+	p1, _ := room.GetPlayer(1)
+	if p1.IsBanker() || len(p1.GetHand()) != 0 || p1.GetStatus() != STATUS_WAITING {
+		t.Error("Expected round state to be reset after settlement")
+	}
+
+	playRound(t, room, bullBullHand, noBullHand)
+	if room.GetFSM().GetCurrentState() != STATE_CLOSED {
+		t.Fatalf("Expected CLOSED after the last round, got %d", room.GetFSM().GetCurrentState())
+	}
+
+	session := room.GetSession()
+	if session.RoundsPlayed() != 2 || len(session.Rounds) != 2 || session.Rounds[1].Round != 2 {
+		t.Errorf("Expected a score table with 2 rounds, got %d", session.RoundsPlayed())
+	}
+	standings := session.Standings()
+	if len(standings) != 2 {
+		t.Fatalf("Expected standings for 2 players, got %d", len(standings))
+	}
+	for _, stats := range standings {
+		if stats.RoundsPlayed != 2 || stats.Wins != 1 {
+			t.Errorf("Player %d: expected 2 rounds and 1 win, got %d rounds and %d wins", stats.PlayerID, stats.RoundsPlayed, stats.Wins)
+		}
+		if stats.BestCardType != CARD_TYPE_BULL_BOMB {
+			t.Errorf("Player %d: expected best hand to be bull-bull, got %d", stats.PlayerID, stats.BestCardType)
+		}
+	}
+	// 第一局 p2 赢 1×1×1×4，第二局 p1 赢 1×1×1×4
+	if standings[0].NetScore != 0 || standings[1].NetScore != 0 {
+		t.Errorf("Expected both players to break even, got %d and %d", standings[0].NetScore, standings[1].NetScore)
+	}
+}
+
+func TestSessionSurvivesReconnect(t *testing.T) {
+	settings := DefaultRoomSettings()
+	settings.Rounds = 10
+	room := NewRoomWithSettings(602, settings)
+	p1 := NewPlayer(1, "p1", nil)
+	room.AddPlayer(p1)
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+
+	playRound(t, room, bullBullHand, noBullHand)
+
+	// 断线后重连，玩家对象和本场累计数据都保留
+	room.SetPlayerOffline(1)
+	p1.SetOnline(true)
+	p1.SetStatus(STATUS_WAITING)
+	playRound(t, room, bullBullHand, noBullHand)
+
+	standings := room.GetSession().Standings()
+	if standings[0].PlayerID != 1 || standings[0].Wins != 2 || standings[0].NetScore != 8 {
+		t.Errorf("Expected player 1 to keep stats across reconnect, got %+v", standings[0])
+	}
+}
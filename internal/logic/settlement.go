@@ -0,0 +1,225 @@
+package logic
+
+import (
+	"sort"
+	"time"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/wallet"
+)
+
+// MaxCardTypeMultiplier 最大的牌型倍数，用于估算一局可能输掉的最大分数
+const MaxCardTypeMultiplier int64 = 5
+
+// PlayerResult 一名玩家在一局中的结算结果
+type PlayerResult struct {
+	PlayerID    int64
+	Seat        int
+	BidMultiple int32 // 抢庄倍数，0 表示不抢
+	BetMultiple int32 // 下注倍数，庄家为0
+	Hand        []Card
+	CardType    CardType
+	ScoreChange int64 // 本局分数变化，已扣除抽水
+	Rake        int64 // 本局交给平台的抽水
+	FinalScore  int64
+}
+
+// RoundResult 一局的结算结果
+type RoundResult struct {
+	RoundID     string // 全局唯一的牌局ID，账目中以此关联
+	Round       int    // 本场对局的第几局，从1开始
+	BankerID    int64  // 通比牛牛没有庄家，为0
+	ShuffleSeed int64  // 本局洗牌使用的种子
+	StartedAt   time.Time
+	SettledAt   time.Time
+	Results     []PlayerResult
+}
+
+// CardTypeMultiplier 返回牌型对应的赔率倍数
+func CardTypeMultiplier(cardType CardType) int64 {
+	switch {
+	case cardType >= CARD_TYPE_FIVE_SMALL:
+		return MaxCardTypeMultiplier
+	case cardType == CARD_TYPE_BULL_BOMB:
+		return 4
+	case cardType == CARD_TYPE_BULL_9:
+		return 3
+	case cardType >= CARD_TYPE_BULL_7:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// handCards 将手牌转换为牌型计算所需的指针切片
+func handCards(hand []Card) []*Card {
+	cards := make([]*Card, len(hand))
+	for i := range hand {
+		cards[i] = &hand[i]
+	}
+	return cards
+}
+
+// payment 结算产生的一笔输家向赢家的支付
+type payment struct {
+	from   int64
+	to     int64
+	amount int64
+}
+
+// settleRound 计算本局参与者按牌面应得的输赢；有庄家时闲家从庄家下家开始按座位顺序逐一与庄家比牌，
+// 没有庄家（通比牛牛）时牌最大的玩家赢其余所有人
+func settleRound(baseBet int64, participants []*Player, banker *Player) ([]PlayerResult, []payment) {
+	results := make([]PlayerResult, len(participants))
+	index := make(map[int64]int, len(participants))
+	hands := make(map[int64][]*Card, len(participants))
+	for i, p := range participants {
+		hand := p.GetHand()
+		cardType, _ := CalculateBull(handCards(hand))
+		results[i] = PlayerResult{
+			PlayerID:    p.ID,
+			Seat:        p.GetSeat(),
+			BidMultiple: p.GetBidMultiple(),
+			BetMultiple: p.GetBetAmount(),
+			Hand:        hand,
+			CardType:    cardType,
+		}
+		index[p.ID] = i
+		hands[p.ID] = handCards(hand)
+	}
+
+	var payments []payment
+	transfer := func(winner, loser int64, amount int64) {
+		results[index[winner]].ScoreChange += amount
+		results[index[loser]].ScoreChange -= amount
+		payments = append(payments, payment{from: loser, to: winner, amount: amount})
+	}
+
+	if banker != nil {
+		bankerMultiple := int64(banker.GetBidMultiple())
+		if bankerMultiple < 1 {
+			bankerMultiple = 1
+		}
+		bankerIndex := index[banker.ID]
+		for offset := 1; offset < len(participants); offset++ {
+			p := participants[(bankerIndex+offset)%len(participants)]
+			betMultiple := int64(p.GetBetAmount())
+			if betMultiple < 1 {
+				betMultiple = 1
+			}
+			if CompareHands(hands[p.ID], hands[banker.ID]) > 0 {
+				amount := baseBet * bankerMultiple * betMultiple * CardTypeMultiplier(results[index[p.ID]].CardType)
+				transfer(p.ID, banker.ID, amount)
+			} else {
+				amount := baseBet * bankerMultiple * betMultiple * CardTypeMultiplier(results[index[banker.ID]].CardType)
+				transfer(banker.ID, p.ID, amount)
+			}
+		}
+	} else if len(participants) > 0 {
+		winner := participants[0]
+		for _, p := range participants[1:] {
+			if CompareHands(hands[p.ID], hands[winner.ID]) > 0 {
+				winner = p
+			}
+		}
+		amount := baseBet * CardTypeMultiplier(results[index[winner.ID]].CardType)
+		for _, p := range participants {
+			if p.ID != winner.ID {
+				transfer(winner.ID, p.ID, amount)
+			}
+		}
+	}
+
+	return results, payments
+}
+
+// capPayments 按余额封顶本局的支付，保证任何人的余额都不会为负：
+// 没有收入的付款方先付，最多付出自己的余额；有收入的付款方（庄家）随后用余额加上本局收入赔付；
+// 付款方不够赔付所有应付款时按赔付策略缩减
+func capPayments(payments []payment, balances map[int64]int64, policy PayoutPolicy) []payment {
+	available := make(map[int64]int64, len(balances))
+	receivers := make(map[int64]bool)
+	for id, balance := range balances {
+		available[id] = balance
+	}
+	for _, pay := range payments {
+		receivers[pay.to] = true
+	}
+
+	// 按付款方分组，没有收入的付款方排在前面，组内保持原有顺序
+	var payers []int64
+	owed := make(map[int64][]payment)
+	for _, p := range payments {
+		if _, seen := owed[p.from]; !seen {
+			payers = append(payers, p.from)
+		}
+		owed[p.from] = append(owed[p.from], p)
+	}
+	sort.SliceStable(payers, func(i, j int) bool {
+		return !receivers[payers[i]] && receivers[payers[j]]
+	})
+
+	capped := make([]payment, 0, len(payments))
+	pay := func(p payment, amount int64) {
+		if amount <= 0 {
+			return
+		}
+		available[p.from] -= amount
+		available[p.to] += amount
+		capped = append(capped, payment{from: p.from, to: p.to, amount: amount})
+	}
+
+	for _, payer := range payers {
+		demand := int64(0)
+		for _, p := range owed[payer] {
+			demand += p.amount
+		}
+		funds := max(available[payer], 0)
+		for _, p := range owed[payer] {
+			switch {
+			case funds >= demand:
+				pay(p, p.amount)
+			case policy == PAYOUT_SEAT_ORDER:
+				pay(p, min(p.amount, available[payer]))
+			default:
+				pay(p, p.amount*funds/demand)
+			}
+		}
+	}
+	return capped
+}
+
+// applySettlement 按余额封顶后通过钱包完成本局的所有支付，再按抽水策略向平台账户交纳抽水，
+// 最后把结算后的余额同步给玩家；结果中的分数变化以实际记账为准
+func applySettlement(w *wallet.Wallet, roundID string, settings RoomSettings, participants []*Player, results []PlayerResult, payments []payment) {
+	before := make([]int64, len(participants))
+	balances := make(map[int64]int64, len(participants))
+	for i, p := range participants {
+		before[i] = w.Balance(p.ID)
+		balances[p.ID] = before[i]
+	}
+	for _, pay := range capPayments(payments, balances, settings.PayoutPolicy) {
+		if err := w.Transfer(pay.from, pay.to, pay.amount, roundID, wallet.REASON_ROUND_SETTLEMENT); err != nil {
+			logger.L().Error("Failed to pay settlement", logger.RoundID(roundID), "amount", pay.amount, "from", pay.from, "to", pay.to, logger.Err(err))
+		}
+	}
+	netWins := make([]int64, len(participants))
+	after := make([]int64, len(participants))
+	for i, p := range participants {
+		after[i] = w.Balance(p.ID)
+		netWins[i] = after[i] - before[i]
+	}
+	rakes := settings.Rake.roundRakes(netWins, after)
+	for i, p := range participants {
+		if rake := rakes[i]; rake > 0 {
+			if err := w.Transfer(p.ID, wallet.HouseAccountID, rake, roundID, wallet.REASON_RAKE); err != nil {
+				logger.L().Error("Failed to collect rake", logger.RoundID(roundID), logger.PlayerID(p.ID), "rake", rake, logger.Err(err))
+			} else {
+				results[i].Rake = rake
+			}
+		}
+
+		results[i].FinalScore = w.Balance(p.ID)
+		results[i].ScoreChange = results[i].FinalScore - before[i]
+		p.SetScore(results[i].FinalScore)
+	}
+}
@@ -0,0 +1,407 @@
+package logic
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"xizexcample/internal/wallet"
+)
+
+var (
+	// 牛牛：10 J Q K 10
+	bullBullHand = []Card{
+		{Suit: SUIT_SPADES, Rank: RANK_TEN},
+		{Suit: SUIT_HEARTS, Rank: RANK_JACK},
+		{Suit: SUIT_CLUBS, Rank: RANK_QUEEN},
+		{Suit: SUIT_DIAMONDS, Rank: RANK_KING},
+		{Suit: SUIT_HEARTS, Rank: RANK_TEN},
+	}
+	// 无牛：2 3 4 6 8
+	noBullHand = []Card{
+		{Suit: SUIT_SPADES, Rank: RANK_TWO},
+		{Suit: SUIT_HEARTS, Rank: RANK_THREE},
+		{Suit: SUIT_CLUBS, Rank: RANK_FOUR},
+		{Suit: SUIT_DIAMONDS, Rank: RANK_SIX},
+		{Suit: SUIT_HEARTS, Rank: RANK_EIGHT},
+	}
+)
+
+// setHand 替换玩家的手牌
+func setHand(p *Player, hand []Card) {
+	p.ClearHand()
+	for _, card := range hand {
+		p.AddCard(card)
+	}
+}
+
+func TestCardTypeMultiplier(t *testing.T) {
+	cases := map[CardType]int64{
+		CARD_TYPE_NO_BULL:       1,
+		CARD_TYPE_BULL_6:        1,
+		CARD_TYPE_BULL_7:        2,
+		CARD_TYPE_BULL_8:        2,
+		CARD_TYPE_BULL_9:        3,
+		CARD_TYPE_BULL_BOMB:     4,
+		CARD_TYPE_FIVE_SMALL:    5,
+		CARD_TYPE_GOLDEN_FLOWER: 5,
+	}
+	for cardType, expected := range cases {
+		if got := CardTypeMultiplier(cardType); got != expected {
+			t.Errorf("CardTypeMultiplier(%d) = %d, expected %d", cardType, got, expected)
+		}
+	}
+}
+
+func TestSettleRoundWithBanker(t *testing.T) {
+	banker := NewPlayer(1, "banker", nil)
+	p2 := NewPlayer(2, "p2", nil)
+	p3 := NewPlayer(3, "p3", nil)
+	banker.SetBanker(true)
+	banker.SetBidMultiple(2)
+	p2.PlaceBet(3)
+	p3.PlaceBet(1)
+	setHand(banker, noBullHand)
+	setHand(p2, bullBullHand)
+	setHand(p3, noBullHand) // 与庄家同牌型同点数时比最大单张，平局算庄家赢
+
+	results, payments := settleRound(1, []*Player{banker, p2, p3}, banker)
+
+	// p2 牛牛赢庄家：底注1 × 抢庄2 × 下注3 × 牛牛4 = 24
+	// p3 输给庄家：底注1 × 抢庄2 × 下注1 × 无牛1 = 2
+	expected := map[int64]int64{1: -22, 2: 24, 3: -2}
+	for _, r := range results {
+		if r.ScoreChange != expected[r.PlayerID] {
+			t.Errorf("Player %d score change = %d, expected %d", r.PlayerID, r.ScoreChange, expected[r.PlayerID])
+		}
+	}
+	if len(payments) != 2 {
+		t.Errorf("Expected 2 payments, got %d", len(payments))
+	}
+}
+
+func TestSettleRoundAllCompare(t *testing.T) {
+	p1 := NewPlayer(1, "p1", nil)
+	p2 := NewPlayer(2, "p2", nil)
+	p3 := NewPlayer(3, "p3", nil)
+	setHand(p1, noBullHand)
+	setHand(p2, bullBullHand)
+	setHand(p3, noBullHand)
+
+	results, _ := settleRound(5, []*Player{p1, p2, p3}, nil)
+
+	// 牌最大的 p2 赢其余每人 底注5 × 牛牛4
+	expected := map[int64]int64{1: -20, 2: 40, 3: -20}
+	for _, r := range results {
+		if r.ScoreChange != expected[r.PlayerID] {
+			t.Errorf("Player %d score change = %d, expected %d", r.PlayerID, r.ScoreChange, expected[r.PlayerID])
+		}
+	}
+}
+
+func TestSettlementRecordsLedger(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	room := NewRoom(701)
+	room.SetWallet(w)
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+
+	playRound(t, room, noBullHand, bullBullHand)
+
+	// p2 牛牛赢庄家 p1：1 × 1 × 1 × 4
+	if w.Balance(1) != InitialScore-4 || w.Balance(2) != InitialScore+4 {
+		t.Errorf("Expected balances %d and %d, got %d and %d", InitialScore-4, InitialScore+4, w.Balance(1), w.Balance(2))
+	}
+	p1, _ := room.GetPlayer(1)
+	if p1.GetScore() != w.Balance(1) {
+		t.Errorf("Expected player score to follow the wallet balance, got %d", p1.GetScore())
+	}
+
+	entries, _ := w.Entries(2)
+	last := entries[len(entries)-1]
+	roundID := room.GetSession().Rounds[0].RoundID
+	if last.Reason != wallet.REASON_ROUND_SETTLEMENT || last.RoundID != roundID || last.CounterpartyID != 1 {
+		t.Errorf("Expected a settlement entry for round %s against player 1, got %+v", roundID, last)
+	}
+	if err := w.Reconcile(); err != nil {
+		t.Errorf("Reconcile failed: %v", err)
+	}
+}
+
+func TestBalanceChecks(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	settings := DefaultRoomSettings()
+	settings.BaseBet = 10
+	room := NewRoomWithSettings(702, settings)
+	room.SetWallet(w)
+	banker := NewPlayer(1, "banker", nil)
+	p2 := NewPlayer(2, "p2", nil)
+	p3 := NewPlayer(3, "p3", nil)
+	room.AddPlayer(banker)
+	room.AddPlayer(p2)
+	room.AddPlayer(p3)
+	setHand(banker, noBullHand)
+	setHand(p2, noBullHand)
+	setHand(p3, noBullHand)
+
+	// 初始 1000 分，底注 10：抢 10 倍需要 10 × 10 × 5 × 2 = 1000
+	if err := room.CheckBidBalance(banker, 10); err != nil {
+		t.Errorf("Expected bid of 10 to be affordable, got %v", err)
+	}
+	if err := room.CheckBidBalance(banker, 11); !errors.Is(err, wallet.ErrInsufficientBalance) {
+		t.Errorf("Expected ErrInsufficientBalance for bid of 11, got %v", err)
+	}
+
+	banker.SetBanker(true)
+	banker.SetBidMultiple(4)
+	// 每注最多输 10 × 4 × 倍数 × 5；闲家 1000 分最多下 5 倍
+	if err := room.CheckBetBalance(p2, 6); err == nil {
+		t.Error("Expected error for a bet the player cannot cover, but got nil")
+	}
+	if err := room.CheckBetBalance(p2, 3); err != nil {
+		t.Fatalf("Expected bet of 3 to be affordable, got %v", err)
+	}
+	p2.PlaceBet(3)
+	// 庄家已承担 600，再下 3 倍需要共 1200
+	if err := room.CheckBetBalance(p3, 3); err == nil {
+		t.Error("Expected error when the banker cannot cover all bets, but got nil")
+	}
+	if err := room.CheckBetBalance(p3, 2); err != nil {
+		t.Errorf("Expected bet of 2 to be covered by the banker, got %v", err)
+	}
+
+	// 余额不足一手最大牌型的底注时不能准备
+	w.Transfer(3, 2, 960, "", wallet.REASON_ROUND_SETTLEMENT)
+	if err := room.CheckPlayBalance(p3); err == nil {
+		t.Error("Expected error for a player who cannot cover a single hand, but got nil")
+	}
+}
+
+func TestAllowedMultiples(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	settings := DefaultRoomSettings()
+	settings.BaseBet = 100
+	room := NewRoomWithSettings(703, settings)
+	room.SetWallet(w)
+	banker := NewPlayer(1, "banker", nil)
+	p2 := NewPlayer(2, "p2", nil)
+	p3 := NewPlayer(3, "p3", nil)
+	room.AddPlayer(banker)
+	room.AddPlayer(p2)
+	room.AddPlayer(p3)
+	setHand(banker, noBullHand)
+	setHand(p2, noBullHand)
+	setHand(p3, noBullHand)
+
+	// 初始 1000 分，底注 100，两名闲家：抢 m 倍需要 100 × m × 5 × 2
+	if got := room.AllowedBidMultiples(banker); !reflect.DeepEqual(got, []int32{1}) {
+		t.Errorf("Expected allowed bids [1], got %v", got)
+	}
+	if err := room.CheckBidMultiple(banker, 0); err != nil {
+		t.Errorf("Expected not bidding to be allowed, got %v", err)
+	}
+	if err := room.CheckBidMultiple(banker, 5); err == nil {
+		t.Error("Expected error for a bid multiple not configured in the room, but got nil")
+	}
+	if err := room.CheckBidMultiple(banker, 2); !errors.Is(err, wallet.ErrInsufficientBalance) {
+		t.Errorf("Expected ErrInsufficientBalance for bid of 2, got %v", err)
+	}
+
+	banker.SetBanker(true)
+	banker.SetBidMultiple(1)
+	if got := room.AllowedBetMultiples(banker); len(got) != 0 {
+		t.Errorf("Expected the banker to have no bet options, got %v", got)
+	}
+	// 每注最多输 100 × 倍数 × 5
+	if got := room.AllowedBetMultiples(p2); !reflect.DeepEqual(got, []int32{1, 2}) {
+		t.Errorf("Expected allowed bets [1 2], got %v", got)
+	}
+	if err := room.CheckBetMultiple(p2, 4); err == nil {
+		t.Error("Expected error for a bet multiple not configured in the room, but got nil")
+	}
+	if err := room.CheckBetMultiple(p2, 2); err != nil {
+		t.Fatalf("Expected bet of 2 to be allowed, got %v", err)
+	}
+	p2.PlaceBet(2)
+	// 庄家的 1000 分已全部用于赔付 p2
+	if got := room.AllowedBetMultiples(p3); len(got) != 0 {
+		t.Errorf("Expected no bet options once the banker is fully exposed, got %v", got)
+	}
+}
+
+func TestFreeBidMultiples(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	settings := DefaultRoomSettings()
+	settings.RuleSet = RULE_FREE_BID
+	room := NewRoomWithSettings(704, settings)
+	room.SetWallet(w)
+	p1 := NewPlayer(1, "p1", nil)
+	p2 := NewPlayer(2, "p2", nil)
+	room.AddPlayer(p1)
+	room.AddPlayer(p2)
+	setHand(p1, noBullHand)
+	setHand(p2, noBullHand)
+
+	// 自由抢庄不加倍，只能以一倍抢庄
+	if got := room.AllowedBidMultiples(p1); !reflect.DeepEqual(got, []int32{1}) {
+		t.Errorf("Expected allowed bids [1], got %v", got)
+	}
+	if err := room.CheckBidMultiple(p1, 2); err == nil {
+		t.Error("Expected error for a bid above 1x in a free bid room, but got nil")
+	}
+	if err := room.CheckBidMultiple(p1, 1); err != nil {
+		t.Errorf("Expected a 1x bid to be allowed, got %v", err)
+	}
+}
+
+func TestRoomSettingsMultiples(t *testing.T) {
+	settings := DefaultRoomSettings()
+	if err := settings.Validate(); err != nil {
+		t.Fatalf("Expected default settings to be valid, got %v", err)
+	}
+
+	invalid := [][]int32{nil, {0, 1}, {1, 3, 2}, {2, 2}}
+	for _, multiples := range invalid {
+		s := DefaultRoomSettings()
+		s.BetMultiples = multiples
+		if err := s.Validate(); err == nil {
+			t.Errorf("Expected bet multiples %v to be invalid", multiples)
+		}
+		s = DefaultRoomSettings()
+		s.BidMultiples = multiples
+		if err := s.Validate(); err == nil {
+			t.Errorf("Expected bid multiples %v to be invalid", multiples)
+		}
+	}
+}
+
+// sumPaid 汇总每个账户在封顶后的支付中的净变化
+func sumPaid(payments []payment) map[int64]int64 {
+	net := make(map[int64]int64)
+	for _, p := range payments {
+		net[p.from] -= p.amount
+		net[p.to] += p.amount
+	}
+	return net
+}
+
+func TestCapPaymentsLoserPaysAtMostBalance(t *testing.T) {
+	payments := []payment{{from: 2, to: 1, amount: 100}}
+	net := sumPaid(capPayments(payments, map[int64]int64{1: 500, 2: 30}, PAYOUT_PROPORTIONAL))
+	if net[2] != -30 || net[1] != 30 {
+		t.Errorf("Expected loser to pay only their balance of 30, got %v", net)
+	}
+}
+
+func TestCapPaymentsBankerBustProportional(t *testing.T) {
+	// 庄家1 余额50，先收闲家5 的 20，再赔闲家2/3/4 各 40
+	payments := []payment{
+		{from: 1, to: 2, amount: 40},
+		{from: 1, to: 3, amount: 40},
+		{from: 5, to: 1, amount: 20},
+		{from: 1, to: 4, amount: 40},
+	}
+	balances := map[int64]int64{1: 50, 2: 100, 3: 100, 4: 100, 5: 100}
+	net := sumPaid(capPayments(payments, balances, PAYOUT_PROPORTIONAL))
+
+	// 可赔 70，应赔 120，每人按比例得 40 × 70 / 120 = 23
+	for _, winner := range []int64{2, 3, 4} {
+		if net[winner] != 23 {
+			t.Errorf("Expected winner %d to receive 23, got %d", winner, net[winner])
+		}
+	}
+	if net[5] != -20 {
+		t.Errorf("Expected loser to pay in full, got %d", net[5])
+	}
+	if balances[1]+net[1] < 0 {
+		t.Errorf("Expected banker balance to stay non-negative, got %d", balances[1]+net[1])
+	}
+}
+
+func TestCapPaymentsBankerBustSeatOrder(t *testing.T) {
+	payments := []payment{
+		{from: 1, to: 2, amount: 40},
+		{from: 1, to: 3, amount: 40},
+		{from: 5, to: 1, amount: 20},
+		{from: 1, to: 4, amount: 40},
+	}
+	balances := map[int64]int64{1: 50, 2: 100, 3: 100, 4: 100, 5: 100}
+	net := sumPaid(capPayments(payments, balances, PAYOUT_SEAT_ORDER))
+
+	// 可赔 70：按顺序赔满 40，再赔 30，最后一位拿不到
+	expected := map[int64]int64{1: -50, 2: 40, 3: 30, 4: 0, 5: -20}
+	for id, amount := range expected {
+		if net[id] != amount {
+			t.Errorf("Player %d: expected %d, got %d", id, amount, net[id])
+		}
+	}
+}
+
+func TestSettlementBankerBust(t *testing.T) {
+	for _, policy := range []PayoutPolicy{PAYOUT_PROPORTIONAL, PAYOUT_SEAT_ORDER} {
+		w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+		settings := DefaultRoomSettings()
+		settings.PayoutPolicy = policy
+		room := NewRoomWithSettings(703, settings)
+		room.SetWallet(w)
+		for i := int64(1); i <= 3; i++ {
+			room.AddPlayer(NewPlayer(i, "p", nil))
+		}
+		// 庄家只剩 6 分，两个闲家都是牛牛，各应得 4
+		w.Transfer(1, wallet.HouseAccountID, InitialScore-6, "", wallet.REASON_ROUND_SETTLEMENT)
+
+		fsm := room.GetFSM()
+		players := room.GetPlayers()
+		for _, p := range players {
+			p.SetStatus(STATUS_READY)
+		}
+		fsm.StartGame()
+		fsm.DealCards()
+		setHand(players[0], noBullHand)
+		setHand(players[1], bullBullHand)
+		setHand(players[2], bullBullHand)
+		room.SetBanker(1)
+		fsm.BidBanker()
+		fsm.PlaceBet()
+		fsm.Showdown()
+		if err := fsm.Settlement(); err != nil {
+			t.Fatalf("Settlement failed: %v", err)
+		}
+
+		if w.Balance(1) != 0 {
+			t.Errorf("Policy %d: expected banker to be paid out to exactly 0, got %d", policy, w.Balance(1))
+		}
+		changes := map[int64]int64{}
+		for _, r := range fsm.GetLastResult().Results {
+			changes[r.PlayerID] = r.ScoreChange
+		}
+		expected := map[PayoutPolicy][2]int64{PAYOUT_PROPORTIONAL: {3, 3}, PAYOUT_SEAT_ORDER: {4, 2}}[policy]
+		if changes[2] != expected[0] || changes[3] != expected[1] || changes[1] != -6 {
+			t.Errorf("Policy %d: unexpected score changes %v", policy, changes)
+		}
+		if err := w.Reconcile(); err != nil {
+			t.Errorf("Reconcile failed: %v", err)
+		}
+	}
+}
+
+func TestRoundResultRecordsHandDetails(t *testing.T) {
+	room := NewRoom(705)
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	defer room.Close()
+
+	playRoundWithBet(t, room, noBullHand, bullBullHand, 3)
+
+	result := room.GetFSM().GetLastResult()
+	if result.ShuffleSeed == 0 || result.ShuffleSeed != room.GetShuffleSeed() || result.SettledAt.IsZero() {
+		t.Errorf("Expected shuffle seed and settlement time to be recorded, got %d and %v", result.ShuffleSeed, result.SettledAt)
+	}
+	if result.StartedAt.IsZero() || result.StartedAt.After(result.SettledAt) {
+		t.Errorf("Expected the round to start before it settled, got %v and %v", result.StartedAt, result.SettledAt)
+	}
+
+	p2 := result.Results[1]
+	if p2.PlayerID != 2 || p2.Seat != 2 || p2.BetMultiple != 3 {
+		t.Errorf("Expected seat and bet of player 2 to be recorded, got %+v", p2)
+	}
+}
@@ -0,0 +1,250 @@
+package logic
+
+import (
+	"errors"
+	"time"
+	"xizexcample/internal/wallet"
+)
+
+// PlayerSnapshot 玩家在快照中的状态
+type PlayerSnapshot struct {
+	ID           int64        `json:"id"`
+	Nickname     string       `json:"nickname"`
+	Seat         int          `json:"seat"`
+	Status       PlayerStatus `json:"status"`
+	Hand         []Card       `json:"hand"`
+	BetAmount    int32        `json:"bet_amount"`
+	IsBanker     bool         `json:"is_banker"`
+	BidMultiple  int32        `json:"bid_multiple"`
+	HasBid       bool         `json:"has_bid"`
+	HasShown     bool         `json:"has_shown"`
+	LastWin      int64        `json:"last_win"`
+	PushCooldown int          `json:"push_cooldown"`
+}
+
+// SessionSnapshot 本场对局记录在快照中的状态
+type SessionSnapshot struct {
+	TotalRounds int            `json:"total_rounds"`
+	Rounds      []RoundResult  `json:"rounds"`
+	Stats       []SessionStats `json:"stats"` // 按首次参与对局的顺序排列
+}
+
+// RoomSnapshot 房间在某一时刻的完整状态，用于服务器重启后恢复房间。
+// 观战者、游客和进行中的解散投票不保存，分数以钱包余额为准也不保存
+type RoomSnapshot struct {
+	ID          int32            `json:"id"`
+	Settings    RoomSettings     `json:"settings"`
+	InviteCode  string           `json:"invite_code"`
+	Password    string           `json:"password"`
+	OwnerID     int64            `json:"owner_id"`
+	LockedSeats []bool           `json:"locked_seats"`
+	State       GameState        `json:"state"`
+	RoundID     string           `json:"round_id"`
+	RoundStart  time.Time        `json:"round_start"`
+	ShuffleSeed int64            `json:"shuffle_seed"`
+	Deck        []Card           `json:"deck"`
+	Players     []PlayerSnapshot `json:"players"`                // 按座位号排列
+	GuestsDealt bool             `json:"guests_dealt,omitempty"` // 本局有游客发到牌，游客不保存，恢复时作废牌局
+	Session     SessionSnapshot  `json:"session"`
+	TakenAt     time.Time        `json:"taken_at"`
+}
+
+// Snapshot 生成房间当前状态的快照
+func (r *Room) Snapshot() *RoomSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state, roundID, roundStart := r.FSM.roundState()
+	snap := &RoomSnapshot{
+		ID:          r.ID,
+		Settings:    r.Settings,
+		InviteCode:  r.InviteCode,
+		Password:    r.password,
+		OwnerID:     r.ownerID,
+		LockedSeats: append([]bool(nil), r.lockedSeats...),
+		State:       state,
+		RoundID:     roundID,
+		RoundStart:  roundStart,
+		ShuffleSeed: r.shuffleSeed,
+		Deck:        r.Deck.Cards(),
+		Session: SessionSnapshot{
+			TotalRounds: r.session.TotalRounds,
+			Rounds:      append([]RoundResult(nil), r.session.Rounds...),
+			Stats:       r.session.Standings(),
+		},
+		TakenAt: time.Now(),
+	}
+	for _, playerID := range r.seats {
+		if playerID == 0 {
+			continue
+		}
+		p := r.Players[playerID]
+		if IsGuest(p.ID) {
+			snap.GuestsDealt = snap.GuestsDealt || len(p.GetHand()) > 0
+			continue
+		}
+		lastWin, cooldown := p.pushState()
+		snap.Players = append(snap.Players, PlayerSnapshot{
+			ID:           p.ID,
+			Nickname:     p.Nickname,
+			Seat:         p.GetSeat(),
+			Status:       p.GetStatus(),
+			Hand:         p.GetHand(),
+			BetAmount:    p.GetBetAmount(),
+			IsBanker:     p.IsBanker(),
+			BidMultiple:  p.GetBidMultiple(),
+			HasBid:       p.HasBid(),
+			HasShown:     p.HasShown(),
+			LastWin:      lastWin,
+			PushCooldown: cooldown,
+		})
+	}
+	return snap
+}
+
+// RestoreRoom 从快照恢复房间，所有玩家都处于离线状态，等待重连；游客不恢复。
+// 抢庄、下注和摊牌阶段的牌局按快照继续；无法恢复的牌局（发牌中、结算中、已经记过账或有游客参与）会被作废，
+// 已记账的分数全部退回，房间回到等待阶段。本场对局已结束的房间不再恢复
+func RestoreRoom(snap *RoomSnapshot, w *wallet.Wallet) (*Room, error) {
+	if snap.State == STATE_CLOSED {
+		return nil, errors.New("room session has already ended")
+	}
+	if err := snap.Settings.Validate(); err != nil {
+		return nil, err
+	}
+	if len(snap.LockedSeats) != snap.Settings.MaxPlayers {
+		return nil, errors.New("snapshot seats do not match room settings")
+	}
+
+	room := NewRoomWithSettings(snap.ID, snap.Settings)
+	room.InviteCode = snap.InviteCode
+	room.password = snap.Password
+	room.ownerID = snap.OwnerID
+	room.wallet = w
+	copy(room.lockedSeats, snap.LockedSeats)
+	room.Deck = NewDeckFromCards(snap.Deck)
+	room.shuffleSeed = snap.ShuffleSeed
+	room.session = restoreSession(snap.Session)
+	room.FSM.restore(snap.State, snap.RoundID, snap.RoundStart)
+
+	now := time.Now().Unix()
+	for _, ps := range snap.Players {
+		if IsGuest(ps.ID) {
+			continue
+		}
+		if ps.Seat < 1 || ps.Seat > len(room.seats) || room.seats[ps.Seat-1] != 0 {
+			room.Close()
+			return nil, errors.New("snapshot has an invalid seat assignment")
+		}
+		p := NewPlayer(ps.ID, ps.Nickname, nil)
+		p.RoomID = room.ID
+		p.Seat = ps.Seat
+		p.Hand = append(make([]Card, 0, len(ps.Hand)), ps.Hand...)
+		p.BetAmount = ps.BetAmount
+		p.isBanker = ps.IsBanker
+		p.bidMultiple = ps.BidMultiple
+		p.hasBid = ps.HasBid
+		p.hasShown = ps.HasShown
+		p.lastWin = ps.LastWin
+		p.pushCooldown = ps.PushCooldown
+		p.Score = w.Balance(ps.ID)
+		p.isOnline = false
+		p.Status = STATUS_OFFLINE
+		p.DisconnectTime = now
+		room.seats[ps.Seat-1] = p.ID
+		room.Players[p.ID] = p
+	}
+	// 房主是没有保存的游客时交给座位顺序中的下一位玩家
+	if _, exists := room.Players[room.ownerID]; !exists {
+		room.passOwnershipLocked()
+	}
+
+	if !snap.roundRecoverable(w) {
+		if err := room.voidRound(); err != nil {
+			room.Close()
+			return nil, err
+		}
+	}
+	return room, nil
+}
+
+// roundRecoverable 检查快照中的牌局能否继续：等待阶段没有牌局需要恢复；
+// 抢庄、下注和摊牌阶段的牌局在还没有记账且手牌完整时可以继续
+func (snap *RoomSnapshot) roundRecoverable(w *wallet.Wallet) bool {
+	switch snap.State {
+	case STATE_WAITING_FOR_PLAYERS:
+		return true
+	case STATE_BIDDING, STATE_BETTING, STATE_SHOWDOWN:
+	default:
+		return false
+	}
+
+	if snap.RoundID == "" || snap.GuestsDealt {
+		return false
+	}
+	if settled, err := w.HasRoundEntries(snap.RoundID); err != nil || settled {
+		return false
+	}
+	dealt := 0
+	for _, p := range snap.Players {
+		if IsGuest(p.ID) && len(p.Hand) > 0 {
+			return false
+		}
+		switch len(p.Hand) {
+		case 0:
+		case 5:
+			dealt++
+		default:
+			return false
+		}
+	}
+	return dealt >= MinSeats
+}
+
+// AbortRound 作废进行中的牌局并退回本局已记账的分数，例如管理员关闭房间时；
+// 没有进行中的牌局时不做任何事。调用方需在房间的 Do 中调用
+func (r *Room) AbortRound() error {
+	switch r.FSM.GetCurrentState() {
+	case STATE_WAITING_FOR_PLAYERS, STATE_CLOSED:
+		return nil
+	}
+	return r.voidRound()
+}
+
+// voidRound 作废当前牌局：退回本局已记账的分数，清除所有玩家的牌局状态和进行中的解散投票，
+// 状态机回到等待阶段并清除牌局ID和暂停状态
+func (r *Room) voidRound() error {
+	roundID := r.FSM.GetRoundID()
+	if roundID != "" {
+		refunded, err := r.GetWallet().RefundRound(roundID)
+		if err != nil {
+			return err
+		}
+		r.Logger().Info("Voided round", "refunded_transfers", refunded)
+	}
+
+	w := r.GetWallet()
+	for _, p := range r.GetPlayers() {
+		p.resetRound()
+		p.SetScore(w.Balance(p.ID))
+	}
+	r.ResetDeck()
+
+	r.mu.Lock()
+	r.dissolveVote = nil
+	r.mu.Unlock()
+	r.FSM.Abort()
+	return nil
+}
+
+// restoreSession 从快照恢复本场对局记录
+func restoreSession(snap SessionSnapshot) *Session {
+	s := newSession(snap.TotalRounds)
+	s.Rounds = append([]RoundResult(nil), snap.Rounds...)
+	for _, stats := range snap.Stats {
+		copied := stats
+		s.stats[stats.PlayerID] = &copied
+		s.order = append(s.order, stats.PlayerID)
+	}
+	return s
+}
@@ -0,0 +1,244 @@
+package logic
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+	"xizexcample/internal/wallet"
+)
+
+// roundTrip 将快照序列化后再读回，模拟写入磁盘后重启
+func roundTrip(t *testing.T, snap *RoomSnapshot) *RoomSnapshot {
+	t.Helper()
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var restored RoomSnapshot
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	return &restored
+}
+
+// startBetting 开始一局并进入下注阶段，p1 以2倍坐庄，p2 下注3倍
+func startBetting(t *testing.T, room *Room) {
+	t.Helper()
+	fsm := room.GetFSM()
+	p1, _ := room.GetPlayer(1)
+	p2, _ := room.GetPlayer(2)
+	p1.SetStatus(STATUS_READY)
+	p2.SetStatus(STATUS_READY)
+	if err := fsm.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	fsm.DealCards()
+	p1.SetBidMultiple(2)
+	room.SetBanker(1)
+	fsm.BidBanker()
+	p2.PlaceBet(3)
+}
+
+func TestRestoreRoundInProgress(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	settings := DefaultRoomSettings()
+	settings.Rounds = 3
+	room := NewRoomWithSettings(731, settings)
+	room.SetWallet(w)
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	room.SetOwner(1)
+	room.LockSeat(1, 5, true)
+	defer room.Close()
+
+	playRound(t, room, noBullHand, bullBullHand)
+	startBetting(t, room)
+	snap := roundTrip(t, room.Snapshot())
+
+	restored, err := RestoreRoom(snap, w)
+	if err != nil {
+		t.Fatalf("RestoreRoom failed: %v", err)
+	}
+	defer restored.Close()
+
+	if restored.GetFSM().GetCurrentState() != STATE_BETTING {
+		t.Errorf("Expected restored room in betting state, got %d", restored.GetFSM().GetCurrentState())
+	}
+	if restored.GetFSM().GetRoundID() != room.GetFSM().GetRoundID() {
+		t.Errorf("Expected round ID %s, got %s", room.GetFSM().GetRoundID(), restored.GetFSM().GetRoundID())
+	}
+	if !reflect.DeepEqual(restored.Deck.Cards(), room.Deck.Cards()) {
+		t.Error("Expected the deck order to be restored")
+	}
+	if restored.GetOwnerID() != 1 || !reflect.DeepEqual(restored.GetLockedSeats(), []int{5}) {
+		t.Error("Expected owner and locked seats to be restored")
+	}
+	if restored.GetSession().RoundsPlayed() != 1 || len(restored.GetSession().Standings()) != 2 {
+		t.Error("Expected the session scoreboard to be restored")
+	}
+
+	for _, original := range room.GetPlayers() {
+		p, err := restored.GetPlayer(original.ID)
+		if err != nil {
+			t.Fatalf("Expected player %d to be restored", original.ID)
+		}
+		if !reflect.DeepEqual(p.GetHand(), original.GetHand()) || p.GetBetAmount() != original.GetBetAmount() ||
+			p.IsBanker() != original.IsBanker() || p.GetBidMultiple() != original.GetBidMultiple() || p.GetSeat() != original.GetSeat() {
+			t.Errorf("Expected player %d round state to be restored", p.ID)
+		}
+		if p.IsOnline() || p.GetStatus() != STATUS_OFFLINE {
+			t.Errorf("Expected player %d to wait offline for reconnection", p.ID)
+		}
+		if p.GetScore() != w.Balance(p.ID) {
+			t.Errorf("Expected player %d score to follow the wallet, got %d", p.ID, p.GetScore())
+		}
+	}
+
+	// 玩家重连后牌局可以继续结算
+	for _, p := range restored.GetPlayers() {
+		p.SetOnline(true)
+		p.SetStatus(STATUS_PLAYING)
+	}
+	fsm := restored.GetFSM()
+	fsm.PlaceBet()
+	fsm.Showdown()
+	if err := fsm.Settlement(); err != nil {
+		t.Fatalf("Settlement after restore failed: %v", err)
+	}
+	if restored.GetSession().RoundsPlayed() != 2 {
+		t.Errorf("Expected 2 rounds played after restore, got %d", restored.GetSession().RoundsPlayed())
+	}
+	if err := w.Reconcile(); err != nil {
+		t.Errorf("Reconcile failed: %v", err)
+	}
+}
+
+func TestRestoreVoidsUnrecoverableRound(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	room := NewRoom(732)
+	room.SetWallet(w)
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	defer room.Close()
+
+	startBetting(t, room)
+	fsm := room.GetFSM()
+	fsm.PlaceBet()
+	fsm.Showdown()
+	snap := roundTrip(t, room.Snapshot())
+
+	// 结算记了一半账时崩溃
+	w.Transfer(1, 2, 50, snap.RoundID, wallet.REASON_ROUND_SETTLEMENT)
+
+	restored, err := RestoreRoom(snap, w)
+	if err != nil {
+		t.Fatalf("RestoreRoom failed: %v", err)
+	}
+	defer restored.Close()
+
+	if restored.GetFSM().GetCurrentState() != STATE_WAITING_FOR_PLAYERS {
+		t.Errorf("Expected voided round to return to waiting, got %d", restored.GetFSM().GetCurrentState())
+	}
+	for _, p := range restored.GetPlayers() {
+		if w.Balance(p.ID) != InitialScore || p.GetScore() != InitialScore {
+			t.Errorf("Expected player %d to be refunded to %d, got balance %d score %d", p.ID, InitialScore, w.Balance(p.ID), p.GetScore())
+		}
+		if len(p.GetHand()) != 0 || p.IsBanker() || p.GetBetAmount() != 0 {
+			t.Errorf("Expected player %d round state to be cleared", p.ID)
+		}
+	}
+	if err := w.Reconcile(); err != nil {
+		t.Errorf("Reconcile failed: %v", err)
+	}
+}
+
+func TestRestoreDropsGuests(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	room := NewRoom(734)
+	room.SetWallet(w)
+	guest := NewPlayer(-7, "guest", nil)
+	room.AddPlayer(guest)
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	room.SetOwner(guest.ID)
+	defer room.Close()
+
+	// 游客参与了进行中的一局
+	for _, p := range room.GetPlayers() {
+		p.SetStatus(STATUS_READY)
+	}
+	fsm := room.GetFSM()
+	fsm.StartGame()
+	fsm.DealCards()
+	snap := roundTrip(t, room.Snapshot())
+	if len(snap.Players) != 2 || !snap.GuestsDealt {
+		t.Fatalf("Expected the snapshot to leave out the dealt-in guest, got %d players, guests dealt %v", len(snap.Players), snap.GuestsDealt)
+	}
+
+	restored, err := RestoreRoom(snap, w)
+	if err != nil {
+		t.Fatalf("RestoreRoom failed: %v", err)
+	}
+	defer restored.Close()
+
+	if _, err := restored.GetPlayer(guest.ID); err == nil {
+		t.Error("Expected the guest not to be restored")
+	}
+	if restored.GetOwnerID() != 1 {
+		t.Errorf("Expected ownership to pass to player 1, got %d", restored.GetOwnerID())
+	}
+	if restored.GetFSM().GetCurrentState() != STATE_WAITING_FOR_PLAYERS {
+		t.Errorf("Expected the round with a guest to be voided, got %s", restored.GetFSM().GetCurrentState())
+	}
+	for _, p := range restored.GetPlayers() {
+		if len(p.GetHand()) != 0 {
+			t.Errorf("Expected player %d round state to be cleared", p.ID)
+		}
+	}
+}
+
+func TestRestoreRejectsClosedRoom(t *testing.T) {
+	snap := NewRoom(733).Snapshot()
+	snap.State = STATE_CLOSED
+	if _, err := RestoreRoom(snap, wallet.GetWallet()); err == nil {
+		t.Error("Expected error when restoring a room whose session has ended, but got nil")
+	}
+}
+
+func TestAbortRound(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	settings := DefaultRoomSettings()
+	settings.Rounds = 10
+	room := NewRoomWithSettings(733, settings)
+	room.SetWallet(w)
+	room.AddPlayer(NewPlayer(1, "p1", nil))
+	room.AddPlayer(NewPlayer(2, "p2", nil))
+	defer room.Close()
+
+	transitions := 0
+	room.GetFSM().SetTransitionHook(func(GameState) { transitions++ })
+	startBetting(t, room)
+	roundID := room.GetFSM().GetRoundID()
+	w.Transfer(1, 2, 50, roundID, wallet.REASON_ROUND_SETTLEMENT)
+	room.ProposeDissolve(1, time.Minute)
+	transitions = 0
+
+	// 作废牌局时状态、牌局ID、暂停状态和解散投票一起清除，并保存快照
+	if err := room.AbortRound(); err != nil {
+		t.Fatalf("AbortRound failed: %v", err)
+	}
+	fsm := room.GetFSM()
+	if fsm.GetCurrentState() != STATE_WAITING_FOR_PLAYERS || fsm.GetRoundID() != "" || fsm.IsPaused() {
+		t.Errorf("Expected a clean waiting state, got %s round %q paused %v", fsm.GetCurrentState(), fsm.GetRoundID(), fsm.IsPaused())
+	}
+	if room.GetDissolveVote() != nil {
+		t.Error("Expected the dissolve vote to be cleared")
+	}
+	if transitions != 1 {
+		t.Errorf("Expected the transition hook to run once, got %d", transitions)
+	}
+	if w.Balance(1) != InitialScore || w.Balance(2) != InitialScore {
+		t.Errorf("Expected transfers of round %s to be refunded", roundID)
+	}
+}
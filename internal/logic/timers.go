@@ -0,0 +1,58 @@
+package logic
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// DefaultBidTimeout 抢庄的默认时限，超时未抢庄的玩家视为不抢
+const DefaultBidTimeout = 10 * time.Second
+
+// DefaultReconnectTimeout 断线玩家的默认重连时限，超时后被移出房间
+const DefaultReconnectTimeout = 5 * time.Minute
+
+// 运行中可修改的时限，0 表示使用默认值
+var (
+	bidTimeout          atomic.Int64
+	dissolveVoteTimeout atomic.Int64
+	reconnectTimeout    atomic.Int64
+)
+
+// SetBidTimeout 设置之后开始的抢庄阶段的时限，不大于 0 时恢复默认值
+func SetBidTimeout(d time.Duration) {
+	bidTimeout.Store(int64(max(d, 0)))
+}
+
+// BidTimeout 返回抢庄阶段的时限
+func BidTimeout() time.Duration {
+	if d := bidTimeout.Load(); d > 0 {
+		return time.Duration(d)
+	}
+	return DefaultBidTimeout
+}
+
+// SetDissolveVoteTimeout 设置之后发起的解散投票的时限，不大于 0 时恢复默认值
+func SetDissolveVoteTimeout(d time.Duration) {
+	dissolveVoteTimeout.Store(int64(max(d, 0)))
+}
+
+// DissolveVoteTimeout 返回解散投票的时限
+func DissolveVoteTimeout() time.Duration {
+	if d := dissolveVoteTimeout.Load(); d > 0 {
+		return time.Duration(d)
+	}
+	return DefaultDissolveVoteTimeout
+}
+
+// SetReconnectTimeout 设置断线玩家的重连时限，不大于 0 时恢复默认值
+func SetReconnectTimeout(d time.Duration) {
+	reconnectTimeout.Store(int64(max(d, 0)))
+}
+
+// ReconnectTimeout 返回断线玩家的重连时限
+func ReconnectTimeout() time.Duration {
+	if d := reconnectTimeout.Load(); d > 0 {
+		return time.Duration(d)
+	}
+	return DefaultReconnectTimeout
+}
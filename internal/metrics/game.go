@@ -0,0 +1,31 @@
+package metrics
+
+// Default 游戏服务的指标注册表，由管理后台的 /metrics 接口输出
+var Default = NewRegistry()
+
+// LatencyBuckets 消息处理耗时的桶上界（秒），包含 0.2 秒以便按 SC-002 检查 p95
+var LatencyBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.2, 0.5, 1, 2.5}
+
+// RoundBuckets 一局耗时的桶上界（秒）
+var RoundBuckets = []float64{5, 10, 20, 30, 45, 60, 90, 120, 180, 300}
+
+var (
+	// RequestsTotal 按消息ID统计处理的客户端请求数
+	RequestsTotal = Default.NewCounterVec("game_requests_total",
+		"Client requests handled, by message ID.", "msg_id")
+	// RequestDuration 按消息ID统计请求的处理耗时
+	RequestDuration = Default.NewHistogramVec("game_request_duration_seconds",
+		"Time spent handling client requests, by message ID.", LatencyBuckets, "msg_id")
+	// ErrorsTotal 按响应消息ID和返回码统计发给客户端的错误响应数
+	ErrorsTotal = Default.NewCounterVec("game_errors_total",
+		"Error responses sent to clients, by response message ID and return code.", "msg_id", "code")
+	// RoundsCompleted 结算完成的牌局数
+	RoundsCompleted = Default.NewCounterVec("game_rounds_completed_total",
+		"Rounds settled.")
+	// RoundDuration 从开局到结算的耗时，平均值为 _sum / _count
+	RoundDuration = Default.NewHistogramVec("game_round_duration_seconds",
+		"Time from the start of a round to its settlement.", RoundBuckets)
+	// ReconnectsTotal 断线玩家重连回房间的次数
+	ReconnectsTotal = Default.NewCounterVec("game_reconnects_total",
+		"Players who reconnected to a room after disconnecting.")
+)
@@ -0,0 +1,280 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// collector 能以 Prometheus 文本格式输出自身的指标
+type collector interface {
+	write(w io.Writer)
+}
+
+// Registry 指标注册表，按注册顺序输出所有指标
+type Registry struct {
+	collectors []collector
+	mu         sync.Mutex
+}
+
+// NewRegistry 创建一个空的指标注册表
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// register 注册一个指标
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, c)
+}
+
+// Write 以 Prometheus 文本格式输出所有指标
+func (r *Registry) Write(w io.Writer) {
+	r.mu.Lock()
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	for _, c := range collectors {
+		c.write(w)
+	}
+}
+
+// series 一组标签值对应的一条时间序列
+type series struct {
+	labels []string
+	value  float64
+}
+
+// vec 按标签值分组的时间序列，是 CounterVec 和 HistogramVec 的公共部分
+type vec struct {
+	name       string
+	help       string
+	labelNames []string
+	mu         sync.Mutex
+}
+
+// key 将标签值拼接为分组的键
+func (v *vec) key(labelValues []string) string {
+	if len(labelValues) != len(v.labelNames) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", v.name, len(v.labelNames), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+// CounterVec 只增不减的计数器，按标签分组
+type CounterVec struct {
+	vec
+	series map[string]*series
+}
+
+// NewCounterVec 创建一个计数器并注册到注册表
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{
+		vec:    vec{name: name, help: help, labelNames: labelNames},
+		series: make(map[string]*series),
+	}
+	r.register(c)
+	return c
+}
+
+// Inc 计数加一
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add 计数增加 delta，delta 不能为负
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("counter %s cannot decrease", c.name))
+	}
+	key := c.key(labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, exists := c.series[key]
+	if !exists {
+		s = &series{labels: append([]string(nil), labelValues...)}
+		c.series[key] = s
+	}
+	s.value += delta
+}
+
+// Value 返回一组标签值的当前计数
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	key := c.key(labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, exists := c.series[key]; exists {
+		return s.value
+	}
+	return 0
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.mu.Lock()
+	samples := make([]series, 0, len(c.series))
+	for _, s := range c.series {
+		samples = append(samples, *s)
+	}
+	c.mu.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+	sortSeries(samples)
+	for _, s := range samples {
+		writeSample(w, c.name, c.labelNames, s.labels, s.value)
+	}
+}
+
+// histogramSeries 一组标签值对应的直方图
+type histogramSeries struct {
+	labels []string
+	counts []uint64 // 每个桶的计数，不累加
+	sum    float64
+	count  uint64
+}
+
+// HistogramVec 直方图，按标签分组
+type HistogramVec struct {
+	vec
+	buckets []float64 // 桶的上界，升序，不包括 +Inf
+	series  map[string]*histogramSeries
+}
+
+// NewHistogramVec 创建一个直方图并注册到注册表，buckets 为升序的桶上界
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	if !sort.Float64sAreSorted(buckets) {
+		panic(fmt.Sprintf("histogram %s buckets must be sorted", name))
+	}
+	h := &HistogramVec{
+		vec:     vec{name: name, help: help, labelNames: labelNames},
+		buckets: buckets,
+		series:  make(map[string]*histogramSeries),
+	}
+	r.register(h)
+	return h
+}
+
+// Observe 记录一次观测值
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := h.key(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, exists := h.series[key]
+	if !exists {
+		s = &histogramSeries{
+			labels: append([]string(nil), labelValues...),
+			counts: make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.sum += value
+	s.count++
+}
+
+// Count 返回一组标签值的观测次数
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	key := h.key(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s, exists := h.series[key]; exists {
+		return s.count
+	}
+	return 0
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	samples := make([]histogramSeries, 0, len(h.series))
+	for _, s := range h.series {
+		copied := *s
+		copied.counts = append([]uint64(nil), s.counts...)
+		samples = append(samples, copied)
+	}
+	h.mu.Unlock()
+
+	writeHeader(w, h.name, h.help, "histogram")
+	sort.Slice(samples, func(i, j int) bool {
+		return lessLabels(samples[i].labels, samples[j].labels)
+	})
+	bucketLabels := append(append([]string(nil), h.labelNames...), "le")
+	for _, s := range samples {
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += s.counts[i]
+			writeSample(w, h.name+"_bucket", bucketLabels, append(append([]string(nil), s.labels...), formatFloat(bound)), float64(cumulative))
+		}
+		writeSample(w, h.name+"_bucket", bucketLabels, append(append([]string(nil), s.labels...), "+Inf"), float64(s.count))
+		writeSample(w, h.name+"_sum", h.labelNames, s.labels, s.sum)
+		writeSample(w, h.name+"_count", h.labelNames, s.labels, float64(s.count))
+	}
+}
+
+// GaugeSample 采集时计算的一个仪表盘样本
+type GaugeSample struct {
+	Labels []string
+	Value  float64
+}
+
+// WriteGauge 以 Prometheus 文本格式输出采集时计算的仪表盘，例如当前连接数
+func WriteGauge(w io.Writer, name, help string, labelNames []string, samples []GaugeSample) {
+	writeHeader(w, name, help, "gauge")
+	for _, s := range samples {
+		writeSample(w, name, labelNames, s.Labels, s.Value)
+	}
+}
+
+// writeHeader 输出指标的 HELP 和 TYPE 行
+func writeHeader(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+}
+
+// writeSample 输出一条样本
+func writeSample(w io.Writer, name string, labelNames, labelValues []string, value float64) {
+	if len(labelNames) == 0 {
+		fmt.Fprintf(w, "%s %s\n", name, formatFloat(value))
+		return
+	}
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, len(labelNames))
+	for i, labelName := range labelNames {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, labelName, escape.Replace(labelValues[i]))
+	}
+	fmt.Fprintf(w, "%s{%s} %s\n", name, strings.Join(pairs, ","), formatFloat(value))
+}
+
+// formatFloat 按 Prometheus 文本格式输出数值
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+// sortSeries 按标签值排序，使输出稳定
+func sortSeries(samples []series) {
+	sort.Slice(samples, func(i, j int) bool {
+		return lessLabels(samples[i].labels, samples[j].labels)
+	})
+}
+
+// lessLabels 按字典序比较两组标签值
+func lessLabels(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
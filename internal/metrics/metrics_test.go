@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCounterVec(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("test_requests_total", "Requests.", "msg_id")
+	c.Inc("B")
+	c.Add(2, "A")
+	c.Inc("A")
+
+	if c.Value("A") != 3 || c.Value("B") != 1 || c.Value("C") != 0 {
+		t.Errorf("Unexpected counter values: A=%v B=%v C=%v", c.Value("A"), c.Value("B"), c.Value("C"))
+	}
+
+	var out strings.Builder
+	r.Write(&out)
+	expected := "# HELP test_requests_total Requests.\n" +
+		"# TYPE test_requests_total counter\n" +
+		"test_requests_total{msg_id=\"A\"} 3\n" +
+		"test_requests_total{msg_id=\"B\"} 1\n"
+	if out.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestHistogramVec(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogramVec("test_duration_seconds", "Durations.", []float64{0.1, 0.2}, "msg_id")
+	h.Observe(0.05, "A")
+	h.Observe(0.2, "A") // 等于上界的观测值落入该桶
+	h.Observe(3, "A")
+
+	if h.Count("A") != 3 {
+		t.Errorf("Expected 3 observations, got %d", h.Count("A"))
+	}
+
+	var out strings.Builder
+	r.Write(&out)
+	for _, line := range []string{
+		"# TYPE test_duration_seconds histogram",
+		`test_duration_seconds_bucket{msg_id="A",le="0.1"} 1`,
+		`test_duration_seconds_bucket{msg_id="A",le="0.2"} 2`,
+		`test_duration_seconds_bucket{msg_id="A",le="+Inf"} 3`,
+		`test_duration_seconds_sum{msg_id="A"} 3.25`,
+		`test_duration_seconds_count{msg_id="A"} 3`,
+	} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestWriteGaugeEscapesLabels(t *testing.T) {
+	var out strings.Builder
+	WriteGauge(&out, "test_gauge", "Gauge.", []string{"name"}, []GaugeSample{{Labels: []string{"a\"b\\c"}, Value: 2}})
+	if !strings.Contains(out.String(), `test_gauge{name="a\"b\\c"} 2`) {
+		t.Errorf("Expected escaped label value, got:\n%s", out.String())
+	}
+}
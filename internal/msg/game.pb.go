@@ -27,25 +27,58 @@ type MsgID int32
 const (
 	MsgID_UNKNOWN MsgID = 0
 	// Client to Server
-	MsgID_C2S_JOIN_ROOM_REQ    MsgID = 101
-	MsgID_C2S_PLAYER_READY_REQ MsgID = 102
-	MsgID_C2S_BID_BANKER_REQ   MsgID = 103
-	MsgID_C2S_PLACE_BET_REQ    MsgID = 104
-	MsgID_C2S_SHOWDOWN_REQ     MsgID = 105
-	MsgID_C2S_LEAVE_ROOM_REQ   MsgID = 106
+	MsgID_C2S_JOIN_ROOM_REQ        MsgID = 101
+	MsgID_C2S_PLAYER_READY_REQ     MsgID = 102
+	MsgID_C2S_BID_BANKER_REQ       MsgID = 103
+	MsgID_C2S_PLACE_BET_REQ        MsgID = 104
+	MsgID_C2S_SHOWDOWN_REQ         MsgID = 105
+	MsgID_C2S_LEAVE_ROOM_REQ       MsgID = 106
+	MsgID_C2S_ROOM_LIST_REQ        MsgID = 107
+	MsgID_C2S_CREATE_ROOM_REQ      MsgID = 108
+	MsgID_C2S_QUICK_MATCH_REQ      MsgID = 109
+	MsgID_C2S_CANCEL_MATCH_REQ     MsgID = 110
+	MsgID_C2S_TAKE_SEAT_REQ        MsgID = 111
+	MsgID_C2S_KICK_PLAYER_REQ      MsgID = 112
+	MsgID_C2S_LOCK_SEAT_REQ        MsgID = 113
+	MsgID_C2S_FORCE_START_REQ      MsgID = 114
+	MsgID_C2S_TRANSFER_OWNER_REQ   MsgID = 115
+	MsgID_C2S_DISSOLVE_PROPOSE_REQ MsgID = 116
+	MsgID_C2S_DISSOLVE_VOTE_REQ    MsgID = 117
+	MsgID_C2S_LOGIN_REQ            MsgID = 118
 	// Server to Client
-	MsgID_S2C_JOIN_ROOM_ACK       MsgID = 201
-	MsgID_S2C_BID_BANKER_ACK      MsgID = 210 // 新增
-	MsgID_S2C_PLACE_BET_ACK       MsgID = 211 // 新增
-	MsgID_S2C_SHOWDOWN_ACK        MsgID = 212 // 新增
-	MsgID_S2C_SYNC_ROOM_STATE_NTF MsgID = 202
-	MsgID_S2C_GAME_START_NTF      MsgID = 203
-	MsgID_S2C_DEAL_CARDS_NTF      MsgID = 204
-	MsgID_S2C_BID_BANKER_NTF      MsgID = 205
-	MsgID_S2C_BET_NTF             MsgID = 206
-	MsgID_S2C_SHOWDOWN_NTF        MsgID = 207
-	MsgID_S2C_GAME_RESULT_NTF     MsgID = 208
-	MsgID_S2C_PLAYER_LEAVE_NTF    MsgID = 209
+	MsgID_S2C_JOIN_ROOM_ACK        MsgID = 201
+	MsgID_S2C_BID_BANKER_ACK       MsgID = 210 // 新增
+	MsgID_S2C_PLACE_BET_ACK        MsgID = 211 // 新增
+	MsgID_S2C_SHOWDOWN_ACK         MsgID = 212 // 新增
+	MsgID_S2C_ROOM_LIST_ACK        MsgID = 213
+	MsgID_S2C_CREATE_ROOM_ACK      MsgID = 214
+	MsgID_S2C_QUICK_MATCH_ACK      MsgID = 215
+	MsgID_S2C_CANCEL_MATCH_ACK     MsgID = 216
+	MsgID_S2C_MATCH_QUEUE_NTF      MsgID = 217
+	MsgID_S2C_MATCH_FOUND_NTF      MsgID = 218
+	MsgID_S2C_TAKE_SEAT_ACK        MsgID = 219
+	MsgID_S2C_LEAVE_ROOM_ACK       MsgID = 220
+	MsgID_S2C_KICK_PLAYER_ACK      MsgID = 221
+	MsgID_S2C_LOCK_SEAT_ACK        MsgID = 222
+	MsgID_S2C_FORCE_START_ACK      MsgID = 223
+	MsgID_S2C_TRANSFER_OWNER_ACK   MsgID = 224
+	MsgID_S2C_KICKED_NTF           MsgID = 225
+	MsgID_S2C_DISSOLVE_PROPOSE_ACK MsgID = 226
+	MsgID_S2C_DISSOLVE_VOTE_ACK    MsgID = 227
+	MsgID_S2C_DISSOLVE_VOTE_NTF    MsgID = 228
+	MsgID_S2C_DISSOLVE_RESULT_NTF  MsgID = 229
+	MsgID_S2C_SESSION_SUMMARY_NTF  MsgID = 230
+	MsgID_S2C_LOGIN_ACK            MsgID = 231
+	MsgID_S2C_MAINTENANCE_NTF      MsgID = 232
+	MsgID_S2C_ANNOUNCEMENT_NTF     MsgID = 233
+	MsgID_S2C_SYNC_ROOM_STATE_NTF  MsgID = 202
+	MsgID_S2C_GAME_START_NTF       MsgID = 203
+	MsgID_S2C_DEAL_CARDS_NTF       MsgID = 204
+	MsgID_S2C_BID_BANKER_NTF       MsgID = 205
+	MsgID_S2C_BET_NTF              MsgID = 206
+	MsgID_S2C_SHOWDOWN_NTF         MsgID = 207
+	MsgID_S2C_GAME_RESULT_NTF      MsgID = 208
+	MsgID_S2C_PLAYER_LEAVE_NTF     MsgID = 209
 )
 
 // Enum value maps for MsgID.
@@ -58,10 +91,43 @@ var (
 		104: "C2S_PLACE_BET_REQ",
 		105: "C2S_SHOWDOWN_REQ",
 		106: "C2S_LEAVE_ROOM_REQ",
+		107: "C2S_ROOM_LIST_REQ",
+		108: "C2S_CREATE_ROOM_REQ",
+		109: "C2S_QUICK_MATCH_REQ",
+		110: "C2S_CANCEL_MATCH_REQ",
+		111: "C2S_TAKE_SEAT_REQ",
+		112: "C2S_KICK_PLAYER_REQ",
+		113: "C2S_LOCK_SEAT_REQ",
+		114: "C2S_FORCE_START_REQ",
+		115: "C2S_TRANSFER_OWNER_REQ",
+		116: "C2S_DISSOLVE_PROPOSE_REQ",
+		117: "C2S_DISSOLVE_VOTE_REQ",
+		118: "C2S_LOGIN_REQ",
 		201: "S2C_JOIN_ROOM_ACK",
 		210: "S2C_BID_BANKER_ACK",
 		211: "S2C_PLACE_BET_ACK",
 		212: "S2C_SHOWDOWN_ACK",
+		213: "S2C_ROOM_LIST_ACK",
+		214: "S2C_CREATE_ROOM_ACK",
+		215: "S2C_QUICK_MATCH_ACK",
+		216: "S2C_CANCEL_MATCH_ACK",
+		217: "S2C_MATCH_QUEUE_NTF",
+		218: "S2C_MATCH_FOUND_NTF",
+		219: "S2C_TAKE_SEAT_ACK",
+		220: "S2C_LEAVE_ROOM_ACK",
+		221: "S2C_KICK_PLAYER_ACK",
+		222: "S2C_LOCK_SEAT_ACK",
+		223: "S2C_FORCE_START_ACK",
+		224: "S2C_TRANSFER_OWNER_ACK",
+		225: "S2C_KICKED_NTF",
+		226: "S2C_DISSOLVE_PROPOSE_ACK",
+		227: "S2C_DISSOLVE_VOTE_ACK",
+		228: "S2C_DISSOLVE_VOTE_NTF",
+		229: "S2C_DISSOLVE_RESULT_NTF",
+		230: "S2C_SESSION_SUMMARY_NTF",
+		231: "S2C_LOGIN_ACK",
+		232: "S2C_MAINTENANCE_NTF",
+		233: "S2C_ANNOUNCEMENT_NTF",
 		202: "S2C_SYNC_ROOM_STATE_NTF",
 		203: "S2C_GAME_START_NTF",
 		204: "S2C_DEAL_CARDS_NTF",
@@ -72,25 +138,58 @@ var (
 		209: "S2C_PLAYER_LEAVE_NTF",
 	}
 	MsgID_value = map[string]int32{
-		"UNKNOWN":                 0,
-		"C2S_JOIN_ROOM_REQ":       101,
-		"C2S_PLAYER_READY_REQ":    102,
-		"C2S_BID_BANKER_REQ":      103,
-		"C2S_PLACE_BET_REQ":       104,
-		"C2S_SHOWDOWN_REQ":        105,
-		"C2S_LEAVE_ROOM_REQ":      106,
-		"S2C_JOIN_ROOM_ACK":       201,
-		"S2C_BID_BANKER_ACK":      210,
-		"S2C_PLACE_BET_ACK":       211,
-		"S2C_SHOWDOWN_ACK":        212,
-		"S2C_SYNC_ROOM_STATE_NTF": 202,
-		"S2C_GAME_START_NTF":      203,
-		"S2C_DEAL_CARDS_NTF":      204,
-		"S2C_BID_BANKER_NTF":      205,
-		"S2C_BET_NTF":             206,
-		"S2C_SHOWDOWN_NTF":        207,
-		"S2C_GAME_RESULT_NTF":     208,
-		"S2C_PLAYER_LEAVE_NTF":    209,
+		"UNKNOWN":                  0,
+		"C2S_JOIN_ROOM_REQ":        101,
+		"C2S_PLAYER_READY_REQ":     102,
+		"C2S_BID_BANKER_REQ":       103,
+		"C2S_PLACE_BET_REQ":        104,
+		"C2S_SHOWDOWN_REQ":         105,
+		"C2S_LEAVE_ROOM_REQ":       106,
+		"C2S_ROOM_LIST_REQ":        107,
+		"C2S_CREATE_ROOM_REQ":      108,
+		"C2S_QUICK_MATCH_REQ":      109,
+		"C2S_CANCEL_MATCH_REQ":     110,
+		"C2S_TAKE_SEAT_REQ":        111,
+		"C2S_KICK_PLAYER_REQ":      112,
+		"C2S_LOCK_SEAT_REQ":        113,
+		"C2S_FORCE_START_REQ":      114,
+		"C2S_TRANSFER_OWNER_REQ":   115,
+		"C2S_DISSOLVE_PROPOSE_REQ": 116,
+		"C2S_DISSOLVE_VOTE_REQ":    117,
+		"C2S_LOGIN_REQ":            118,
+		"S2C_JOIN_ROOM_ACK":        201,
+		"S2C_BID_BANKER_ACK":       210,
+		"S2C_PLACE_BET_ACK":        211,
+		"S2C_SHOWDOWN_ACK":         212,
+		"S2C_ROOM_LIST_ACK":        213,
+		"S2C_CREATE_ROOM_ACK":      214,
+		"S2C_QUICK_MATCH_ACK":      215,
+		"S2C_CANCEL_MATCH_ACK":     216,
+		"S2C_MATCH_QUEUE_NTF":      217,
+		"S2C_MATCH_FOUND_NTF":      218,
+		"S2C_TAKE_SEAT_ACK":        219,
+		"S2C_LEAVE_ROOM_ACK":       220,
+		"S2C_KICK_PLAYER_ACK":      221,
+		"S2C_LOCK_SEAT_ACK":        222,
+		"S2C_FORCE_START_ACK":      223,
+		"S2C_TRANSFER_OWNER_ACK":   224,
+		"S2C_KICKED_NTF":           225,
+		"S2C_DISSOLVE_PROPOSE_ACK": 226,
+		"S2C_DISSOLVE_VOTE_ACK":    227,
+		"S2C_DISSOLVE_VOTE_NTF":    228,
+		"S2C_DISSOLVE_RESULT_NTF":  229,
+		"S2C_SESSION_SUMMARY_NTF":  230,
+		"S2C_LOGIN_ACK":            231,
+		"S2C_MAINTENANCE_NTF":      232,
+		"S2C_ANNOUNCEMENT_NTF":     233,
+		"S2C_SYNC_ROOM_STATE_NTF":  202,
+		"S2C_GAME_START_NTF":       203,
+		"S2C_DEAL_CARDS_NTF":       204,
+		"S2C_BID_BANKER_NTF":       205,
+		"S2C_BET_NTF":              206,
+		"S2C_SHOWDOWN_NTF":         207,
+		"S2C_GAME_RESULT_NTF":      208,
+		"S2C_PLAYER_LEAVE_NTF":     209,
 	}
 )
 
@@ -264,21 +363,22 @@ func (Rank) EnumDescriptor() ([]byte, []int) {
 type CardPattern int32
 
 const (
-	CardPattern_PATTERN_UNKNOWN CardPattern = 0
-	CardPattern_NO_NIU          CardPattern = 1
-	CardPattern_NIU_1           CardPattern = 2
-	CardPattern_NIU_2           CardPattern = 3
-	CardPattern_NIU_3           CardPattern = 4
-	CardPattern_NIU_4           CardPattern = 5
-	CardPattern_NIU_5           CardPattern = 6
-	CardPattern_NIU_6           CardPattern = 7
-	CardPattern_NIU_7           CardPattern = 8
-	CardPattern_NIU_8           CardPattern = 9
-	CardPattern_NIU_9           CardPattern = 10
-	CardPattern_NIU_NIU         CardPattern = 11
-	CardPattern_FIVE_FLOWER_NIU CardPattern = 12 // 五花牛
-	CardPattern_BOMB_NIU        CardPattern = 13 // 炸弹牛
-	CardPattern_FIVE_SMALL_NIU  CardPattern = 14 // 五小牛
+	CardPattern_PATTERN_UNKNOWN    CardPattern = 0
+	CardPattern_NO_NIU             CardPattern = 1
+	CardPattern_NIU_1              CardPattern = 2
+	CardPattern_NIU_2              CardPattern = 3
+	CardPattern_NIU_3              CardPattern = 4
+	CardPattern_NIU_4              CardPattern = 5
+	CardPattern_NIU_5              CardPattern = 6
+	CardPattern_NIU_6              CardPattern = 7
+	CardPattern_NIU_7              CardPattern = 8
+	CardPattern_NIU_8              CardPattern = 9
+	CardPattern_NIU_9              CardPattern = 10
+	CardPattern_NIU_NIU            CardPattern = 11
+	CardPattern_FIVE_FLOWER_NIU    CardPattern = 12 // 五花牛
+	CardPattern_BOMB_NIU           CardPattern = 13 // 炸弹牛
+	CardPattern_FIVE_SMALL_NIU     CardPattern = 14 // 五小牛
+	CardPattern_STRAIGHT_FLUSH_NIU CardPattern = 15 // 同花顺
 )
 
 // Enum value maps for CardPattern.
@@ -299,23 +399,25 @@ var (
 		12: "FIVE_FLOWER_NIU",
 		13: "BOMB_NIU",
 		14: "FIVE_SMALL_NIU",
+		15: "STRAIGHT_FLUSH_NIU",
 	}
 	CardPattern_value = map[string]int32{
-		"PATTERN_UNKNOWN": 0,
-		"NO_NIU":          1,
-		"NIU_1":           2,
-		"NIU_2":           3,
-		"NIU_3":           4,
-		"NIU_4":           5,
-		"NIU_5":           6,
-		"NIU_6":           7,
-		"NIU_7":           8,
-		"NIU_8":           9,
-		"NIU_9":           10,
-		"NIU_NIU":         11,
-		"FIVE_FLOWER_NIU": 12,
-		"BOMB_NIU":        13,
-		"FIVE_SMALL_NIU":  14,
+		"PATTERN_UNKNOWN":    0,
+		"NO_NIU":             1,
+		"NIU_1":              2,
+		"NIU_2":              3,
+		"NIU_3":              4,
+		"NIU_4":              5,
+		"NIU_5":              6,
+		"NIU_6":              7,
+		"NIU_7":              8,
+		"NIU_8":              9,
+		"NIU_9":              10,
+		"NIU_NIU":            11,
+		"FIVE_FLOWER_NIU":    12,
+		"BOMB_NIU":           13,
+		"FIVE_SMALL_NIU":     14,
+		"STRAIGHT_FLUSH_NIU": 15,
 	}
 )
 
@@ -410,6 +512,7 @@ const (
 	GameState_BETTING             GameState = 4
 	GameState_SHOWDOWN            GameState = 5
 	GameState_SETTLEMENT          GameState = 6
+	GameState_CLOSED              GameState = 7 // 本场对局已结束
 )
 
 // Enum value maps for GameState.
@@ -422,6 +525,7 @@ var (
 		4: "BETTING",
 		5: "SHOWDOWN",
 		6: "SETTLEMENT",
+		7: "CLOSED",
 	}
 	GameState_value = map[string]int32{
 		"STATE_UNKNOWN":       0,
@@ -431,6 +535,7 @@ var (
 		"BETTING":             4,
 		"SHOWDOWN":            5,
 		"SETTLEMENT":          6,
+		"CLOSED":              7,
 	}
 )
 
@@ -461,6 +566,206 @@ func (GameState) EnumDescriptor() ([]byte, []int) {
 	return file_api_proto_game_proto_rawDescGZIP(), []int{5}
 }
 
+// 玩法规则
+type RuleSet int32
+
+const (
+	RuleSet_RULE_SET_UNKNOWN RuleSet = 0
+	RuleSet_BANKER_BID       RuleSet = 1 // 看牌抢庄
+	RuleSet_FREE_BID         RuleSet = 2 // 自由抢庄
+	RuleSet_FIXED_BANKER     RuleSet = 3 // 固定庄家
+	RuleSet_ALL_COMPARE      RuleSet = 4 // 通比牛牛
+)
+
+// Enum value maps for RuleSet.
+var (
+	RuleSet_name = map[int32]string{
+		0: "RULE_SET_UNKNOWN",
+		1: "BANKER_BID",
+		2: "FREE_BID",
+		3: "FIXED_BANKER",
+		4: "ALL_COMPARE",
+	}
+	RuleSet_value = map[string]int32{
+		"RULE_SET_UNKNOWN": 0,
+		"BANKER_BID":       1,
+		"FREE_BID":         2,
+		"FIXED_BANKER":     3,
+		"ALL_COMPARE":      4,
+	}
+)
+
+func (x RuleSet) Enum() *RuleSet {
+	p := new(RuleSet)
+	*p = x
+	return p
+}
+
+func (x RuleSet) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RuleSet) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_game_proto_enumTypes[6].Descriptor()
+}
+
+func (RuleSet) Type() protoreflect.EnumType {
+	return &file_api_proto_game_proto_enumTypes[6]
+}
+
+func (x RuleSet) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RuleSet.Descriptor instead.
+func (RuleSet) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{6}
+}
+
+// 庄家不够赔时的赔付策略
+type PayoutPolicy int32
+
+const (
+	PayoutPolicy_PAYOUT_PROPORTIONAL PayoutPolicy = 0 // 按应得比例缩减
+	PayoutPolicy_PAYOUT_SEAT_ORDER   PayoutPolicy = 1 // 从庄家下家开始按座位顺序赔付, 赔完为止
+)
+
+// Enum value maps for PayoutPolicy.
+var (
+	PayoutPolicy_name = map[int32]string{
+		0: "PAYOUT_PROPORTIONAL",
+		1: "PAYOUT_SEAT_ORDER",
+	}
+	PayoutPolicy_value = map[string]int32{
+		"PAYOUT_PROPORTIONAL": 0,
+		"PAYOUT_SEAT_ORDER":   1,
+	}
+)
+
+func (x PayoutPolicy) Enum() *PayoutPolicy {
+	p := new(PayoutPolicy)
+	*p = x
+	return p
+}
+
+func (x PayoutPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PayoutPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_game_proto_enumTypes[7].Descriptor()
+}
+
+func (PayoutPolicy) Type() protoreflect.EnumType {
+	return &file_api_proto_game_proto_enumTypes[7]
+}
+
+func (x PayoutPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PayoutPolicy.Descriptor instead.
+func (PayoutPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{7}
+}
+
+// 平台抽水方式
+type RakeMode int32
+
+const (
+	RakeMode_RAKE_NONE     RakeMode = 0 // 不抽水
+	RakeMode_RAKE_PERCENT  RakeMode = 1 // 按赢分的百分比抽水
+	RakeMode_RAKE_FLAT_FEE RakeMode = 2 // 每局向每位参与者收取固定台费
+)
+
+// Enum value maps for RakeMode.
+var (
+	RakeMode_name = map[int32]string{
+		0: "RAKE_NONE",
+		1: "RAKE_PERCENT",
+		2: "RAKE_FLAT_FEE",
+	}
+	RakeMode_value = map[string]int32{
+		"RAKE_NONE":     0,
+		"RAKE_PERCENT":  1,
+		"RAKE_FLAT_FEE": 2,
+	}
+)
+
+func (x RakeMode) Enum() *RakeMode {
+	p := new(RakeMode)
+	*p = x
+	return p
+}
+
+func (x RakeMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RakeMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_game_proto_enumTypes[8].Descriptor()
+}
+
+func (RakeMode) Type() protoreflect.EnumType {
+	return &file_api_proto_game_proto_enumTypes[8]
+}
+
+func (x RakeMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RakeMode.Descriptor instead.
+func (RakeMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{8}
+}
+
+// 解散房间投票规则
+type DissolveRule int32
+
+const (
+	DissolveRule_DISSOLVE_UNANIMOUS DissolveRule = 0 // 全员同意
+	DissolveRule_DISSOLVE_MAJORITY  DissolveRule = 1 // 过半数同意
+)
+
+// Enum value maps for DissolveRule.
+var (
+	DissolveRule_name = map[int32]string{
+		0: "DISSOLVE_UNANIMOUS",
+		1: "DISSOLVE_MAJORITY",
+	}
+	DissolveRule_value = map[string]int32{
+		"DISSOLVE_UNANIMOUS": 0,
+		"DISSOLVE_MAJORITY":  1,
+	}
+)
+
+func (x DissolveRule) Enum() *DissolveRule {
+	p := new(DissolveRule)
+	*p = x
+	return p
+}
+
+func (x DissolveRule) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DissolveRule) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_game_proto_enumTypes[9].Descriptor()
+}
+
+func (DissolveRule) Type() protoreflect.EnumType {
+	return &file_api_proto_game_proto_enumTypes[9]
+}
+
+func (x DissolveRule) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DissolveRule.Descriptor instead.
+func (DissolveRule) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{9}
+}
+
 // 数据结构
 type Card struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -523,25 +828,2054 @@ type PlayerInfo struct {
 	IsBanker      bool                   `protobuf:"varint,5,opt,name=is_banker,json=isBanker,proto3" json:"is_banker,omitempty"`
 	Hand          []*Card                `protobuf:"bytes,6,rep,name=hand,proto3" json:"hand,omitempty"`
 	CardPattern   CardPattern            `protobuf:"varint,7,opt,name=card_pattern,json=cardPattern,proto3,enum=game.CardPattern" json:"card_pattern,omitempty"`
+	Seat          int32                  `protobuf:"varint,8,opt,name=seat,proto3" json:"seat,omitempty"` // 座位号, 从1开始
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerInfo) Reset() {
+	*x = PlayerInfo{}
+	mi := &file_api_proto_game_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerInfo) ProtoMessage() {}
+
+func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerInfo.ProtoReflect.Descriptor instead.
+func (*PlayerInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlayerInfo) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *PlayerInfo) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *PlayerInfo) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PlayerInfo) GetStatus() PlayerStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PlayerStatus_STATUS_UNKNOWN
+}
+
+func (x *PlayerInfo) GetIsBanker() bool {
+	if x != nil {
+		return x.IsBanker
+	}
+	return false
+}
+
+func (x *PlayerInfo) GetHand() []*Card {
+	if x != nil {
+		return x.Hand
+	}
+	return nil
+}
+
+func (x *PlayerInfo) GetCardPattern() CardPattern {
+	if x != nil {
+		return x.CardPattern
+	}
+	return CardPattern_PATTERN_UNKNOWN
+}
+
+func (x *PlayerInfo) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+// 玩家档案
+type ProfileInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Balance       int64                  `protobuf:"varint,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	RoundsPlayed  int32                  `protobuf:"varint,4,opt,name=rounds_played,json=roundsPlayed,proto3" json:"rounds_played,omitempty"`
+	RoundsWon     int32                  `protobuf:"varint,5,opt,name=rounds_won,json=roundsWon,proto3" json:"rounds_won,omitempty"`
+	TotalWon      int64                  `protobuf:"varint,6,opt,name=total_won,json=totalWon,proto3" json:"total_won,omitempty"`
+	TotalLost     int64                  `protobuf:"varint,7,opt,name=total_lost,json=totalLost,proto3" json:"total_lost,omitempty"`
+	BiggestWin    int64                  `protobuf:"varint,8,opt,name=biggest_win,json=biggestWin,proto3" json:"biggest_win,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileInfo) Reset() {
+	*x = ProfileInfo{}
+	mi := &file_api_proto_game_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileInfo) ProtoMessage() {}
+
+func (x *ProfileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileInfo.ProtoReflect.Descriptor instead.
+func (*ProfileInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProfileInfo) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *ProfileInfo) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *ProfileInfo) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *ProfileInfo) GetRoundsPlayed() int32 {
+	if x != nil {
+		return x.RoundsPlayed
+	}
+	return 0
+}
+
+func (x *ProfileInfo) GetRoundsWon() int32 {
+	if x != nil {
+		return x.RoundsWon
+	}
+	return 0
+}
+
+func (x *ProfileInfo) GetTotalWon() int64 {
+	if x != nil {
+		return x.TotalWon
+	}
+	return 0
+}
+
+func (x *ProfileInfo) GetTotalLost() int64 {
+	if x != nil {
+		return x.TotalLost
+	}
+	return 0
+}
+
+func (x *ProfileInfo) GetBiggestWin() int64 {
+	if x != nil {
+		return x.BiggestWin
+	}
+	return 0
+}
+
+// C2S 消息
+// 登录, 之后该连接以 player_id 的身份进行游戏; 不登录时以游客身份游戏, 玩家ID为负数, 只在本次启动内有效
+type C2S_LoginReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"` // 为空时沿用档案中的昵称
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`       // 账号服务为 player_id 签发的登录令牌
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_LoginReq) Reset() {
+	*x = C2S_LoginReq{}
+	mi := &file_api_proto_game_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_LoginReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_LoginReq) ProtoMessage() {}
+
+func (x *C2S_LoginReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_LoginReq.ProtoReflect.Descriptor instead.
+func (*C2S_LoginReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *C2S_LoginReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *C2S_LoginReq) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *C2S_LoginReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type C2S_JoinRoomReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	InviteCode    string                 `protobuf:"bytes,2,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`     // 私人房间邀请码, room_id 为0时按邀请码查找房间
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`                           // 私人房间密码
+	AsSpectator   bool                   `protobuf:"varint,4,opt,name=as_spectator,json=asSpectator,proto3" json:"as_spectator,omitempty"` // 以观战者身份加入
+	Seat          int32                  `protobuf:"varint,5,opt,name=seat,proto3" json:"seat,omitempty"`                                  // 指定座位号, 0表示自动分配
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_JoinRoomReq) Reset() {
+	*x = C2S_JoinRoomReq{}
+	mi := &file_api_proto_game_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_JoinRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_JoinRoomReq) ProtoMessage() {}
+
+func (x *C2S_JoinRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_JoinRoomReq.ProtoReflect.Descriptor instead.
+func (*C2S_JoinRoomReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *C2S_JoinRoomReq) GetRoomId() int32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *C2S_JoinRoomReq) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+func (x *C2S_JoinRoomReq) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *C2S_JoinRoomReq) GetAsSpectator() bool {
+	if x != nil {
+		return x.AsSpectator
+	}
+	return false
+}
+
+func (x *C2S_JoinRoomReq) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+type C2S_PlayerReadyReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsReady       bool                   `protobuf:"varint,1,opt,name=is_ready,json=isReady,proto3" json:"is_ready,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_PlayerReadyReq) Reset() {
+	*x = C2S_PlayerReadyReq{}
+	mi := &file_api_proto_game_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_PlayerReadyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_PlayerReadyReq) ProtoMessage() {}
+
+func (x *C2S_PlayerReadyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_PlayerReadyReq.ProtoReflect.Descriptor instead.
+func (*C2S_PlayerReadyReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *C2S_PlayerReadyReq) GetIsReady() bool {
+	if x != nil {
+		return x.IsReady
+	}
+	return false
+}
+
+type C2S_BidBankerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Multiple      int32                  `protobuf:"varint,1,opt,name=multiple,proto3" json:"multiple,omitempty"` // 抢庄倍数, 0表示不抢
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_BidBankerReq) Reset() {
+	*x = C2S_BidBankerReq{}
+	mi := &file_api_proto_game_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_BidBankerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_BidBankerReq) ProtoMessage() {}
+
+func (x *C2S_BidBankerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_BidBankerReq.ProtoReflect.Descriptor instead.
+func (*C2S_BidBankerReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *C2S_BidBankerReq) GetMultiple() int32 {
+	if x != nil {
+		return x.Multiple
+	}
+	return 0
+}
+
+type C2S_PlaceBetReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Multiple      int32                  `protobuf:"varint,1,opt,name=multiple,proto3" json:"multiple,omitempty"` // 下注倍数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_PlaceBetReq) Reset() {
+	*x = C2S_PlaceBetReq{}
+	mi := &file_api_proto_game_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_PlaceBetReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_PlaceBetReq) ProtoMessage() {}
+
+func (x *C2S_PlaceBetReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_PlaceBetReq.ProtoReflect.Descriptor instead.
+func (*C2S_PlaceBetReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *C2S_PlaceBetReq) GetMultiple() int32 {
+	if x != nil {
+		return x.Multiple
+	}
+	return 0
+}
+
+type C2S_ShowdownReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 客户端计算好牌型后，将手牌顺序调整好再发给服务器
+	SortedHand    []*Card `protobuf:"bytes,1,rep,name=sorted_hand,json=sortedHand,proto3" json:"sorted_hand,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_ShowdownReq) Reset() {
+	*x = C2S_ShowdownReq{}
+	mi := &file_api_proto_game_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_ShowdownReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_ShowdownReq) ProtoMessage() {}
+
+func (x *C2S_ShowdownReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_ShowdownReq.ProtoReflect.Descriptor instead.
+func (*C2S_ShowdownReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *C2S_ShowdownReq) GetSortedHand() []*Card {
+	if x != nil {
+		return x.SortedHand
+	}
+	return nil
+}
+
+type C2S_LeaveRoomReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_LeaveRoomReq) Reset() {
+	*x = C2S_LeaveRoomReq{}
+	mi := &file_api_proto_game_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_LeaveRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_LeaveRoomReq) ProtoMessage() {}
+
+func (x *C2S_LeaveRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_LeaveRoomReq.ProtoReflect.Descriptor instead.
+func (*C2S_LeaveRoomReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{9}
+}
+
+// 房间设置
+type RoomSettings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseBet       int64                  `protobuf:"varint,1,opt,name=base_bet,json=baseBet,proto3" json:"base_bet,omitempty"`                                       // 底注
+	MaxPlayers    int32                  `protobuf:"varint,2,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`                              // 最大玩家数(座位数), 2-10
+	RuleSet       RuleSet                `protobuf:"varint,3,opt,name=rule_set,json=ruleSet,proto3,enum=game.RuleSet" json:"rule_set,omitempty"`                     // 玩法规则
+	Rounds        int32                  `protobuf:"varint,4,opt,name=rounds,proto3" json:"rounds,omitempty"`                                                        // 局数, 0表示不限
+	IsPrivate     bool                   `protobuf:"varint,5,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`                                 // 是否私人房间
+	MaxSpectators int32                  `protobuf:"varint,6,opt,name=max_spectators,json=maxSpectators,proto3" json:"max_spectators,omitempty"`                     // 最大观战人数, 0表示不允许观战
+	MinPlayers    int32                  `protobuf:"varint,7,opt,name=min_players,json=minPlayers,proto3" json:"min_players,omitempty"`                              // 开局所需最少玩家数
+	DissolveRule  DissolveRule           `protobuf:"varint,8,opt,name=dissolve_rule,json=dissolveRule,proto3,enum=game.DissolveRule" json:"dissolve_rule,omitempty"` // 解散房间投票规则
+	PayoutPolicy  PayoutPolicy           `protobuf:"varint,9,opt,name=payout_policy,json=payoutPolicy,proto3,enum=game.PayoutPolicy" json:"payout_policy,omitempty"` // 庄家不够赔时的赔付策略
+	Rake          *RakeInfo              `protobuf:"bytes,10,opt,name=rake,proto3" json:"rake,omitempty"`                                                            // 平台抽水策略, 由服务器配置, 创建房间时忽略
+	BidMultiples  []int32                `protobuf:"varint,11,rep,packed,name=bid_multiples,json=bidMultiples,proto3" json:"bid_multiples,omitempty"`                // 允许的抢庄倍数, 升序, 为空时使用默认值
+	BetMultiples  []int32                `protobuf:"varint,12,rep,packed,name=bet_multiples,json=betMultiples,proto3" json:"bet_multiples,omitempty"`                // 允许的下注倍数, 升序, 为空时使用默认值
+	PushBetCap    int32                  `protobuf:"varint,13,opt,name=push_bet_cap,json=pushBetCap,proto3" json:"push_bet_cap,omitempty"`                           // 推注倍数上限, 0表示不允许推注
+	PushCooldown  int32                  `protobuf:"varint,14,opt,name=push_cooldown,json=pushCooldown,proto3" json:"push_cooldown,omitempty"`                       // 推注后需要间隔的局数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomSettings) Reset() {
+	*x = RoomSettings{}
+	mi := &file_api_proto_game_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomSettings) ProtoMessage() {}
+
+func (x *RoomSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomSettings.ProtoReflect.Descriptor instead.
+func (*RoomSettings) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RoomSettings) GetBaseBet() int64 {
+	if x != nil {
+		return x.BaseBet
+	}
+	return 0
+}
+
+func (x *RoomSettings) GetMaxPlayers() int32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *RoomSettings) GetRuleSet() RuleSet {
+	if x != nil {
+		return x.RuleSet
+	}
+	return RuleSet_RULE_SET_UNKNOWN
+}
+
+func (x *RoomSettings) GetRounds() int32 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *RoomSettings) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *RoomSettings) GetMaxSpectators() int32 {
+	if x != nil {
+		return x.MaxSpectators
+	}
+	return 0
+}
+
+func (x *RoomSettings) GetMinPlayers() int32 {
+	if x != nil {
+		return x.MinPlayers
+	}
+	return 0
+}
+
+func (x *RoomSettings) GetDissolveRule() DissolveRule {
+	if x != nil {
+		return x.DissolveRule
+	}
+	return DissolveRule_DISSOLVE_UNANIMOUS
+}
+
+func (x *RoomSettings) GetPayoutPolicy() PayoutPolicy {
+	if x != nil {
+		return x.PayoutPolicy
+	}
+	return PayoutPolicy_PAYOUT_PROPORTIONAL
+}
+
+func (x *RoomSettings) GetRake() *RakeInfo {
+	if x != nil {
+		return x.Rake
+	}
+	return nil
+}
+
+func (x *RoomSettings) GetBidMultiples() []int32 {
+	if x != nil {
+		return x.BidMultiples
+	}
+	return nil
+}
+
+func (x *RoomSettings) GetBetMultiples() []int32 {
+	if x != nil {
+		return x.BetMultiples
+	}
+	return nil
+}
+
+func (x *RoomSettings) GetPushBetCap() int32 {
+	if x != nil {
+		return x.PushBetCap
+	}
+	return 0
+}
+
+func (x *RoomSettings) GetPushCooldown() int32 {
+	if x != nil {
+		return x.PushCooldown
+	}
+	return 0
+}
+
+// 平台抽水策略
+type RakeInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          RakeMode               `protobuf:"varint,1,opt,name=mode,proto3,enum=game.RakeMode" json:"mode,omitempty"`
+	Percent       int64                  `protobuf:"varint,2,opt,name=percent,proto3" json:"percent,omitempty"` // RAKE_PERCENT: 抽取净赢分的百分比
+	Cap           int64                  `protobuf:"varint,3,opt,name=cap,proto3" json:"cap,omitempty"`         // RAKE_PERCENT: 每局抽水合计上限, 由本局赢家分摊, 0表示不设上限
+	Fee           int64                  `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`         // RAKE_FLAT_FEE: 每人每局的台费
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RakeInfo) Reset() {
+	*x = RakeInfo{}
+	mi := &file_api_proto_game_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RakeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RakeInfo) ProtoMessage() {}
+
+func (x *RakeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RakeInfo.ProtoReflect.Descriptor instead.
+func (*RakeInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RakeInfo) GetMode() RakeMode {
+	if x != nil {
+		return x.Mode
+	}
+	return RakeMode_RAKE_NONE
+}
+
+func (x *RakeInfo) GetPercent() int64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *RakeInfo) GetCap() int64 {
+	if x != nil {
+		return x.Cap
+	}
+	return 0
+}
+
+func (x *RakeInfo) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+// 大厅房间列表过滤条件, 零值表示不过滤
+type C2S_RoomListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinBaseBet    int64                  `protobuf:"varint,1,opt,name=min_base_bet,json=minBaseBet,proto3" json:"min_base_bet,omitempty"`
+	MaxBaseBet    int64                  `protobuf:"varint,2,opt,name=max_base_bet,json=maxBaseBet,proto3" json:"max_base_bet,omitempty"`
+	RuleSet       RuleSet                `protobuf:"varint,3,opt,name=rule_set,json=ruleSet,proto3,enum=game.RuleSet" json:"rule_set,omitempty"`
+	OnlyAvailable bool                   `protobuf:"varint,4,opt,name=only_available,json=onlyAvailable,proto3" json:"only_available,omitempty"` // 只返回未满且未开局的房间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_RoomListReq) Reset() {
+	*x = C2S_RoomListReq{}
+	mi := &file_api_proto_game_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_RoomListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_RoomListReq) ProtoMessage() {}
+
+func (x *C2S_RoomListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_RoomListReq.ProtoReflect.Descriptor instead.
+func (*C2S_RoomListReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *C2S_RoomListReq) GetMinBaseBet() int64 {
+	if x != nil {
+		return x.MinBaseBet
+	}
+	return 0
+}
+
+func (x *C2S_RoomListReq) GetMaxBaseBet() int64 {
+	if x != nil {
+		return x.MaxBaseBet
+	}
+	return 0
+}
+
+func (x *C2S_RoomListReq) GetRuleSet() RuleSet {
+	if x != nil {
+		return x.RuleSet
+	}
+	return RuleSet_RULE_SET_UNKNOWN
+}
+
+func (x *C2S_RoomListReq) GetOnlyAvailable() bool {
+	if x != nil {
+		return x.OnlyAvailable
+	}
+	return false
+}
+
+type C2S_CreateRoomReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *RoomSettings          `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // 私人房间密码, 可选
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_CreateRoomReq) Reset() {
+	*x = C2S_CreateRoomReq{}
+	mi := &file_api_proto_game_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_CreateRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_CreateRoomReq) ProtoMessage() {}
+
+func (x *C2S_CreateRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_CreateRoomReq.ProtoReflect.Descriptor instead.
+func (*C2S_CreateRoomReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *C2S_CreateRoomReq) GetSettings() *RoomSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *C2S_CreateRoomReq) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// 快速匹配, 按场次排队
+type C2S_QuickMatchReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TierId        int32                  `protobuf:"varint,1,opt,name=tier_id,json=tierId,proto3" json:"tier_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_QuickMatchReq) Reset() {
+	*x = C2S_QuickMatchReq{}
+	mi := &file_api_proto_game_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_QuickMatchReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_QuickMatchReq) ProtoMessage() {}
+
+func (x *C2S_QuickMatchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_QuickMatchReq.ProtoReflect.Descriptor instead.
+func (*C2S_QuickMatchReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *C2S_QuickMatchReq) GetTierId() int32 {
+	if x != nil {
+		return x.TierId
+	}
+	return 0
+}
+
+type C2S_CancelMatchReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_CancelMatchReq) Reset() {
+	*x = C2S_CancelMatchReq{}
+	mi := &file_api_proto_game_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_CancelMatchReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_CancelMatchReq) ProtoMessage() {}
+
+func (x *C2S_CancelMatchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_CancelMatchReq.ProtoReflect.Descriptor instead.
+func (*C2S_CancelMatchReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{15}
+}
+
+// 观战者在两局之间入座
+type C2S_TakeSeatReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seat          int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"` // 指定座位号, 0表示自动分配
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_TakeSeatReq) Reset() {
+	*x = C2S_TakeSeatReq{}
+	mi := &file_api_proto_game_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_TakeSeatReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_TakeSeatReq) ProtoMessage() {}
+
+func (x *C2S_TakeSeatReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_TakeSeatReq.ProtoReflect.Descriptor instead.
+func (*C2S_TakeSeatReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *C2S_TakeSeatReq) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+// 房主操作: 两局之间踢出玩家
+type C2S_KickPlayerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_KickPlayerReq) Reset() {
+	*x = C2S_KickPlayerReq{}
+	mi := &file_api_proto_game_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_KickPlayerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_KickPlayerReq) ProtoMessage() {}
+
+func (x *C2S_KickPlayerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_KickPlayerReq.ProtoReflect.Descriptor instead.
+func (*C2S_KickPlayerReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *C2S_KickPlayerReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 房主操作: 锁定或解锁空座位
+type C2S_LockSeatReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seat          int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	Locked        bool                   `protobuf:"varint,2,opt,name=locked,proto3" json:"locked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_LockSeatReq) Reset() {
+	*x = C2S_LockSeatReq{}
+	mi := &file_api_proto_game_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_LockSeatReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_LockSeatReq) ProtoMessage() {}
+
+func (x *C2S_LockSeatReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_LockSeatReq.ProtoReflect.Descriptor instead.
+func (*C2S_LockSeatReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *C2S_LockSeatReq) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *C2S_LockSeatReq) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+// 房主操作: 已准备人数达到最少开局人数时强制开局
+type C2S_ForceStartReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_ForceStartReq) Reset() {
+	*x = C2S_ForceStartReq{}
+	mi := &file_api_proto_game_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_ForceStartReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_ForceStartReq) ProtoMessage() {}
+
+func (x *C2S_ForceStartReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_ForceStartReq.ProtoReflect.Descriptor instead.
+func (*C2S_ForceStartReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{19}
+}
+
+// 房主操作: 转让房主
+type C2S_TransferOwnerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_TransferOwnerReq) Reset() {
+	*x = C2S_TransferOwnerReq{}
+	mi := &file_api_proto_game_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_TransferOwnerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_TransferOwnerReq) ProtoMessage() {}
+
+func (x *C2S_TransferOwnerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_TransferOwnerReq.ProtoReflect.Descriptor instead.
+func (*C2S_TransferOwnerReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *C2S_TransferOwnerReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 发起解散房间投票, 仅限有局数限制的房间
+type C2S_DissolveProposeReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_DissolveProposeReq) Reset() {
+	*x = C2S_DissolveProposeReq{}
+	mi := &file_api_proto_game_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_DissolveProposeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_DissolveProposeReq) ProtoMessage() {}
+
+func (x *C2S_DissolveProposeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_DissolveProposeReq.ProtoReflect.Descriptor instead.
+func (*C2S_DissolveProposeReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{21}
+}
+
+// 对解散房间投票
+type C2S_DissolveVoteReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agree         bool                   `protobuf:"varint,1,opt,name=agree,proto3" json:"agree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *C2S_DissolveVoteReq) Reset() {
+	*x = C2S_DissolveVoteReq{}
+	mi := &file_api_proto_game_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *C2S_DissolveVoteReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_DissolveVoteReq) ProtoMessage() {}
+
+func (x *C2S_DissolveVoteReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_DissolveVoteReq.ProtoReflect.Descriptor instead.
+func (*C2S_DissolveVoteReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *C2S_DissolveVoteReq) GetAgree() bool {
+	if x != nil {
+		return x.Agree
+	}
+	return false
+}
+
+// S2C 消息
+type S2C_LoginAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Profile       *ProfileInfo           `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *S2C_LoginAck) Reset() {
+	*x = S2C_LoginAck{}
+	mi := &file_api_proto_game_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S2C_LoginAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_LoginAck) ProtoMessage() {}
+
+func (x *S2C_LoginAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_LoginAck.ProtoReflect.Descriptor instead.
+func (*S2C_LoginAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *S2C_LoginAck) GetRetCode() int32 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+func (x *S2C_LoginAck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *S2C_LoginAck) GetProfile() *ProfileInfo {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type S2C_JoinRoomAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"` // 0 for success
+	RoomInfo      *RoomInfo              `protobuf:"bytes,2,opt,name=room_info,json=roomInfo,proto3" json:"room_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *S2C_JoinRoomAck) Reset() {
+	*x = S2C_JoinRoomAck{}
+	mi := &file_api_proto_game_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S2C_JoinRoomAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_JoinRoomAck) ProtoMessage() {}
+
+func (x *S2C_JoinRoomAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_JoinRoomAck.ProtoReflect.Descriptor instead.
+func (*S2C_JoinRoomAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *S2C_JoinRoomAck) GetRetCode() int32 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+func (x *S2C_JoinRoomAck) GetRoomInfo() *RoomInfo {
+	if x != nil {
+		return x.RoomInfo
+	}
+	return nil
+}
+
+// 新增: S2C_BidBankerAck 响应客户端的抢庄请求
+type S2C_BidBankerAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"`    // 0 for success
+	PlayerId      int64                  `protobuf:"varint,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 成功抢到庄的玩家ID，如果ret_code非0则此字段无意义
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *S2C_BidBankerAck) Reset() {
+	*x = S2C_BidBankerAck{}
+	mi := &file_api_proto_game_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S2C_BidBankerAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_BidBankerAck) ProtoMessage() {}
+
+func (x *S2C_BidBankerAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_BidBankerAck.ProtoReflect.Descriptor instead.
+func (*S2C_BidBankerAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *S2C_BidBankerAck) GetRetCode() int32 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+func (x *S2C_BidBankerAck) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 新增: S2C_PlaceBetAck 响应客户端的下注请求
+type S2C_PlaceBetAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"` // 0 for success
+	Multiple      int32                  `protobuf:"varint,2,opt,name=multiple,proto3" json:"multiple,omitempty"`              // 成功下注的倍数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *S2C_PlaceBetAck) Reset() {
+	*x = S2C_PlaceBetAck{}
+	mi := &file_api_proto_game_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S2C_PlaceBetAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_PlaceBetAck) ProtoMessage() {}
+
+func (x *S2C_PlaceBetAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_PlaceBetAck.ProtoReflect.Descriptor instead.
+func (*S2C_PlaceBetAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *S2C_PlaceBetAck) GetRetCode() int32 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+func (x *S2C_PlaceBetAck) GetMultiple() int32 {
+	if x != nil {
+		return x.Multiple
+	}
+	return 0
+}
+
+// 新增: S2C_ShowdownAck 响应客户端的摊牌请求
+type S2C_ShowdownAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"` // 0 for success
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *S2C_ShowdownAck) Reset() {
+	*x = S2C_ShowdownAck{}
+	mi := &file_api_proto_game_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S2C_ShowdownAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_ShowdownAck) ProtoMessage() {}
+
+func (x *S2C_ShowdownAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_ShowdownAck.ProtoReflect.Descriptor instead.
+func (*S2C_ShowdownAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *S2C_ShowdownAck) GetRetCode() int32 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+type RoomInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RoomId         int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Players        []*PlayerInfo          `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
+	GameState      GameState              `protobuf:"varint,3,opt,name=game_state,json=gameState,proto3,enum=game.GameState" json:"game_state,omitempty"`
+	BankerId       int64                  `protobuf:"varint,4,opt,name=banker_id,json=bankerId,proto3" json:"banker_id,omitempty"`
+	Settings       *RoomSettings          `protobuf:"bytes,5,opt,name=settings,proto3" json:"settings,omitempty"`
+	InviteCode     string                 `protobuf:"bytes,6,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"` // 私人房间邀请码
+	SpectatorCount int32                  `protobuf:"varint,7,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`
+	OwnerId        int64                  `protobuf:"varint,8,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`                    // 房主ID, 0表示没有房主
+	LockedSeats    []int32                `protobuf:"varint,9,rep,packed,name=locked_seats,json=lockedSeats,proto3" json:"locked_seats,omitempty"` // 被房主锁定的座位号
+	DissolveVote   *DissolveVoteInfo      `protobuf:"bytes,10,opt,name=dissolve_vote,json=dissolveVote,proto3" json:"dissolve_vote,omitempty"`     // 进行中的解散投票, 没有投票时为空
+	Session        *SessionInfo           `protobuf:"bytes,11,opt,name=session,proto3" json:"session,omitempty"`                                   // 本场对局的分数表
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RoomInfo) Reset() {
+	*x = RoomInfo{}
+	mi := &file_api_proto_game_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomInfo) ProtoMessage() {}
+
+func (x *RoomInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomInfo.ProtoReflect.Descriptor instead.
+func (*RoomInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RoomInfo) GetRoomId() int32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetPlayers() []*PlayerInfo {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *RoomInfo) GetGameState() GameState {
+	if x != nil {
+		return x.GameState
+	}
+	return GameState_STATE_UNKNOWN
+}
+
+func (x *RoomInfo) GetBankerId() int64 {
+	if x != nil {
+		return x.BankerId
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetSettings() *RoomSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *RoomInfo) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+func (x *RoomInfo) GetSpectatorCount() int32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetLockedSeats() []int32 {
+	if x != nil {
+		return x.LockedSeats
+	}
+	return nil
+}
+
+func (x *RoomInfo) GetDissolveVote() *DissolveVoteInfo {
+	if x != nil {
+		return x.DissolveVote
+	}
+	return nil
+}
+
+func (x *RoomInfo) GetSession() *SessionInfo {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// 一名玩家在一局中的分数变化
+type ScoreChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	ScoreChange   int64                  `protobuf:"varint,2,opt,name=score_change,json=scoreChange,proto3" json:"score_change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreChange) Reset() {
+	*x = ScoreChange{}
+	mi := &file_api_proto_game_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreChange) ProtoMessage() {}
+
+func (x *ScoreChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreChange.ProtoReflect.Descriptor instead.
+func (*ScoreChange) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ScoreChange) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *ScoreChange) GetScoreChange() int64 {
+	if x != nil {
+		return x.ScoreChange
+	}
+	return 0
+}
+
+// 分数表中的一局
+type RoundScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Round         int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	BankerId      int64                  `protobuf:"varint,2,opt,name=banker_id,json=bankerId,proto3" json:"banker_id,omitempty"`
+	Changes       []*ScoreChange         `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoundScore) Reset() {
+	*x = RoundScore{}
+	mi := &file_api_proto_game_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoundScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoundScore) ProtoMessage() {}
+
+func (x *RoundScore) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoundScore.ProtoReflect.Descriptor instead.
+func (*RoundScore) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RoundScore) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *RoundScore) GetBankerId() int64 {
+	if x != nil {
+		return x.BankerId
+	}
+	return 0
+}
+
+func (x *RoundScore) GetChanges() []*ScoreChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// 一名玩家在本场对局中的累计数据
+type SessionScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	RoundsPlayed  int32                  `protobuf:"varint,3,opt,name=rounds_played,json=roundsPlayed,proto3" json:"rounds_played,omitempty"`
+	Wins          int32                  `protobuf:"varint,4,opt,name=wins,proto3" json:"wins,omitempty"`
+	NetScore      int64                  `protobuf:"varint,5,opt,name=net_score,json=netScore,proto3" json:"net_score,omitempty"`
+	BestHand      []*Card                `protobuf:"bytes,6,rep,name=best_hand,json=bestHand,proto3" json:"best_hand,omitempty"`
+	BestPattern   CardPattern            `protobuf:"varint,7,opt,name=best_pattern,json=bestPattern,proto3,enum=game.CardPattern" json:"best_pattern,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionScore) Reset() {
+	*x = SessionScore{}
+	mi := &file_api_proto_game_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionScore) ProtoMessage() {}
+
+func (x *SessionScore) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionScore.ProtoReflect.Descriptor instead.
+func (*SessionScore) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SessionScore) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *SessionScore) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *SessionScore) GetRoundsPlayed() int32 {
+	if x != nil {
+		return x.RoundsPlayed
+	}
+	return 0
+}
+
+func (x *SessionScore) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *SessionScore) GetNetScore() int64 {
+	if x != nil {
+		return x.NetScore
+	}
+	return 0
+}
+
+func (x *SessionScore) GetBestHand() []*Card {
+	if x != nil {
+		return x.BestHand
+	}
+	return nil
+}
+
+func (x *SessionScore) GetBestPattern() CardPattern {
+	if x != nil {
+		return x.BestPattern
+	}
+	return CardPattern_PATTERN_UNKNOWN
+}
+
+// 本场对局的进度和分数表
+type SessionInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoundsPlayed  int32                  `protobuf:"varint,1,opt,name=rounds_played,json=roundsPlayed,proto3" json:"rounds_played,omitempty"`
+	TotalRounds   int32                  `protobuf:"varint,2,opt,name=total_rounds,json=totalRounds,proto3" json:"total_rounds,omitempty"` // 0表示不限局数
+	Rounds        []*RoundScore          `protobuf:"bytes,3,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	Scores        []*SessionScore        `protobuf:"bytes,4,rep,name=scores,proto3" json:"scores,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
+	mi := &file_api_proto_game_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInfo) ProtoMessage() {}
+
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SessionInfo) GetRoundsPlayed() int32 {
+	if x != nil {
+		return x.RoundsPlayed
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetTotalRounds() int32 {
+	if x != nil {
+		return x.TotalRounds
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetRounds() []*RoundScore {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetScores() []*SessionScore {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+// 解散房间投票的进度
+type DissolveVoteInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProposerId    int64                  `protobuf:"varint,1,opt,name=proposer_id,json=proposerId,proto3" json:"proposer_id,omitempty"`
+	Deadline      int64                  `protobuf:"varint,2,opt,name=deadline,proto3" json:"deadline,omitempty"`      // 投票截止时间, Unix 秒; 截止时未投票的玩家视为同意
+	Agreed        []int64                `protobuf:"varint,3,rep,packed,name=agreed,proto3" json:"agreed,omitempty"`   // 已同意的玩家ID
+	Refused       []int64                `protobuf:"varint,4,rep,packed,name=refused,proto3" json:"refused,omitempty"` // 已拒绝的玩家ID
+	Pending       []int64                `protobuf:"varint,5,rep,packed,name=pending,proto3" json:"pending,omitempty"` // 尚未投票的玩家ID
+	Rule          DissolveRule           `protobuf:"varint,6,opt,name=rule,proto3,enum=game.DissolveRule" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DissolveVoteInfo) Reset() {
+	*x = DissolveVoteInfo{}
+	mi := &file_api_proto_game_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DissolveVoteInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DissolveVoteInfo) ProtoMessage() {}
+
+func (x *DissolveVoteInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DissolveVoteInfo.ProtoReflect.Descriptor instead.
+func (*DissolveVoteInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DissolveVoteInfo) GetProposerId() int64 {
+	if x != nil {
+		return x.ProposerId
+	}
+	return 0
+}
+
+func (x *DissolveVoteInfo) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *DissolveVoteInfo) GetAgreed() []int64 {
+	if x != nil {
+		return x.Agreed
+	}
+	return nil
+}
+
+func (x *DissolveVoteInfo) GetRefused() []int64 {
+	if x != nil {
+		return x.Refused
+	}
+	return nil
+}
+
+func (x *DissolveVoteInfo) GetPending() []int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+func (x *DissolveVoteInfo) GetRule() DissolveRule {
+	if x != nil {
+		return x.Rule
+	}
+	return DissolveRule_DISSOLVE_UNANIMOUS
+}
+
+// 大厅中展示的房间摘要
+type RoomSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	PlayerCount   int32                  `protobuf:"varint,2,opt,name=player_count,json=playerCount,proto3" json:"player_count,omitempty"`
+	MaxPlayers    int32                  `protobuf:"varint,3,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
+	BaseBet       int64                  `protobuf:"varint,4,opt,name=base_bet,json=baseBet,proto3" json:"base_bet,omitempty"`
+	RuleSet       RuleSet                `protobuf:"varint,5,opt,name=rule_set,json=ruleSet,proto3,enum=game.RuleSet" json:"rule_set,omitempty"`
+	Rounds        int32                  `protobuf:"varint,6,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	GameState     GameState              `protobuf:"varint,7,opt,name=game_state,json=gameState,proto3,enum=game.GameState" json:"game_state,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlayerInfo) Reset() {
-	*x = PlayerInfo{}
-	mi := &file_api_proto_game_proto_msgTypes[1]
+func (x *RoomSummary) Reset() {
+	*x = RoomSummary{}
+	mi := &file_api_proto_game_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerInfo) String() string {
+func (x *RoomSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerInfo) ProtoMessage() {}
+func (*RoomSummary) ProtoMessage() {}
 
-func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[1]
+func (x *RoomSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,83 +2886,83 @@ func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerInfo.ProtoReflect.Descriptor instead.
-func (*PlayerInfo) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use RoomSummary.ProtoReflect.Descriptor instead.
+func (*RoomSummary) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *PlayerInfo) GetPlayerId() int64 {
+func (x *RoomSummary) GetRoomId() int32 {
 	if x != nil {
-		return x.PlayerId
+		return x.RoomId
 	}
 	return 0
 }
 
-func (x *PlayerInfo) GetNickname() string {
+func (x *RoomSummary) GetPlayerCount() int32 {
 	if x != nil {
-		return x.Nickname
+		return x.PlayerCount
 	}
-	return ""
+	return 0
 }
 
-func (x *PlayerInfo) GetScore() int64 {
+func (x *RoomSummary) GetMaxPlayers() int32 {
 	if x != nil {
-		return x.Score
+		return x.MaxPlayers
 	}
 	return 0
 }
 
-func (x *PlayerInfo) GetStatus() PlayerStatus {
+func (x *RoomSummary) GetBaseBet() int64 {
 	if x != nil {
-		return x.Status
+		return x.BaseBet
 	}
-	return PlayerStatus_STATUS_UNKNOWN
+	return 0
 }
 
-func (x *PlayerInfo) GetIsBanker() bool {
+func (x *RoomSummary) GetRuleSet() RuleSet {
 	if x != nil {
-		return x.IsBanker
+		return x.RuleSet
 	}
-	return false
+	return RuleSet_RULE_SET_UNKNOWN
 }
 
-func (x *PlayerInfo) GetHand() []*Card {
+func (x *RoomSummary) GetRounds() int32 {
 	if x != nil {
-		return x.Hand
+		return x.Rounds
 	}
-	return nil
+	return 0
 }
 
-func (x *PlayerInfo) GetCardPattern() CardPattern {
+func (x *RoomSummary) GetGameState() GameState {
 	if x != nil {
-		return x.CardPattern
+		return x.GameState
 	}
-	return CardPattern_PATTERN_UNKNOWN
+	return GameState_STATE_UNKNOWN
 }
 
-// C2S 消息
-type C2S_JoinRoomReq struct {
+type S2C_RoomListAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"`
+	Rooms         []*RoomSummary         `protobuf:"bytes,2,rep,name=rooms,proto3" json:"rooms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *C2S_JoinRoomReq) Reset() {
-	*x = C2S_JoinRoomReq{}
-	mi := &file_api_proto_game_proto_msgTypes[2]
+func (x *S2C_RoomListAck) Reset() {
+	*x = S2C_RoomListAck{}
+	mi := &file_api_proto_game_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *C2S_JoinRoomReq) String() string {
+func (x *S2C_RoomListAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*C2S_JoinRoomReq) ProtoMessage() {}
+func (*S2C_RoomListAck) ProtoMessage() {}
 
-func (x *C2S_JoinRoomReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[2]
+func (x *S2C_RoomListAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -639,40 +2973,48 @@ func (x *C2S_JoinRoomReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use C2S_JoinRoomReq.ProtoReflect.Descriptor instead.
-func (*C2S_JoinRoomReq) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use S2C_RoomListAck.ProtoReflect.Descriptor instead.
+func (*S2C_RoomListAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *C2S_JoinRoomReq) GetRoomId() int32 {
+func (x *S2C_RoomListAck) GetRetCode() int32 {
 	if x != nil {
-		return x.RoomId
+		return x.RetCode
 	}
 	return 0
 }
 
-type C2S_PlayerReadyReq struct {
+func (x *S2C_RoomListAck) GetRooms() []*RoomSummary {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+type S2C_CreateRoomAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	IsReady       bool                   `protobuf:"varint,1,opt,name=is_ready,json=isReady,proto3" json:"is_ready,omitempty"`
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"`
+	RoomInfo      *RoomInfo              `protobuf:"bytes,2,opt,name=room_info,json=roomInfo,proto3" json:"room_info,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *C2S_PlayerReadyReq) Reset() {
-	*x = C2S_PlayerReadyReq{}
-	mi := &file_api_proto_game_proto_msgTypes[3]
+func (x *S2C_CreateRoomAck) Reset() {
+	*x = S2C_CreateRoomAck{}
+	mi := &file_api_proto_game_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *C2S_PlayerReadyReq) String() string {
+func (x *S2C_CreateRoomAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*C2S_PlayerReadyReq) ProtoMessage() {}
+func (*S2C_CreateRoomAck) ProtoMessage() {}
 
-func (x *C2S_PlayerReadyReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[3]
+func (x *S2C_CreateRoomAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -683,40 +3025,49 @@ func (x *C2S_PlayerReadyReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use C2S_PlayerReadyReq.ProtoReflect.Descriptor instead.
-func (*C2S_PlayerReadyReq) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use S2C_CreateRoomAck.ProtoReflect.Descriptor instead.
+func (*S2C_CreateRoomAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *C2S_PlayerReadyReq) GetIsReady() bool {
+func (x *S2C_CreateRoomAck) GetRetCode() int32 {
 	if x != nil {
-		return x.IsReady
+		return x.RetCode
 	}
-	return false
+	return 0
 }
 
-type C2S_BidBankerReq struct {
+func (x *S2C_CreateRoomAck) GetRoomInfo() *RoomInfo {
+	if x != nil {
+		return x.RoomInfo
+	}
+	return nil
+}
+
+type S2C_QuickMatchAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Multiple      int32                  `protobuf:"varint,1,opt,name=multiple,proto3" json:"multiple,omitempty"` // 抢庄倍数, 0表示不抢
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"`
+	TierId        int32                  `protobuf:"varint,2,opt,name=tier_id,json=tierId,proto3" json:"tier_id,omitempty"`
+	Position      int32                  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"` // 排队位置, 从1开始
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *C2S_BidBankerReq) Reset() {
-	*x = C2S_BidBankerReq{}
-	mi := &file_api_proto_game_proto_msgTypes[4]
+func (x *S2C_QuickMatchAck) Reset() {
+	*x = S2C_QuickMatchAck{}
+	mi := &file_api_proto_game_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *C2S_BidBankerReq) String() string {
+func (x *S2C_QuickMatchAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*C2S_BidBankerReq) ProtoMessage() {}
+func (*S2C_QuickMatchAck) ProtoMessage() {}
 
-func (x *C2S_BidBankerReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[4]
+func (x *S2C_QuickMatchAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -727,40 +3078,54 @@ func (x *C2S_BidBankerReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use C2S_BidBankerReq.ProtoReflect.Descriptor instead.
-func (*C2S_BidBankerReq) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use S2C_QuickMatchAck.ProtoReflect.Descriptor instead.
+func (*S2C_QuickMatchAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *C2S_BidBankerReq) GetMultiple() int32 {
+func (x *S2C_QuickMatchAck) GetRetCode() int32 {
 	if x != nil {
-		return x.Multiple
+		return x.RetCode
 	}
 	return 0
 }
 
-type C2S_PlaceBetReq struct {
+func (x *S2C_QuickMatchAck) GetTierId() int32 {
+	if x != nil {
+		return x.TierId
+	}
+	return 0
+}
+
+func (x *S2C_QuickMatchAck) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type S2C_CancelMatchAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Multiple      int32                  `protobuf:"varint,1,opt,name=multiple,proto3" json:"multiple,omitempty"` // 下注倍数
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *C2S_PlaceBetReq) Reset() {
-	*x = C2S_PlaceBetReq{}
-	mi := &file_api_proto_game_proto_msgTypes[5]
+func (x *S2C_CancelMatchAck) Reset() {
+	*x = S2C_CancelMatchAck{}
+	mi := &file_api_proto_game_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *C2S_PlaceBetReq) String() string {
+func (x *S2C_CancelMatchAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*C2S_PlaceBetReq) ProtoMessage() {}
+func (*S2C_CancelMatchAck) ProtoMessage() {}
 
-func (x *C2S_PlaceBetReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[5]
+func (x *S2C_CancelMatchAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -771,41 +3136,40 @@ func (x *C2S_PlaceBetReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use C2S_PlaceBetReq.ProtoReflect.Descriptor instead.
-func (*C2S_PlaceBetReq) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use S2C_CancelMatchAck.ProtoReflect.Descriptor instead.
+func (*S2C_CancelMatchAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *C2S_PlaceBetReq) GetMultiple() int32 {
+func (x *S2C_CancelMatchAck) GetRetCode() int32 {
 	if x != nil {
-		return x.Multiple
+		return x.RetCode
 	}
 	return 0
 }
 
-type C2S_ShowdownReq struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// 客户端计算好牌型后，将手牌顺序调整好再发给服务器
-	SortedHand    []*Card `protobuf:"bytes,1,rep,name=sorted_hand,json=sortedHand,proto3" json:"sorted_hand,omitempty"`
+type S2C_TakeSeatAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *C2S_ShowdownReq) Reset() {
-	*x = C2S_ShowdownReq{}
-	mi := &file_api_proto_game_proto_msgTypes[6]
+func (x *S2C_TakeSeatAck) Reset() {
+	*x = S2C_TakeSeatAck{}
+	mi := &file_api_proto_game_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *C2S_ShowdownReq) String() string {
+func (x *S2C_TakeSeatAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*C2S_ShowdownReq) ProtoMessage() {}
+func (*S2C_TakeSeatAck) ProtoMessage() {}
 
-func (x *C2S_ShowdownReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[6]
+func (x *S2C_TakeSeatAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -816,39 +3180,40 @@ func (x *C2S_ShowdownReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use C2S_ShowdownReq.ProtoReflect.Descriptor instead.
-func (*C2S_ShowdownReq) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use S2C_TakeSeatAck.ProtoReflect.Descriptor instead.
+func (*S2C_TakeSeatAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *C2S_ShowdownReq) GetSortedHand() []*Card {
+func (x *S2C_TakeSeatAck) GetRetCode() int32 {
 	if x != nil {
-		return x.SortedHand
+		return x.RetCode
 	}
-	return nil
+	return 0
 }
 
-type C2S_LeaveRoomReq struct {
+type S2C_LeaveRoomAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *C2S_LeaveRoomReq) Reset() {
-	*x = C2S_LeaveRoomReq{}
-	mi := &file_api_proto_game_proto_msgTypes[7]
+func (x *S2C_LeaveRoomAck) Reset() {
+	*x = S2C_LeaveRoomAck{}
+	mi := &file_api_proto_game_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *C2S_LeaveRoomReq) String() string {
+func (x *S2C_LeaveRoomAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*C2S_LeaveRoomReq) ProtoMessage() {}
+func (*S2C_LeaveRoomAck) ProtoMessage() {}
 
-func (x *C2S_LeaveRoomReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[7]
+func (x *S2C_LeaveRoomAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -859,35 +3224,41 @@ func (x *C2S_LeaveRoomReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use C2S_LeaveRoomReq.ProtoReflect.Descriptor instead.
-func (*C2S_LeaveRoomReq) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use S2C_LeaveRoomAck.ProtoReflect.Descriptor instead.
+func (*S2C_LeaveRoomAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{40}
 }
 
-// S2C 消息
-type S2C_JoinRoomAck struct {
+func (x *S2C_LeaveRoomAck) GetRetCode() int32 {
+	if x != nil {
+		return x.RetCode
+	}
+	return 0
+}
+
+// 房主操作的通用响应
+type S2C_RoomOwnerAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"` // 0 for success
-	RoomInfo      *RoomInfo              `protobuf:"bytes,2,opt,name=room_info,json=roomInfo,proto3" json:"room_info,omitempty"`
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *S2C_JoinRoomAck) Reset() {
-	*x = S2C_JoinRoomAck{}
-	mi := &file_api_proto_game_proto_msgTypes[8]
+func (x *S2C_RoomOwnerAck) Reset() {
+	*x = S2C_RoomOwnerAck{}
+	mi := &file_api_proto_game_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *S2C_JoinRoomAck) String() string {
+func (x *S2C_RoomOwnerAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*S2C_JoinRoomAck) ProtoMessage() {}
+func (*S2C_RoomOwnerAck) ProtoMessage() {}
 
-func (x *S2C_JoinRoomAck) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[8]
+func (x *S2C_RoomOwnerAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -898,49 +3269,41 @@ func (x *S2C_JoinRoomAck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use S2C_JoinRoomAck.ProtoReflect.Descriptor instead.
-func (*S2C_JoinRoomAck) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use S2C_RoomOwnerAck.ProtoReflect.Descriptor instead.
+func (*S2C_RoomOwnerAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *S2C_JoinRoomAck) GetRetCode() int32 {
+func (x *S2C_RoomOwnerAck) GetRetCode() int32 {
 	if x != nil {
 		return x.RetCode
 	}
 	return 0
 }
 
-func (x *S2C_JoinRoomAck) GetRoomInfo() *RoomInfo {
-	if x != nil {
-		return x.RoomInfo
-	}
-	return nil
-}
-
-// 新增: S2C_BidBankerAck 响应客户端的抢庄请求
-type S2C_BidBankerAck struct {
+// 解散投票操作的通用响应
+type S2C_DissolveAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"`    // 0 for success
-	PlayerId      int64                  `protobuf:"varint,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 成功抢到庄的玩家ID，如果ret_code非0则此字段无意义
+	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *S2C_BidBankerAck) Reset() {
-	*x = S2C_BidBankerAck{}
-	mi := &file_api_proto_game_proto_msgTypes[9]
+func (x *S2C_DissolveAck) Reset() {
+	*x = S2C_DissolveAck{}
+	mi := &file_api_proto_game_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *S2C_BidBankerAck) String() string {
+func (x *S2C_DissolveAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*S2C_BidBankerAck) ProtoMessage() {}
+func (*S2C_DissolveAck) ProtoMessage() {}
 
-func (x *S2C_BidBankerAck) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[9]
+func (x *S2C_DissolveAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -951,49 +3314,87 @@ func (x *S2C_BidBankerAck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use S2C_BidBankerAck.ProtoReflect.Descriptor instead.
-func (*S2C_BidBankerAck) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use S2C_DissolveAck.ProtoReflect.Descriptor instead.
+func (*S2C_DissolveAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *S2C_BidBankerAck) GetRetCode() int32 {
+func (x *S2C_DissolveAck) GetRetCode() int32 {
 	if x != nil {
 		return x.RetCode
 	}
 	return 0
 }
 
-func (x *S2C_BidBankerAck) GetPlayerId() int64 {
+// 解散投票进度变化时推送
+type S2C_DissolveVoteNtf struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vote          *DissolveVoteInfo      `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *S2C_DissolveVoteNtf) Reset() {
+	*x = S2C_DissolveVoteNtf{}
+	mi := &file_api_proto_game_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S2C_DissolveVoteNtf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_DissolveVoteNtf) ProtoMessage() {}
+
+func (x *S2C_DissolveVoteNtf) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[43]
 	if x != nil {
-		return x.PlayerId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-// 新增: S2C_PlaceBetAck 响应客户端的下注请求
-type S2C_PlaceBetAck struct {
+// Deprecated: Use S2C_DissolveVoteNtf.ProtoReflect.Descriptor instead.
+func (*S2C_DissolveVoteNtf) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *S2C_DissolveVoteNtf) GetVote() *DissolveVoteInfo {
+	if x != nil {
+		return x.Vote
+	}
+	return nil
+}
+
+// 解散投票结束时推送, 解散成功时附带各玩家最终分数, 随后房间关闭
+type S2C_DissolveResultNtf struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"` // 0 for success
-	Multiple      int32                  `protobuf:"varint,2,opt,name=multiple,proto3" json:"multiple,omitempty"`              // 成功下注的倍数
+	Dissolved     bool                   `protobuf:"varint,1,opt,name=dissolved,proto3" json:"dissolved,omitempty"`
+	Players       []*PlayerInfo          `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *S2C_PlaceBetAck) Reset() {
-	*x = S2C_PlaceBetAck{}
-	mi := &file_api_proto_game_proto_msgTypes[10]
+func (x *S2C_DissolveResultNtf) Reset() {
+	*x = S2C_DissolveResultNtf{}
+	mi := &file_api_proto_game_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *S2C_PlaceBetAck) String() string {
+func (x *S2C_DissolveResultNtf) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*S2C_PlaceBetAck) ProtoMessage() {}
+func (*S2C_DissolveResultNtf) ProtoMessage() {}
 
-func (x *S2C_PlaceBetAck) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[10]
+func (x *S2C_DissolveResultNtf) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1004,48 +3405,48 @@ func (x *S2C_PlaceBetAck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use S2C_PlaceBetAck.ProtoReflect.Descriptor instead.
-func (*S2C_PlaceBetAck) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use S2C_DissolveResultNtf.ProtoReflect.Descriptor instead.
+func (*S2C_DissolveResultNtf) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *S2C_PlaceBetAck) GetRetCode() int32 {
+func (x *S2C_DissolveResultNtf) GetDissolved() bool {
 	if x != nil {
-		return x.RetCode
+		return x.Dissolved
 	}
-	return 0
+	return false
 }
 
-func (x *S2C_PlaceBetAck) GetMultiple() int32 {
+func (x *S2C_DissolveResultNtf) GetPlayers() []*PlayerInfo {
 	if x != nil {
-		return x.Multiple
+		return x.Players
 	}
-	return 0
+	return nil
 }
 
-// 新增: S2C_ShowdownAck 响应客户端的摊牌请求
-type S2C_ShowdownAck struct {
+// 被房主踢出房间时推送
+type S2C_KickedNtf struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RetCode       int32                  `protobuf:"varint,1,opt,name=ret_code,json=retCode,proto3" json:"ret_code,omitempty"` // 0 for success
+	RoomId        int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
-
-func (x *S2C_ShowdownAck) Reset() {
-	*x = S2C_ShowdownAck{}
-	mi := &file_api_proto_game_proto_msgTypes[11]
+
+func (x *S2C_KickedNtf) Reset() {
+	*x = S2C_KickedNtf{}
+	mi := &file_api_proto_game_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *S2C_ShowdownAck) String() string {
+func (x *S2C_KickedNtf) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*S2C_ShowdownAck) ProtoMessage() {}
+func (*S2C_KickedNtf) ProtoMessage() {}
 
-func (x *S2C_ShowdownAck) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[11]
+func (x *S2C_KickedNtf) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1056,43 +3457,43 @@ func (x *S2C_ShowdownAck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use S2C_ShowdownAck.ProtoReflect.Descriptor instead.
-func (*S2C_ShowdownAck) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use S2C_KickedNtf.ProtoReflect.Descriptor instead.
+func (*S2C_KickedNtf) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *S2C_ShowdownAck) GetRetCode() int32 {
+func (x *S2C_KickedNtf) GetRoomId() int32 {
 	if x != nil {
-		return x.RetCode
+		return x.RoomId
 	}
 	return 0
 }
 
-type RoomInfo struct {
+// 排队位置变化时推送
+type S2C_MatchQueueNtf struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	Players       []*PlayerInfo          `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
-	GameState     GameState              `protobuf:"varint,3,opt,name=game_state,json=gameState,proto3,enum=game.GameState" json:"game_state,omitempty"`
-	BankerId      int64                  `protobuf:"varint,4,opt,name=banker_id,json=bankerId,proto3" json:"banker_id,omitempty"`
+	TierId        int32                  `protobuf:"varint,1,opt,name=tier_id,json=tierId,proto3" json:"tier_id,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	WaitSeconds   int32                  `protobuf:"varint,3,opt,name=wait_seconds,json=waitSeconds,proto3" json:"wait_seconds,omitempty"` // 已等待秒数
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoomInfo) Reset() {
-	*x = RoomInfo{}
-	mi := &file_api_proto_game_proto_msgTypes[12]
+func (x *S2C_MatchQueueNtf) Reset() {
+	*x = S2C_MatchQueueNtf{}
+	mi := &file_api_proto_game_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoomInfo) String() string {
+func (x *S2C_MatchQueueNtf) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomInfo) ProtoMessage() {}
+func (*S2C_MatchQueueNtf) ProtoMessage() {}
 
-func (x *RoomInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[12]
+func (x *S2C_MatchQueueNtf) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1103,37 +3504,75 @@ func (x *RoomInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomInfo.ProtoReflect.Descriptor instead.
-func (*RoomInfo) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use S2C_MatchQueueNtf.ProtoReflect.Descriptor instead.
+func (*S2C_MatchQueueNtf) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *RoomInfo) GetRoomId() int32 {
+func (x *S2C_MatchQueueNtf) GetTierId() int32 {
 	if x != nil {
-		return x.RoomId
+		return x.TierId
 	}
 	return 0
 }
 
-func (x *RoomInfo) GetPlayers() []*PlayerInfo {
+func (x *S2C_MatchQueueNtf) GetPosition() int32 {
 	if x != nil {
-		return x.Players
+		return x.Position
 	}
-	return nil
+	return 0
 }
 
-func (x *RoomInfo) GetGameState() GameState {
+func (x *S2C_MatchQueueNtf) GetWaitSeconds() int32 {
 	if x != nil {
-		return x.GameState
+		return x.WaitSeconds
 	}
-	return GameState_STATE_UNKNOWN
+	return 0
 }
 
-func (x *RoomInfo) GetBankerId() int64 {
+// 匹配成功, 玩家已加入房间
+type S2C_MatchFoundNtf struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomInfo      *RoomInfo              `protobuf:"bytes,1,opt,name=room_info,json=roomInfo,proto3" json:"room_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *S2C_MatchFoundNtf) Reset() {
+	*x = S2C_MatchFoundNtf{}
+	mi := &file_api_proto_game_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S2C_MatchFoundNtf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_MatchFoundNtf) ProtoMessage() {}
+
+func (x *S2C_MatchFoundNtf) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[47]
 	if x != nil {
-		return x.BankerId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_MatchFoundNtf.ProtoReflect.Descriptor instead.
+func (*S2C_MatchFoundNtf) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *S2C_MatchFoundNtf) GetRoomInfo() *RoomInfo {
+	if x != nil {
+		return x.RoomInfo
+	}
+	return nil
 }
 
 type S2C_SyncRoomStateNtf struct {
@@ -1145,7 +3584,7 @@ type S2C_SyncRoomStateNtf struct {
 
 func (x *S2C_SyncRoomStateNtf) Reset() {
 	*x = S2C_SyncRoomStateNtf{}
-	mi := &file_api_proto_game_proto_msgTypes[13]
+	mi := &file_api_proto_game_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1157,7 +3596,7 @@ func (x *S2C_SyncRoomStateNtf) String() string {
 func (*S2C_SyncRoomStateNtf) ProtoMessage() {}
 
 func (x *S2C_SyncRoomStateNtf) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[13]
+	mi := &file_api_proto_game_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1170,7 +3609,7 @@ func (x *S2C_SyncRoomStateNtf) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S2C_SyncRoomStateNtf.ProtoReflect.Descriptor instead.
 func (*S2C_SyncRoomStateNtf) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{13}
+	return file_api_proto_game_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *S2C_SyncRoomStateNtf) GetRoomInfo() *RoomInfo {
@@ -1189,7 +3628,7 @@ type S2C_GameStartNtf struct {
 
 func (x *S2C_GameStartNtf) Reset() {
 	*x = S2C_GameStartNtf{}
-	mi := &file_api_proto_game_proto_msgTypes[14]
+	mi := &file_api_proto_game_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1201,7 +3640,7 @@ func (x *S2C_GameStartNtf) String() string {
 func (*S2C_GameStartNtf) ProtoMessage() {}
 
 func (x *S2C_GameStartNtf) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[14]
+	mi := &file_api_proto_game_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1214,7 +3653,7 @@ func (x *S2C_GameStartNtf) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S2C_GameStartNtf.ProtoReflect.Descriptor instead.
 func (*S2C_GameStartNtf) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{14}
+	return file_api_proto_game_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *S2C_GameStartNtf) GetBankerId() int64 {
@@ -1233,7 +3672,7 @@ type S2C_DealCardsNtf struct {
 
 func (x *S2C_DealCardsNtf) Reset() {
 	*x = S2C_DealCardsNtf{}
-	mi := &file_api_proto_game_proto_msgTypes[15]
+	mi := &file_api_proto_game_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1245,7 +3684,7 @@ func (x *S2C_DealCardsNtf) String() string {
 func (*S2C_DealCardsNtf) ProtoMessage() {}
 
 func (x *S2C_DealCardsNtf) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[15]
+	mi := &file_api_proto_game_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1258,7 +3697,7 @@ func (x *S2C_DealCardsNtf) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S2C_DealCardsNtf.ProtoReflect.Descriptor instead.
 func (*S2C_DealCardsNtf) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{15}
+	return file_api_proto_game_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *S2C_DealCardsNtf) GetHand() []*Card {
@@ -1270,14 +3709,15 @@ func (x *S2C_DealCardsNtf) GetHand() []*Card {
 
 type S2C_BidBankerNtf struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Countdown     int32                  `protobuf:"varint,1,opt,name=countdown,proto3" json:"countdown,omitempty"` // 倒计时
+	Countdown     int32                  `protobuf:"varint,1,opt,name=countdown,proto3" json:"countdown,omitempty"`        // 倒计时
+	Multiples     []int32                `protobuf:"varint,2,rep,packed,name=multiples,proto3" json:"multiples,omitempty"` // 该玩家可选的抢庄倍数, 已按余额过滤
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *S2C_BidBankerNtf) Reset() {
 	*x = S2C_BidBankerNtf{}
-	mi := &file_api_proto_game_proto_msgTypes[16]
+	mi := &file_api_proto_game_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1289,7 +3729,7 @@ func (x *S2C_BidBankerNtf) String() string {
 func (*S2C_BidBankerNtf) ProtoMessage() {}
 
 func (x *S2C_BidBankerNtf) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[16]
+	mi := &file_api_proto_game_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1302,7 +3742,7 @@ func (x *S2C_BidBankerNtf) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S2C_BidBankerNtf.ProtoReflect.Descriptor instead.
 func (*S2C_BidBankerNtf) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{16}
+	return file_api_proto_game_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *S2C_BidBankerNtf) GetCountdown() int32 {
@@ -1312,17 +3752,26 @@ func (x *S2C_BidBankerNtf) GetCountdown() int32 {
 	return 0
 }
 
+func (x *S2C_BidBankerNtf) GetMultiples() []int32 {
+	if x != nil {
+		return x.Multiples
+	}
+	return nil
+}
+
 type S2C_BetNtf struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BankerId      int64                  `protobuf:"varint,1,opt,name=banker_id,json=bankerId,proto3" json:"banker_id,omitempty"`
-	Countdown     int32                  `protobuf:"varint,2,opt,name=countdown,proto3" json:"countdown,omitempty"` // 倒计时
+	Countdown     int32                  `protobuf:"varint,2,opt,name=countdown,proto3" json:"countdown,omitempty"`                           // 倒计时
+	Multiples     []int32                `protobuf:"varint,3,rep,packed,name=multiples,proto3" json:"multiples,omitempty"`                    // 该玩家可选的下注倍数, 已按余额过滤, 庄家为空
+	PushMultiple  int32                  `protobuf:"varint,4,opt,name=push_multiple,json=pushMultiple,proto3" json:"push_multiple,omitempty"` // 该玩家本局可推注的倍数, 包含在 multiples 中, 0表示不能推注
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *S2C_BetNtf) Reset() {
 	*x = S2C_BetNtf{}
-	mi := &file_api_proto_game_proto_msgTypes[17]
+	mi := &file_api_proto_game_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1334,7 +3783,7 @@ func (x *S2C_BetNtf) String() string {
 func (*S2C_BetNtf) ProtoMessage() {}
 
 func (x *S2C_BetNtf) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[17]
+	mi := &file_api_proto_game_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1347,7 +3796,7 @@ func (x *S2C_BetNtf) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S2C_BetNtf.ProtoReflect.Descriptor instead.
 func (*S2C_BetNtf) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{17}
+	return file_api_proto_game_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *S2C_BetNtf) GetBankerId() int64 {
@@ -1364,6 +3813,20 @@ func (x *S2C_BetNtf) GetCountdown() int32 {
 	return 0
 }
 
+func (x *S2C_BetNtf) GetMultiples() []int32 {
+	if x != nil {
+		return x.Multiples
+	}
+	return nil
+}
+
+func (x *S2C_BetNtf) GetPushMultiple() int32 {
+	if x != nil {
+		return x.PushMultiple
+	}
+	return 0
+}
+
 type S2C_ShowdownNtf struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Countdown     int32                  `protobuf:"varint,1,opt,name=countdown,proto3" json:"countdown,omitempty"` // 倒计时
@@ -1373,7 +3836,7 @@ type S2C_ShowdownNtf struct {
 
 func (x *S2C_ShowdownNtf) Reset() {
 	*x = S2C_ShowdownNtf{}
-	mi := &file_api_proto_game_proto_msgTypes[18]
+	mi := &file_api_proto_game_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1385,7 +3848,7 @@ func (x *S2C_ShowdownNtf) String() string {
 func (*S2C_ShowdownNtf) ProtoMessage() {}
 
 func (x *S2C_ShowdownNtf) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[18]
+	mi := &file_api_proto_game_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1398,7 +3861,7 @@ func (x *S2C_ShowdownNtf) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S2C_ShowdownNtf.ProtoReflect.Descriptor instead.
 func (*S2C_ShowdownNtf) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{18}
+	return file_api_proto_game_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *S2C_ShowdownNtf) GetCountdown() int32 {
@@ -1413,15 +3876,16 @@ type PlayerResult struct {
 	PlayerId      int64                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
 	Hand          []*Card                `protobuf:"bytes,2,rep,name=hand,proto3" json:"hand,omitempty"`
 	CardPattern   CardPattern            `protobuf:"varint,3,opt,name=card_pattern,json=cardPattern,proto3,enum=game.CardPattern" json:"card_pattern,omitempty"`
-	ScoreChange   int64                  `protobuf:"varint,4,opt,name=score_change,json=scoreChange,proto3" json:"score_change,omitempty"`
+	ScoreChange   int64                  `protobuf:"varint,4,opt,name=score_change,json=scoreChange,proto3" json:"score_change,omitempty"` // 已扣除抽水
 	FinalScore    int64                  `protobuf:"varint,5,opt,name=final_score,json=finalScore,proto3" json:"final_score,omitempty"`
+	Rake          int64                  `protobuf:"varint,6,opt,name=rake,proto3" json:"rake,omitempty"` // 本局交给平台的抽水
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PlayerResult) Reset() {
 	*x = PlayerResult{}
-	mi := &file_api_proto_game_proto_msgTypes[19]
+	mi := &file_api_proto_game_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1433,7 +3897,7 @@ func (x *PlayerResult) String() string {
 func (*PlayerResult) ProtoMessage() {}
 
 func (x *PlayerResult) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[19]
+	mi := &file_api_proto_game_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1446,7 +3910,7 @@ func (x *PlayerResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerResult.ProtoReflect.Descriptor instead.
 func (*PlayerResult) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{19}
+	return file_api_proto_game_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *PlayerResult) GetPlayerId() int64 {
@@ -1484,16 +3948,26 @@ func (x *PlayerResult) GetFinalScore() int64 {
 	return 0
 }
 
+func (x *PlayerResult) GetRake() int64 {
+	if x != nil {
+		return x.Rake
+	}
+	return 0
+}
+
 type S2C_GameResultNtf struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Results       []*PlayerResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Round         int32                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`                                // 本场对局的第几局
+	TotalRounds   int32                  `protobuf:"varint,3,opt,name=total_rounds,json=totalRounds,proto3" json:"total_rounds,omitempty"` // 0表示不限局数
+	BankerId      int64                  `protobuf:"varint,4,opt,name=banker_id,json=bankerId,proto3" json:"banker_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *S2C_GameResultNtf) Reset() {
 	*x = S2C_GameResultNtf{}
-	mi := &file_api_proto_game_proto_msgTypes[20]
+	mi := &file_api_proto_game_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1505,7 +3979,7 @@ func (x *S2C_GameResultNtf) String() string {
 func (*S2C_GameResultNtf) ProtoMessage() {}
 
 func (x *S2C_GameResultNtf) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[20]
+	mi := &file_api_proto_game_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1518,7 +3992,7 @@ func (x *S2C_GameResultNtf) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S2C_GameResultNtf.ProtoReflect.Descriptor instead.
 func (*S2C_GameResultNtf) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{20}
+	return file_api_proto_game_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *S2C_GameResultNtf) GetResults() []*PlayerResult {
@@ -1528,6 +4002,194 @@ func (x *S2C_GameResultNtf) GetResults() []*PlayerResult {
 	return nil
 }
 
+func (x *S2C_GameResultNtf) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *S2C_GameResultNtf) GetTotalRounds() int32 {
+	if x != nil {
+		return x.TotalRounds
+	}
+	return 0
+}
+
+func (x *S2C_GameResultNtf) GetBankerId() int64 {
+	if x != nil {
+		return x.BankerId
+	}
+	return 0
+}
+
+// 本场对局结束时推送, 随后房间关闭
+type S2C_SessionSummaryNtf struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Session       *SessionInfo           `protobuf:"bytes,2,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *S2C_SessionSummaryNtf) Reset() {
+	*x = S2C_SessionSummaryNtf{}
+	mi := &file_api_proto_game_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S2C_SessionSummaryNtf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_SessionSummaryNtf) ProtoMessage() {}
+
+func (x *S2C_SessionSummaryNtf) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_SessionSummaryNtf.ProtoReflect.Descriptor instead.
+func (*S2C_SessionSummaryNtf) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *S2C_SessionSummaryNtf) GetRoomId() int32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *S2C_SessionSummaryNtf) GetSession() *SessionInfo {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// 进入或结束维护时推送给所有连接, 维护期间进行中的牌局可以打完, 不再开始新的一局
+type S2C_MaintenanceNtf struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ShutdownAt    int64                  `protobuf:"varint,2,opt,name=shutdown_at,json=shutdownAt,proto3" json:"shutdown_at,omitempty"` // 停服时间, Unix 时间戳(秒), 0 表示不停服
+	Active        bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`                           // false 表示维护已结束, 可以继续开局
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *S2C_MaintenanceNtf) Reset() {
+	*x = S2C_MaintenanceNtf{}
+	mi := &file_api_proto_game_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S2C_MaintenanceNtf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_MaintenanceNtf) ProtoMessage() {}
+
+func (x *S2C_MaintenanceNtf) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_MaintenanceNtf.ProtoReflect.Descriptor instead.
+func (*S2C_MaintenanceNtf) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *S2C_MaintenanceNtf) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *S2C_MaintenanceNtf) GetShutdownAt() int64 {
+	if x != nil {
+		return x.ShutdownAt
+	}
+	return 0
+}
+
+func (x *S2C_MaintenanceNtf) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// 系统公告, 推送给所有连接
+type S2C_AnnouncementNtf struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	SentAt        int64                  `protobuf:"varint,2,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"` // Unix 时间戳(秒)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *S2C_AnnouncementNtf) Reset() {
+	*x = S2C_AnnouncementNtf{}
+	mi := &file_api_proto_game_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *S2C_AnnouncementNtf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_AnnouncementNtf) ProtoMessage() {}
+
+func (x *S2C_AnnouncementNtf) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_game_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_AnnouncementNtf.ProtoReflect.Descriptor instead.
+func (*S2C_AnnouncementNtf) Descriptor() ([]byte, []int) {
+	return file_api_proto_game_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *S2C_AnnouncementNtf) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *S2C_AnnouncementNtf) GetSentAt() int64 {
+	if x != nil {
+		return x.SentAt
+	}
+	return 0
+}
+
 type S2C_PlayerLeaveNtf struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      int64                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
@@ -1537,7 +4199,7 @@ type S2C_PlayerLeaveNtf struct {
 
 func (x *S2C_PlayerLeaveNtf) Reset() {
 	*x = S2C_PlayerLeaveNtf{}
-	mi := &file_api_proto_game_proto_msgTypes[21]
+	mi := &file_api_proto_game_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1549,7 +4211,7 @@ func (x *S2C_PlayerLeaveNtf) String() string {
 func (*S2C_PlayerLeaveNtf) ProtoMessage() {}
 
 func (x *S2C_PlayerLeaveNtf) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_game_proto_msgTypes[21]
+	mi := &file_api_proto_game_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1562,7 +4224,7 @@ func (x *S2C_PlayerLeaveNtf) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S2C_PlayerLeaveNtf.ProtoReflect.Descriptor instead.
 func (*S2C_PlayerLeaveNtf) Descriptor() ([]byte, []int) {
-	return file_api_proto_game_proto_rawDescGZIP(), []int{21}
+	return file_api_proto_game_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *S2C_PlayerLeaveNtf) GetPlayerId() int64 {
@@ -1581,7 +4243,7 @@ const file_api_proto_game_proto_rawDesc = "" +
 	"\x04suit\x18\x01 \x01(\x0e2\n" +
 	".game.SuitR\x04suit\x12\x1e\n" +
 	"\x04rank\x18\x02 \x01(\x0e2\n" +
-	".game.RankR\x04rank\"\xfa\x01\n" +
+	".game.RankR\x04rank\"\x8e\x02\n" +
 	"\n" +
 	"PlayerInfo\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\x03R\bplayerId\x12\x1a\n" +
@@ -1591,9 +4253,31 @@ const file_api_proto_game_proto_rawDesc = "" +
 	"\tis_banker\x18\x05 \x01(\bR\bisBanker\x12\x1e\n" +
 	"\x04hand\x18\x06 \x03(\v2\n" +
 	".game.CardR\x04hand\x124\n" +
-	"\fcard_pattern\x18\a \x01(\x0e2\x11.game.CardPatternR\vcardPattern\"*\n" +
+	"\fcard_pattern\x18\a \x01(\x0e2\x11.game.CardPatternR\vcardPattern\x12\x12\n" +
+	"\x04seat\x18\b \x01(\x05R\x04seat\"\x81\x02\n" +
+	"\vProfileInfo\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x03R\bplayerId\x12\x1a\n" +
+	"\bnickname\x18\x02 \x01(\tR\bnickname\x12\x18\n" +
+	"\abalance\x18\x03 \x01(\x03R\abalance\x12#\n" +
+	"\rrounds_played\x18\x04 \x01(\x05R\froundsPlayed\x12\x1d\n" +
+	"\n" +
+	"rounds_won\x18\x05 \x01(\x05R\troundsWon\x12\x1b\n" +
+	"\ttotal_won\x18\x06 \x01(\x03R\btotalWon\x12\x1d\n" +
+	"\n" +
+	"total_lost\x18\a \x01(\x03R\ttotalLost\x12\x1f\n" +
+	"\vbiggest_win\x18\b \x01(\x03R\n" +
+	"biggestWin\"]\n" +
+	"\fC2S_LoginReq\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x03R\bplayerId\x12\x1a\n" +
+	"\bnickname\x18\x02 \x01(\tR\bnickname\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"\x9e\x01\n" +
 	"\x0fC2S_JoinRoomReq\x12\x17\n" +
-	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\"/\n" +
+	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\x12\x1f\n" +
+	"\vinvite_code\x18\x02 \x01(\tR\n" +
+	"inviteCode\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12!\n" +
+	"\fas_spectator\x18\x04 \x01(\bR\vasSpectator\x12\x12\n" +
+	"\x04seat\x18\x05 \x01(\x05R\x04seat\"/\n" +
 	"\x12C2S_PlayerReadyReq\x12\x19\n" +
 	"\bis_ready\x18\x01 \x01(\bR\aisReady\".\n" +
 	"\x10C2S_BidBankerReq\x12\x1a\n" +
@@ -1604,7 +4288,62 @@ const file_api_proto_game_proto_rawDesc = "" +
 	"\vsorted_hand\x18\x01 \x03(\v2\n" +
 	".game.CardR\n" +
 	"sortedHand\"\x12\n" +
-	"\x10C2S_LeaveRoomReq\"Y\n" +
+	"\x10C2S_LeaveRoomReq\"\x9a\x04\n" +
+	"\fRoomSettings\x12\x19\n" +
+	"\bbase_bet\x18\x01 \x01(\x03R\abaseBet\x12\x1f\n" +
+	"\vmax_players\x18\x02 \x01(\x05R\n" +
+	"maxPlayers\x12(\n" +
+	"\brule_set\x18\x03 \x01(\x0e2\r.game.RuleSetR\aruleSet\x12\x16\n" +
+	"\x06rounds\x18\x04 \x01(\x05R\x06rounds\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\x05 \x01(\bR\tisPrivate\x12%\n" +
+	"\x0emax_spectators\x18\x06 \x01(\x05R\rmaxSpectators\x12\x1f\n" +
+	"\vmin_players\x18\a \x01(\x05R\n" +
+	"minPlayers\x127\n" +
+	"\rdissolve_rule\x18\b \x01(\x0e2\x12.game.DissolveRuleR\fdissolveRule\x127\n" +
+	"\rpayout_policy\x18\t \x01(\x0e2\x12.game.PayoutPolicyR\fpayoutPolicy\x12\"\n" +
+	"\x04rake\x18\n" +
+	" \x01(\v2\x0e.game.RakeInfoR\x04rake\x12#\n" +
+	"\rbid_multiples\x18\v \x03(\x05R\fbidMultiples\x12#\n" +
+	"\rbet_multiples\x18\f \x03(\x05R\fbetMultiples\x12 \n" +
+	"\fpush_bet_cap\x18\r \x01(\x05R\n" +
+	"pushBetCap\x12#\n" +
+	"\rpush_cooldown\x18\x0e \x01(\x05R\fpushCooldown\"l\n" +
+	"\bRakeInfo\x12\"\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x0e.game.RakeModeR\x04mode\x12\x18\n" +
+	"\apercent\x18\x02 \x01(\x03R\apercent\x12\x10\n" +
+	"\x03cap\x18\x03 \x01(\x03R\x03cap\x12\x10\n" +
+	"\x03fee\x18\x04 \x01(\x03R\x03fee\"\xa6\x01\n" +
+	"\x0fC2S_RoomListReq\x12 \n" +
+	"\fmin_base_bet\x18\x01 \x01(\x03R\n" +
+	"minBaseBet\x12 \n" +
+	"\fmax_base_bet\x18\x02 \x01(\x03R\n" +
+	"maxBaseBet\x12(\n" +
+	"\brule_set\x18\x03 \x01(\x0e2\r.game.RuleSetR\aruleSet\x12%\n" +
+	"\x0eonly_available\x18\x04 \x01(\bR\ronlyAvailable\"_\n" +
+	"\x11C2S_CreateRoomReq\x12.\n" +
+	"\bsettings\x18\x01 \x01(\v2\x12.game.RoomSettingsR\bsettings\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\",\n" +
+	"\x11C2S_QuickMatchReq\x12\x17\n" +
+	"\atier_id\x18\x01 \x01(\x05R\x06tierId\"\x14\n" +
+	"\x12C2S_CancelMatchReq\"%\n" +
+	"\x0fC2S_TakeSeatReq\x12\x12\n" +
+	"\x04seat\x18\x01 \x01(\x05R\x04seat\"0\n" +
+	"\x11C2S_KickPlayerReq\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x03R\bplayerId\"=\n" +
+	"\x0fC2S_LockSeatReq\x12\x12\n" +
+	"\x04seat\x18\x01 \x01(\x05R\x04seat\x12\x16\n" +
+	"\x06locked\x18\x02 \x01(\bR\x06locked\"\x13\n" +
+	"\x11C2S_ForceStartReq\"3\n" +
+	"\x14C2S_TransferOwnerReq\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x03R\bplayerId\"\x18\n" +
+	"\x16C2S_DissolveProposeReq\"+\n" +
+	"\x13C2S_DissolveVoteReq\x12\x14\n" +
+	"\x05agree\x18\x01 \x01(\bR\x05agree\"p\n" +
+	"\fS2C_LoginAck\x12\x19\n" +
+	"\bret_code\x18\x01 \x01(\x05R\aretCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\aprofile\x18\x03 \x01(\v2\x11.game.ProfileInfoR\aprofile\"Y\n" +
 	"\x0fS2C_JoinRoomAck\x12\x19\n" +
 	"\bret_code\x18\x01 \x01(\x05R\aretCode\x12+\n" +
 	"\troom_info\x18\x02 \x01(\v2\x0e.game.RoomInfoR\broomInfo\"J\n" +
@@ -1615,28 +4354,113 @@ const file_api_proto_game_proto_rawDesc = "" +
 	"\bret_code\x18\x01 \x01(\x05R\aretCode\x12\x1a\n" +
 	"\bmultiple\x18\x02 \x01(\x05R\bmultiple\",\n" +
 	"\x0fS2C_ShowdownAck\x12\x19\n" +
-	"\bret_code\x18\x01 \x01(\x05R\aretCode\"\x9c\x01\n" +
+	"\bret_code\x18\x01 \x01(\x05R\aretCode\"\xbe\x03\n" +
 	"\bRoomInfo\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\x12*\n" +
 	"\aplayers\x18\x02 \x03(\v2\x10.game.PlayerInfoR\aplayers\x12.\n" +
 	"\n" +
 	"game_state\x18\x03 \x01(\x0e2\x0f.game.GameStateR\tgameState\x12\x1b\n" +
-	"\tbanker_id\x18\x04 \x01(\x03R\bbankerId\"C\n" +
+	"\tbanker_id\x18\x04 \x01(\x03R\bbankerId\x12.\n" +
+	"\bsettings\x18\x05 \x01(\v2\x12.game.RoomSettingsR\bsettings\x12\x1f\n" +
+	"\vinvite_code\x18\x06 \x01(\tR\n" +
+	"inviteCode\x12'\n" +
+	"\x0fspectator_count\x18\a \x01(\x05R\x0espectatorCount\x12\x19\n" +
+	"\bowner_id\x18\b \x01(\x03R\aownerId\x12!\n" +
+	"\flocked_seats\x18\t \x03(\x05R\vlockedSeats\x12;\n" +
+	"\rdissolve_vote\x18\n" +
+	" \x01(\v2\x16.game.DissolveVoteInfoR\fdissolveVote\x12+\n" +
+	"\asession\x18\v \x01(\v2\x11.game.SessionInfoR\asession\"M\n" +
+	"\vScoreChange\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x03R\bplayerId\x12!\n" +
+	"\fscore_change\x18\x02 \x01(\x03R\vscoreChange\"l\n" +
+	"\n" +
+	"RoundScore\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x12\x1b\n" +
+	"\tbanker_id\x18\x02 \x01(\x03R\bbankerId\x12+\n" +
+	"\achanges\x18\x03 \x03(\v2\x11.game.ScoreChangeR\achanges\"\xfc\x01\n" +
+	"\fSessionScore\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x03R\bplayerId\x12\x1a\n" +
+	"\bnickname\x18\x02 \x01(\tR\bnickname\x12#\n" +
+	"\rrounds_played\x18\x03 \x01(\x05R\froundsPlayed\x12\x12\n" +
+	"\x04wins\x18\x04 \x01(\x05R\x04wins\x12\x1b\n" +
+	"\tnet_score\x18\x05 \x01(\x03R\bnetScore\x12'\n" +
+	"\tbest_hand\x18\x06 \x03(\v2\n" +
+	".game.CardR\bbestHand\x124\n" +
+	"\fbest_pattern\x18\a \x01(\x0e2\x11.game.CardPatternR\vbestPattern\"\xab\x01\n" +
+	"\vSessionInfo\x12#\n" +
+	"\rrounds_played\x18\x01 \x01(\x05R\froundsPlayed\x12!\n" +
+	"\ftotal_rounds\x18\x02 \x01(\x05R\vtotalRounds\x12(\n" +
+	"\x06rounds\x18\x03 \x03(\v2\x10.game.RoundScoreR\x06rounds\x12*\n" +
+	"\x06scores\x18\x04 \x03(\v2\x12.game.SessionScoreR\x06scores\"\xc3\x01\n" +
+	"\x10DissolveVoteInfo\x12\x1f\n" +
+	"\vproposer_id\x18\x01 \x01(\x03R\n" +
+	"proposerId\x12\x1a\n" +
+	"\bdeadline\x18\x02 \x01(\x03R\bdeadline\x12\x16\n" +
+	"\x06agreed\x18\x03 \x03(\x03R\x06agreed\x12\x18\n" +
+	"\arefused\x18\x04 \x03(\x03R\arefused\x12\x18\n" +
+	"\apending\x18\x05 \x03(\x03R\apending\x12&\n" +
+	"\x04rule\x18\x06 \x01(\x0e2\x12.game.DissolveRuleR\x04rule\"\xf7\x01\n" +
+	"\vRoomSummary\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\x12!\n" +
+	"\fplayer_count\x18\x02 \x01(\x05R\vplayerCount\x12\x1f\n" +
+	"\vmax_players\x18\x03 \x01(\x05R\n" +
+	"maxPlayers\x12\x19\n" +
+	"\bbase_bet\x18\x04 \x01(\x03R\abaseBet\x12(\n" +
+	"\brule_set\x18\x05 \x01(\x0e2\r.game.RuleSetR\aruleSet\x12\x16\n" +
+	"\x06rounds\x18\x06 \x01(\x05R\x06rounds\x12.\n" +
+	"\n" +
+	"game_state\x18\a \x01(\x0e2\x0f.game.GameStateR\tgameState\"U\n" +
+	"\x0fS2C_RoomListAck\x12\x19\n" +
+	"\bret_code\x18\x01 \x01(\x05R\aretCode\x12'\n" +
+	"\x05rooms\x18\x02 \x03(\v2\x11.game.RoomSummaryR\x05rooms\"[\n" +
+	"\x11S2C_CreateRoomAck\x12\x19\n" +
+	"\bret_code\x18\x01 \x01(\x05R\aretCode\x12+\n" +
+	"\troom_info\x18\x02 \x01(\v2\x0e.game.RoomInfoR\broomInfo\"c\n" +
+	"\x11S2C_QuickMatchAck\x12\x19\n" +
+	"\bret_code\x18\x01 \x01(\x05R\aretCode\x12\x17\n" +
+	"\atier_id\x18\x02 \x01(\x05R\x06tierId\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\x05R\bposition\"/\n" +
+	"\x12S2C_CancelMatchAck\x12\x19\n" +
+	"\bret_code\x18\x01 \x01(\x05R\aretCode\",\n" +
+	"\x0fS2C_TakeSeatAck\x12\x19\n" +
+	"\bret_code\x18\x01 \x01(\x05R\aretCode\"-\n" +
+	"\x10S2C_LeaveRoomAck\x12\x19\n" +
+	"\bret_code\x18\x01 \x01(\x05R\aretCode\"-\n" +
+	"\x10S2C_RoomOwnerAck\x12\x19\n" +
+	"\bret_code\x18\x01 \x01(\x05R\aretCode\",\n" +
+	"\x0fS2C_DissolveAck\x12\x19\n" +
+	"\bret_code\x18\x01 \x01(\x05R\aretCode\"A\n" +
+	"\x13S2C_DissolveVoteNtf\x12*\n" +
+	"\x04vote\x18\x01 \x01(\v2\x16.game.DissolveVoteInfoR\x04vote\"a\n" +
+	"\x15S2C_DissolveResultNtf\x12\x1c\n" +
+	"\tdissolved\x18\x01 \x01(\bR\tdissolved\x12*\n" +
+	"\aplayers\x18\x02 \x03(\v2\x10.game.PlayerInfoR\aplayers\"(\n" +
+	"\rS2C_KickedNtf\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\"k\n" +
+	"\x11S2C_MatchQueueNtf\x12\x17\n" +
+	"\atier_id\x18\x01 \x01(\x05R\x06tierId\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12!\n" +
+	"\fwait_seconds\x18\x03 \x01(\x05R\vwaitSeconds\"@\n" +
+	"\x11S2C_MatchFoundNtf\x12+\n" +
+	"\troom_info\x18\x01 \x01(\v2\x0e.game.RoomInfoR\broomInfo\"C\n" +
 	"\x14S2C_SyncRoomStateNtf\x12+\n" +
 	"\troom_info\x18\x01 \x01(\v2\x0e.game.RoomInfoR\broomInfo\"/\n" +
 	"\x10S2C_GameStartNtf\x12\x1b\n" +
 	"\tbanker_id\x18\x01 \x01(\x03R\bbankerId\"2\n" +
 	"\x10S2C_DealCardsNtf\x12\x1e\n" +
 	"\x04hand\x18\x01 \x03(\v2\n" +
-	".game.CardR\x04hand\"0\n" +
+	".game.CardR\x04hand\"N\n" +
 	"\x10S2C_BidBankerNtf\x12\x1c\n" +
-	"\tcountdown\x18\x01 \x01(\x05R\tcountdown\"G\n" +
+	"\tcountdown\x18\x01 \x01(\x05R\tcountdown\x12\x1c\n" +
+	"\tmultiples\x18\x02 \x03(\x05R\tmultiples\"\x8a\x01\n" +
 	"\n" +
 	"S2C_BetNtf\x12\x1b\n" +
 	"\tbanker_id\x18\x01 \x01(\x03R\bbankerId\x12\x1c\n" +
-	"\tcountdown\x18\x02 \x01(\x05R\tcountdown\"/\n" +
+	"\tcountdown\x18\x02 \x01(\x05R\tcountdown\x12\x1c\n" +
+	"\tmultiples\x18\x03 \x03(\x05R\tmultiples\x12#\n" +
+	"\rpush_multiple\x18\x04 \x01(\x05R\fpushMultiple\"/\n" +
 	"\x0fS2C_ShowdownNtf\x12\x1c\n" +
-	"\tcountdown\x18\x01 \x01(\x05R\tcountdown\"\xc5\x01\n" +
+	"\tcountdown\x18\x01 \x01(\x05R\tcountdown\"\xd9\x01\n" +
 	"\fPlayerResult\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\x03R\bplayerId\x12\x1e\n" +
 	"\x04hand\x18\x02 \x03(\v2\n" +
@@ -1644,11 +4468,27 @@ const file_api_proto_game_proto_rawDesc = "" +
 	"\fcard_pattern\x18\x03 \x01(\x0e2\x11.game.CardPatternR\vcardPattern\x12!\n" +
 	"\fscore_change\x18\x04 \x01(\x03R\vscoreChange\x12\x1f\n" +
 	"\vfinal_score\x18\x05 \x01(\x03R\n" +
-	"finalScore\"A\n" +
+	"finalScore\x12\x12\n" +
+	"\x04rake\x18\x06 \x01(\x03R\x04rake\"\x97\x01\n" +
 	"\x11S2C_GameResultNtf\x12,\n" +
-	"\aresults\x18\x01 \x03(\v2\x12.game.PlayerResultR\aresults\"1\n" +
+	"\aresults\x18\x01 \x03(\v2\x12.game.PlayerResultR\aresults\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x05R\x05round\x12!\n" +
+	"\ftotal_rounds\x18\x03 \x01(\x05R\vtotalRounds\x12\x1b\n" +
+	"\tbanker_id\x18\x04 \x01(\x03R\bbankerId\"]\n" +
+	"\x15S2C_SessionSummaryNtf\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\x12+\n" +
+	"\asession\x18\x02 \x01(\v2\x11.game.SessionInfoR\asession\"g\n" +
+	"\x12S2C_MaintenanceNtf\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1f\n" +
+	"\vshutdown_at\x18\x02 \x01(\x03R\n" +
+	"shutdownAt\x12\x16\n" +
+	"\x06active\x18\x03 \x01(\bR\x06active\"H\n" +
+	"\x13S2C_AnnouncementNtf\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x17\n" +
+	"\asent_at\x18\x02 \x01(\x03R\x06sentAt\"1\n" +
 	"\x12S2C_PlayerLeaveNtf\x12\x1b\n" +
-	"\tplayer_id\x18\x01 \x01(\x03R\bplayerId*\xc9\x03\n" +
+	"\tplayer_id\x18\x01 \x01(\x03R\bplayerId*\x9a\n" +
+	"\n" +
 	"\x05MsgID\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\x15\n" +
 	"\x11C2S_JOIN_ROOM_REQ\x10e\x12\x18\n" +
@@ -1656,11 +4496,44 @@ const file_api_proto_game_proto_rawDesc = "" +
 	"\x12C2S_BID_BANKER_REQ\x10g\x12\x15\n" +
 	"\x11C2S_PLACE_BET_REQ\x10h\x12\x14\n" +
 	"\x10C2S_SHOWDOWN_REQ\x10i\x12\x16\n" +
-	"\x12C2S_LEAVE_ROOM_REQ\x10j\x12\x16\n" +
+	"\x12C2S_LEAVE_ROOM_REQ\x10j\x12\x15\n" +
+	"\x11C2S_ROOM_LIST_REQ\x10k\x12\x17\n" +
+	"\x13C2S_CREATE_ROOM_REQ\x10l\x12\x17\n" +
+	"\x13C2S_QUICK_MATCH_REQ\x10m\x12\x18\n" +
+	"\x14C2S_CANCEL_MATCH_REQ\x10n\x12\x15\n" +
+	"\x11C2S_TAKE_SEAT_REQ\x10o\x12\x17\n" +
+	"\x13C2S_KICK_PLAYER_REQ\x10p\x12\x15\n" +
+	"\x11C2S_LOCK_SEAT_REQ\x10q\x12\x17\n" +
+	"\x13C2S_FORCE_START_REQ\x10r\x12\x1a\n" +
+	"\x16C2S_TRANSFER_OWNER_REQ\x10s\x12\x1c\n" +
+	"\x18C2S_DISSOLVE_PROPOSE_REQ\x10t\x12\x19\n" +
+	"\x15C2S_DISSOLVE_VOTE_REQ\x10u\x12\x11\n" +
+	"\rC2S_LOGIN_REQ\x10v\x12\x16\n" +
 	"\x11S2C_JOIN_ROOM_ACK\x10\xc9\x01\x12\x17\n" +
 	"\x12S2C_BID_BANKER_ACK\x10\xd2\x01\x12\x16\n" +
 	"\x11S2C_PLACE_BET_ACK\x10\xd3\x01\x12\x15\n" +
-	"\x10S2C_SHOWDOWN_ACK\x10\xd4\x01\x12\x1c\n" +
+	"\x10S2C_SHOWDOWN_ACK\x10\xd4\x01\x12\x16\n" +
+	"\x11S2C_ROOM_LIST_ACK\x10\xd5\x01\x12\x18\n" +
+	"\x13S2C_CREATE_ROOM_ACK\x10\xd6\x01\x12\x18\n" +
+	"\x13S2C_QUICK_MATCH_ACK\x10\xd7\x01\x12\x19\n" +
+	"\x14S2C_CANCEL_MATCH_ACK\x10\xd8\x01\x12\x18\n" +
+	"\x13S2C_MATCH_QUEUE_NTF\x10\xd9\x01\x12\x18\n" +
+	"\x13S2C_MATCH_FOUND_NTF\x10\xda\x01\x12\x16\n" +
+	"\x11S2C_TAKE_SEAT_ACK\x10\xdb\x01\x12\x17\n" +
+	"\x12S2C_LEAVE_ROOM_ACK\x10\xdc\x01\x12\x18\n" +
+	"\x13S2C_KICK_PLAYER_ACK\x10\xdd\x01\x12\x16\n" +
+	"\x11S2C_LOCK_SEAT_ACK\x10\xde\x01\x12\x18\n" +
+	"\x13S2C_FORCE_START_ACK\x10\xdf\x01\x12\x1b\n" +
+	"\x16S2C_TRANSFER_OWNER_ACK\x10\xe0\x01\x12\x13\n" +
+	"\x0eS2C_KICKED_NTF\x10\xe1\x01\x12\x1d\n" +
+	"\x18S2C_DISSOLVE_PROPOSE_ACK\x10\xe2\x01\x12\x1a\n" +
+	"\x15S2C_DISSOLVE_VOTE_ACK\x10\xe3\x01\x12\x1a\n" +
+	"\x15S2C_DISSOLVE_VOTE_NTF\x10\xe4\x01\x12\x1c\n" +
+	"\x17S2C_DISSOLVE_RESULT_NTF\x10\xe5\x01\x12\x1c\n" +
+	"\x17S2C_SESSION_SUMMARY_NTF\x10\xe6\x01\x12\x12\n" +
+	"\rS2C_LOGIN_ACK\x10\xe7\x01\x12\x18\n" +
+	"\x13S2C_MAINTENANCE_NTF\x10\xe8\x01\x12\x19\n" +
+	"\x14S2C_ANNOUNCEMENT_NTF\x10\xe9\x01\x12\x1c\n" +
 	"\x17S2C_SYNC_ROOM_STATE_NTF\x10\xca\x01\x12\x17\n" +
 	"\x12S2C_GAME_START_NTF\x10\xcb\x01\x12\x17\n" +
 	"\x12S2C_DEAL_CARDS_NTF\x10\xcc\x01\x12\x17\n" +
@@ -1692,7 +4565,7 @@ const file_api_proto_game_proto_rawDesc = "" +
 	"\x12\b\n" +
 	"\x04JACK\x10\v\x12\t\n" +
 	"\x05QUEEN\x10\f\x12\b\n" +
-	"\x04KING\x10\r*\xd5\x01\n" +
+	"\x04KING\x10\r*\xed\x01\n" +
 	"\vCardPattern\x12\x13\n" +
 	"\x0fPATTERN_UNKNOWN\x10\x00\x12\n" +
 	"\n" +
@@ -1710,12 +4583,13 @@ const file_api_proto_game_proto_rawDesc = "" +
 	"\aNIU_NIU\x10\v\x12\x13\n" +
 	"\x0fFIVE_FLOWER_NIU\x10\f\x12\f\n" +
 	"\bBOMB_NIU\x10\r\x12\x12\n" +
-	"\x0eFIVE_SMALL_NIU\x10\x0e*G\n" +
+	"\x0eFIVE_SMALL_NIU\x10\x0e\x12\x16\n" +
+	"\x12STRAIGHT_FLUSH_NIU\x10\x0f*G\n" +
 	"\fPlayerStatus\x12\x12\n" +
 	"\x0eSTATUS_UNKNOWN\x10\x00\x12\v\n" +
 	"\aWAITING\x10\x01\x12\t\n" +
 	"\x05READY\x10\x02\x12\v\n" +
-	"\aPLAYING\x10\x03*|\n" +
+	"\aPLAYING\x10\x03*\x88\x01\n" +
 	"\tGameState\x12\x11\n" +
 	"\rSTATE_UNKNOWN\x10\x00\x12\x17\n" +
 	"\x13WAITING_FOR_PLAYERS\x10\x01\x12\v\n" +
@@ -1724,7 +4598,26 @@ const file_api_proto_game_proto_rawDesc = "" +
 	"\aBETTING\x10\x04\x12\f\n" +
 	"\bSHOWDOWN\x10\x05\x12\x0e\n" +
 	"\n" +
-	"SETTLEMENT\x10\x06B\x1aZ\x18xizexcample/internal/msgb\x06proto3"
+	"SETTLEMENT\x10\x06\x12\n" +
+	"\n" +
+	"\x06CLOSED\x10\a*`\n" +
+	"\aRuleSet\x12\x14\n" +
+	"\x10RULE_SET_UNKNOWN\x10\x00\x12\x0e\n" +
+	"\n" +
+	"BANKER_BID\x10\x01\x12\f\n" +
+	"\bFREE_BID\x10\x02\x12\x10\n" +
+	"\fFIXED_BANKER\x10\x03\x12\x0f\n" +
+	"\vALL_COMPARE\x10\x04*>\n" +
+	"\fPayoutPolicy\x12\x17\n" +
+	"\x13PAYOUT_PROPORTIONAL\x10\x00\x12\x15\n" +
+	"\x11PAYOUT_SEAT_ORDER\x10\x01*>\n" +
+	"\bRakeMode\x12\r\n" +
+	"\tRAKE_NONE\x10\x00\x12\x10\n" +
+	"\fRAKE_PERCENT\x10\x01\x12\x11\n" +
+	"\rRAKE_FLAT_FEE\x10\x02*=\n" +
+	"\fDissolveRule\x12\x16\n" +
+	"\x12DISSOLVE_UNANIMOUS\x10\x00\x12\x15\n" +
+	"\x11DISSOLVE_MAJORITY\x10\x01B\x1aZ\x18xizexcample/internal/msgb\x06proto3"
 
 var (
 	file_api_proto_game_proto_rawDescOnce sync.Once
@@ -1738,58 +4631,125 @@ func file_api_proto_game_proto_rawDescGZIP() []byte {
 	return file_api_proto_game_proto_rawDescData
 }
 
-var file_api_proto_game_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_api_proto_game_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_api_proto_game_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_api_proto_game_proto_msgTypes = make([]protoimpl.MessageInfo, 60)
 var file_api_proto_game_proto_goTypes = []any{
-	(MsgID)(0),                   // 0: game.MsgID
-	(Suit)(0),                    // 1: game.Suit
-	(Rank)(0),                    // 2: game.Rank
-	(CardPattern)(0),             // 3: game.CardPattern
-	(PlayerStatus)(0),            // 4: game.PlayerStatus
-	(GameState)(0),               // 5: game.GameState
-	(*Card)(nil),                 // 6: game.Card
-	(*PlayerInfo)(nil),           // 7: game.PlayerInfo
-	(*C2S_JoinRoomReq)(nil),      // 8: game.C2S_JoinRoomReq
-	(*C2S_PlayerReadyReq)(nil),   // 9: game.C2S_PlayerReadyReq
-	(*C2S_BidBankerReq)(nil),     // 10: game.C2S_BidBankerReq
-	(*C2S_PlaceBetReq)(nil),      // 11: game.C2S_PlaceBetReq
-	(*C2S_ShowdownReq)(nil),      // 12: game.C2S_ShowdownReq
-	(*C2S_LeaveRoomReq)(nil),     // 13: game.C2S_LeaveRoomReq
-	(*S2C_JoinRoomAck)(nil),      // 14: game.S2C_JoinRoomAck
-	(*S2C_BidBankerAck)(nil),     // 15: game.S2C_BidBankerAck
-	(*S2C_PlaceBetAck)(nil),      // 16: game.S2C_PlaceBetAck
-	(*S2C_ShowdownAck)(nil),      // 17: game.S2C_ShowdownAck
-	(*RoomInfo)(nil),             // 18: game.RoomInfo
-	(*S2C_SyncRoomStateNtf)(nil), // 19: game.S2C_SyncRoomStateNtf
-	(*S2C_GameStartNtf)(nil),     // 20: game.S2C_GameStartNtf
-	(*S2C_DealCardsNtf)(nil),     // 21: game.S2C_DealCardsNtf
-	(*S2C_BidBankerNtf)(nil),     // 22: game.S2C_BidBankerNtf
-	(*S2C_BetNtf)(nil),           // 23: game.S2C_BetNtf
-	(*S2C_ShowdownNtf)(nil),      // 24: game.S2C_ShowdownNtf
-	(*PlayerResult)(nil),         // 25: game.PlayerResult
-	(*S2C_GameResultNtf)(nil),    // 26: game.S2C_GameResultNtf
-	(*S2C_PlayerLeaveNtf)(nil),   // 27: game.S2C_PlayerLeaveNtf
+	(MsgID)(0),                     // 0: game.MsgID
+	(Suit)(0),                      // 1: game.Suit
+	(Rank)(0),                      // 2: game.Rank
+	(CardPattern)(0),               // 3: game.CardPattern
+	(PlayerStatus)(0),              // 4: game.PlayerStatus
+	(GameState)(0),                 // 5: game.GameState
+	(RuleSet)(0),                   // 6: game.RuleSet
+	(PayoutPolicy)(0),              // 7: game.PayoutPolicy
+	(RakeMode)(0),                  // 8: game.RakeMode
+	(DissolveRule)(0),              // 9: game.DissolveRule
+	(*Card)(nil),                   // 10: game.Card
+	(*PlayerInfo)(nil),             // 11: game.PlayerInfo
+	(*ProfileInfo)(nil),            // 12: game.ProfileInfo
+	(*C2S_LoginReq)(nil),           // 13: game.C2S_LoginReq
+	(*C2S_JoinRoomReq)(nil),        // 14: game.C2S_JoinRoomReq
+	(*C2S_PlayerReadyReq)(nil),     // 15: game.C2S_PlayerReadyReq
+	(*C2S_BidBankerReq)(nil),       // 16: game.C2S_BidBankerReq
+	(*C2S_PlaceBetReq)(nil),        // 17: game.C2S_PlaceBetReq
+	(*C2S_ShowdownReq)(nil),        // 18: game.C2S_ShowdownReq
+	(*C2S_LeaveRoomReq)(nil),       // 19: game.C2S_LeaveRoomReq
+	(*RoomSettings)(nil),           // 20: game.RoomSettings
+	(*RakeInfo)(nil),               // 21: game.RakeInfo
+	(*C2S_RoomListReq)(nil),        // 22: game.C2S_RoomListReq
+	(*C2S_CreateRoomReq)(nil),      // 23: game.C2S_CreateRoomReq
+	(*C2S_QuickMatchReq)(nil),      // 24: game.C2S_QuickMatchReq
+	(*C2S_CancelMatchReq)(nil),     // 25: game.C2S_CancelMatchReq
+	(*C2S_TakeSeatReq)(nil),        // 26: game.C2S_TakeSeatReq
+	(*C2S_KickPlayerReq)(nil),      // 27: game.C2S_KickPlayerReq
+	(*C2S_LockSeatReq)(nil),        // 28: game.C2S_LockSeatReq
+	(*C2S_ForceStartReq)(nil),      // 29: game.C2S_ForceStartReq
+	(*C2S_TransferOwnerReq)(nil),   // 30: game.C2S_TransferOwnerReq
+	(*C2S_DissolveProposeReq)(nil), // 31: game.C2S_DissolveProposeReq
+	(*C2S_DissolveVoteReq)(nil),    // 32: game.C2S_DissolveVoteReq
+	(*S2C_LoginAck)(nil),           // 33: game.S2C_LoginAck
+	(*S2C_JoinRoomAck)(nil),        // 34: game.S2C_JoinRoomAck
+	(*S2C_BidBankerAck)(nil),       // 35: game.S2C_BidBankerAck
+	(*S2C_PlaceBetAck)(nil),        // 36: game.S2C_PlaceBetAck
+	(*S2C_ShowdownAck)(nil),        // 37: game.S2C_ShowdownAck
+	(*RoomInfo)(nil),               // 38: game.RoomInfo
+	(*ScoreChange)(nil),            // 39: game.ScoreChange
+	(*RoundScore)(nil),             // 40: game.RoundScore
+	(*SessionScore)(nil),           // 41: game.SessionScore
+	(*SessionInfo)(nil),            // 42: game.SessionInfo
+	(*DissolveVoteInfo)(nil),       // 43: game.DissolveVoteInfo
+	(*RoomSummary)(nil),            // 44: game.RoomSummary
+	(*S2C_RoomListAck)(nil),        // 45: game.S2C_RoomListAck
+	(*S2C_CreateRoomAck)(nil),      // 46: game.S2C_CreateRoomAck
+	(*S2C_QuickMatchAck)(nil),      // 47: game.S2C_QuickMatchAck
+	(*S2C_CancelMatchAck)(nil),     // 48: game.S2C_CancelMatchAck
+	(*S2C_TakeSeatAck)(nil),        // 49: game.S2C_TakeSeatAck
+	(*S2C_LeaveRoomAck)(nil),       // 50: game.S2C_LeaveRoomAck
+	(*S2C_RoomOwnerAck)(nil),       // 51: game.S2C_RoomOwnerAck
+	(*S2C_DissolveAck)(nil),        // 52: game.S2C_DissolveAck
+	(*S2C_DissolveVoteNtf)(nil),    // 53: game.S2C_DissolveVoteNtf
+	(*S2C_DissolveResultNtf)(nil),  // 54: game.S2C_DissolveResultNtf
+	(*S2C_KickedNtf)(nil),          // 55: game.S2C_KickedNtf
+	(*S2C_MatchQueueNtf)(nil),      // 56: game.S2C_MatchQueueNtf
+	(*S2C_MatchFoundNtf)(nil),      // 57: game.S2C_MatchFoundNtf
+	(*S2C_SyncRoomStateNtf)(nil),   // 58: game.S2C_SyncRoomStateNtf
+	(*S2C_GameStartNtf)(nil),       // 59: game.S2C_GameStartNtf
+	(*S2C_DealCardsNtf)(nil),       // 60: game.S2C_DealCardsNtf
+	(*S2C_BidBankerNtf)(nil),       // 61: game.S2C_BidBankerNtf
+	(*S2C_BetNtf)(nil),             // 62: game.S2C_BetNtf
+	(*S2C_ShowdownNtf)(nil),        // 63: game.S2C_ShowdownNtf
+	(*PlayerResult)(nil),           // 64: game.PlayerResult
+	(*S2C_GameResultNtf)(nil),      // 65: game.S2C_GameResultNtf
+	(*S2C_SessionSummaryNtf)(nil),  // 66: game.S2C_SessionSummaryNtf
+	(*S2C_MaintenanceNtf)(nil),     // 67: game.S2C_MaintenanceNtf
+	(*S2C_AnnouncementNtf)(nil),    // 68: game.S2C_AnnouncementNtf
+	(*S2C_PlayerLeaveNtf)(nil),     // 69: game.S2C_PlayerLeaveNtf
 }
 var file_api_proto_game_proto_depIdxs = []int32{
 	1,  // 0: game.Card.suit:type_name -> game.Suit
 	2,  // 1: game.Card.rank:type_name -> game.Rank
 	4,  // 2: game.PlayerInfo.status:type_name -> game.PlayerStatus
-	6,  // 3: game.PlayerInfo.hand:type_name -> game.Card
+	10, // 3: game.PlayerInfo.hand:type_name -> game.Card
 	3,  // 4: game.PlayerInfo.card_pattern:type_name -> game.CardPattern
-	6,  // 5: game.C2S_ShowdownReq.sorted_hand:type_name -> game.Card
-	18, // 6: game.S2C_JoinRoomAck.room_info:type_name -> game.RoomInfo
-	7,  // 7: game.RoomInfo.players:type_name -> game.PlayerInfo
-	5,  // 8: game.RoomInfo.game_state:type_name -> game.GameState
-	18, // 9: game.S2C_SyncRoomStateNtf.room_info:type_name -> game.RoomInfo
-	6,  // 10: game.S2C_DealCardsNtf.hand:type_name -> game.Card
-	6,  // 11: game.PlayerResult.hand:type_name -> game.Card
-	3,  // 12: game.PlayerResult.card_pattern:type_name -> game.CardPattern
-	25, // 13: game.S2C_GameResultNtf.results:type_name -> game.PlayerResult
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	10, // 5: game.C2S_ShowdownReq.sorted_hand:type_name -> game.Card
+	6,  // 6: game.RoomSettings.rule_set:type_name -> game.RuleSet
+	9,  // 7: game.RoomSettings.dissolve_rule:type_name -> game.DissolveRule
+	7,  // 8: game.RoomSettings.payout_policy:type_name -> game.PayoutPolicy
+	21, // 9: game.RoomSettings.rake:type_name -> game.RakeInfo
+	8,  // 10: game.RakeInfo.mode:type_name -> game.RakeMode
+	6,  // 11: game.C2S_RoomListReq.rule_set:type_name -> game.RuleSet
+	20, // 12: game.C2S_CreateRoomReq.settings:type_name -> game.RoomSettings
+	12, // 13: game.S2C_LoginAck.profile:type_name -> game.ProfileInfo
+	38, // 14: game.S2C_JoinRoomAck.room_info:type_name -> game.RoomInfo
+	11, // 15: game.RoomInfo.players:type_name -> game.PlayerInfo
+	5,  // 16: game.RoomInfo.game_state:type_name -> game.GameState
+	20, // 17: game.RoomInfo.settings:type_name -> game.RoomSettings
+	43, // 18: game.RoomInfo.dissolve_vote:type_name -> game.DissolveVoteInfo
+	42, // 19: game.RoomInfo.session:type_name -> game.SessionInfo
+	39, // 20: game.RoundScore.changes:type_name -> game.ScoreChange
+	10, // 21: game.SessionScore.best_hand:type_name -> game.Card
+	3,  // 22: game.SessionScore.best_pattern:type_name -> game.CardPattern
+	40, // 23: game.SessionInfo.rounds:type_name -> game.RoundScore
+	41, // 24: game.SessionInfo.scores:type_name -> game.SessionScore
+	9,  // 25: game.DissolveVoteInfo.rule:type_name -> game.DissolveRule
+	6,  // 26: game.RoomSummary.rule_set:type_name -> game.RuleSet
+	5,  // 27: game.RoomSummary.game_state:type_name -> game.GameState
+	44, // 28: game.S2C_RoomListAck.rooms:type_name -> game.RoomSummary
+	38, // 29: game.S2C_CreateRoomAck.room_info:type_name -> game.RoomInfo
+	43, // 30: game.S2C_DissolveVoteNtf.vote:type_name -> game.DissolveVoteInfo
+	11, // 31: game.S2C_DissolveResultNtf.players:type_name -> game.PlayerInfo
+	38, // 32: game.S2C_MatchFoundNtf.room_info:type_name -> game.RoomInfo
+	38, // 33: game.S2C_SyncRoomStateNtf.room_info:type_name -> game.RoomInfo
+	10, // 34: game.S2C_DealCardsNtf.hand:type_name -> game.Card
+	10, // 35: game.PlayerResult.hand:type_name -> game.Card
+	3,  // 36: game.PlayerResult.card_pattern:type_name -> game.CardPattern
+	64, // 37: game.S2C_GameResultNtf.results:type_name -> game.PlayerResult
+	42, // 38: game.S2C_SessionSummaryNtf.session:type_name -> game.SessionInfo
+	39, // [39:39] is the sub-list for method output_type
+	39, // [39:39] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_game_proto_init() }
@@ -1802,8 +4762,8 @@ func file_api_proto_game_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_game_proto_rawDesc), len(file_api_proto_game_proto_rawDesc)),
-			NumEnums:      6,
-			NumMessages:   22,
+			NumEnums:      10,
+			NumMessages:   60,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
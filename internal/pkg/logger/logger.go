@@ -1,26 +1,136 @@
 package logger
 
 import (
+	"errors"
+	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os"
+	"strings"
+	"sync/atomic"
 )
 
+// 日志上下文字段的名称，所有日志中的同一种上下文使用相同的字段名，便于检索
+const (
+	KeyRoomID   = "room_id"
+	KeyPlayerID = "player_id"
+	KeyRoundID  = "round_id"
+	KeyConnID   = "conn_id"
+	KeyMsgID    = "msg_id"
+)
+
+// Config 日志配置
+type Config struct {
+	Level      string // debug / info / warn / error
+	Format     string // text / json
+	File       string // 日志文件路径，为空时输出到标准输出
+	MaxSizeMB  int    // 单个日志文件的最大大小（MB），超过后轮转
+	MaxBackups int    // 保留的历史日志文件数
+}
+
 var (
-	// InfoLogger 用于记录参考信息
-	InfoLogger *log.Logger
-	// ErrorLogger 用于记录错误信息
-	ErrorLogger *log.Logger
+	level         = new(slog.LevelVar)
+	defaultLogger atomic.Pointer[slog.Logger]
 )
 
 func init() {
-	// 默认初始化，将日志输出到标准输出和标准错误
-	// 之后可以被 T041 中的配置管理覆盖
-	InitLogger(os.Stdout, os.Stderr)
+	// 默认输出到标准输出，服务启动时由 Init 按配置替换
+	defaultLogger.Store(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: level})))
+}
+
+// Init 按配置初始化全局日志记录器，返回的 io.Closer 用于在退出时关闭日志文件
+func Init(cfg Config) (io.Closer, error) {
+	if err := SetLevel(cfg.Level); err != nil {
+		return nil, err
+	}
+
+	var out io.Writer = os.Stdout
+	var closer io.Closer = io.NopCloser(nil)
+	if cfg.File != "" {
+		file, err := NewRotatingFile(cfg.File, int64(cfg.MaxSizeMB)<<20, cfg.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		out, closer = file, file
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch cfg.Format {
+	case "", "text":
+		handler = slog.NewTextHandler(out, options)
+	case "json":
+		handler = slog.NewJSONHandler(out, options)
+	default:
+		closer.Close()
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+	defaultLogger.Store(slog.New(handler))
+	return closer, nil
+}
+
+// L 返回全局日志记录器
+func L() *slog.Logger {
+	return defaultLogger.Load()
+}
+
+// ParseLevel 解析日志级别名称，空字符串表示 info
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, errors.New("unknown log level " + name)
+	}
+}
+
+// SetLevel 修改日志级别，运行中修改立即生效
+func SetLevel(name string) error {
+	l, err := ParseLevel(name)
+	if err != nil {
+		return err
+	}
+	level.Set(l)
+	return nil
+}
+
+// GetLevel 返回当前的日志级别
+func GetLevel() string {
+	return strings.ToLower(level.Level().String())
+}
+
+// RoomID 房间ID上下文
+func RoomID(id int32) slog.Attr {
+	return slog.Int(KeyRoomID, int(id))
+}
+
+// PlayerID 玩家ID上下文
+func PlayerID(id int64) slog.Attr {
+	return slog.Int64(KeyPlayerID, id)
+}
+
+// RoundID 牌局ID上下文
+func RoundID(id string) slog.Attr {
+	return slog.String(KeyRoundID, id)
+}
+
+// ConnID 连接ID上下文
+func ConnID(id uint64) slog.Attr {
+	return slog.Uint64(KeyConnID, id)
+}
+
+// MsgID 消息ID上下文，使用消息名称
+func MsgID(name string) slog.Attr {
+	return slog.String(KeyMsgID, name)
 }
 
-// InitLogger 初始化日志记录器，允许将输出重定向到不同的 io.Writer
-func InitLogger(infoHandle io.Writer, errorHandle io.Writer) {
-	InfoLogger = log.New(infoHandle, "[INFO] ", log.Ldate|log.Ltime|log.Lshortfile)
-	ErrorLogger = log.New(errorHandle, "[ERROR] ", log.Ldate|log.Ltime|log.Lshortfile)
+// Err 错误字段
+func Err(err error) slog.Attr {
+	return slog.Any("error", err)
 }
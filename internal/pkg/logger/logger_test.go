@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetLevel(t *testing.T) {
+	defer SetLevel("info")
+
+	for _, name := range []string{"debug", "info", "warn", "error", "DEBUG"} {
+		if err := SetLevel(name); err != nil {
+			t.Errorf("Expected level %q to be accepted, got %v", name, err)
+		}
+		if GetLevel() != strings.ToLower(name) {
+			t.Errorf("Expected level %s, got %s", strings.ToLower(name), GetLevel())
+		}
+	}
+	if err := SetLevel("verbose"); err == nil {
+		t.Error("Expected an unknown level to be rejected")
+	}
+	if GetLevel() != "debug" {
+		t.Errorf("Expected an unknown level to leave the level unchanged, got %s", GetLevel())
+	}
+}
+
+func TestInitJSONWithContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	previous := L()
+	defer defaultLogger.Store(previous)
+	closer, err := Init(Config{Level: "warn", Format: "json", File: path})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	defer SetLevel("info")
+
+	L().Info("filtered out")
+	L().With(RoomID(7), RoundID("7-1")).Warn("round event", PlayerID(42), ConnID(3), MsgID("C2S_PLACE_BET_REQ"))
+	closer.Close()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	defer file.Close()
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Expected JSON log lines, got %q", scanner.Text())
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry above the warn level, got %d", len(entries))
+	}
+	want := map[string]interface{}{
+		KeyRoomID:   float64(7),
+		KeyRoundID:  "7-1",
+		KeyPlayerID: float64(42),
+		KeyConnID:   float64(3),
+		KeyMsgID:    "C2S_PLACE_BET_REQ",
+	}
+	for key, value := range want {
+		if entries[0][key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, entries[0][key])
+		}
+	}
+}
+
+func TestInitRejectsUnknownFormat(t *testing.T) {
+	if _, err := Init(Config{Format: "xml"}); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "server.log")
+	r, err := NewRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+	defer r.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := r.Write([]byte(line)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	// 每次写入都会超过10字节的上限，只保留最近的两个历史文件
+	expected := map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	}
+	for file, content := range expected {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		if string(data) != content {
+			t.Errorf("Expected %s to contain %q, got %q", file, content, string(data))
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("Expected at most 2 backups to be kept")
+	}
+}
@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidToken 登录令牌无效或已过期
+var ErrInvalidToken = errors.New("invalid login token")
+
+// Authenticator 校验账号服务签发的登录令牌。令牌格式为 "<过期时间的 Unix 秒>.<签名>"，
+// 签名是用双方共享的密钥对 "<player_id>.<过期时间>" 计算的 HMAC-SHA256，十六进制编码
+type Authenticator struct {
+	secret []byte
+}
+
+// NewAuthenticator 使用共享密钥创建令牌校验器
+func NewAuthenticator(secret string) (*Authenticator, error) {
+	if secret == "" {
+		return nil, errors.New("login secret must not be empty")
+	}
+	return &Authenticator{secret: []byte(secret)}, nil
+}
+
+// Sign 为玩家签发一个在 expiresAt 之前有效的登录令牌
+func (a *Authenticator) Sign(playerID int64, expiresAt time.Time) string {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return expires + "." + a.signature(playerID, expires)
+}
+
+// Verify 校验令牌是否由共享密钥为该玩家签发且尚未过期
+func (a *Authenticator) Verify(playerID int64, token string, now time.Time) error {
+	expires, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalidToken
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidToken
+	}
+	if !hmac.Equal([]byte(signature), []byte(a.signature(playerID, expires))) {
+		return ErrInvalidToken
+	}
+	if !now.Before(time.Unix(expiresAt, 0)) {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// signature 计算玩家ID和过期时间的签名
+func (a *Authenticator) signature(playerID int64, expires string) string {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(strconv.FormatInt(playerID, 10) + "." + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+var (
+	authenticatorInstance *Authenticator
+	authenticatorMu       sync.Mutex
+)
+
+// GetAuthenticator 获取全局令牌校验器，未通过 SetAuthenticator 指定时返回 nil，此时不允许登录
+func GetAuthenticator() *Authenticator {
+	authenticatorMu.Lock()
+	defer authenticatorMu.Unlock()
+	return authenticatorInstance
+}
+
+// SetAuthenticator 替换全局令牌校验器，应在服务启动时调用
+func SetAuthenticator(a *Authenticator) {
+	authenticatorMu.Lock()
+	defer authenticatorMu.Unlock()
+	authenticatorInstance = a
+}
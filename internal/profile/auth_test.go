@@ -0,0 +1,44 @@
+package profile
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAuthenticatorVerify(t *testing.T) {
+	if _, err := NewAuthenticator(""); err == nil {
+		t.Errorf("Expected an error for an empty secret")
+	}
+	auth, err := NewAuthenticator("secret")
+	if err != nil {
+		t.Fatalf("NewAuthenticator failed: %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	token := auth.Sign(10001, now.Add(time.Hour))
+
+	if err := auth.Verify(10001, token, now); err != nil {
+		t.Errorf("Expected the token to be valid, got %v", err)
+	}
+
+	other, _ := NewAuthenticator("other secret")
+	tests := []struct {
+		name     string
+		auth     *Authenticator
+		playerID int64
+		token    string
+		now      time.Time
+	}{
+		{"other player", auth, 10002, token, now},
+		{"expired", auth, 10001, token, now.Add(time.Hour)},
+		{"extended expiry", auth, 10001, "9999999999" + token[len("1700003600"):], now},
+		{"other secret", other, 10001, token, now},
+		{"malformed", auth, 10001, "not a token", now},
+		{"empty", auth, 10001, "", now},
+	}
+	for _, tt := range tests {
+		if err := tt.auth.Verify(tt.playerID, tt.token, tt.now); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package profile
+
+import (
+	"errors"
+	"strconv"
+	"time"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/wallet"
+)
+
+// ErrBanned 被封禁的玩家不能登录
+var ErrBanned = errors.New("player is banned")
+
+// Stats 玩家的累计战绩
+type Stats struct {
+	RoundsPlayed int   `json:"rounds_played"`
+	RoundsWon    int   `json:"rounds_won"`
+	TotalWon     int64 `json:"total_won"`  // 赢局的净赢分之和
+	TotalLost    int64 `json:"total_lost"` // 输局的输分之和，为正数
+	BiggestWin   int64 `json:"biggest_win"`
+}
+
+// Profile 玩家档案，离开房间和服务器重启后依然保留。
+// 分数以钱包余额为准，Balance 是最近一次登录或结算时的余额副本
+type Profile struct {
+	PlayerID    int64     `json:"player_id"`
+	Nickname    string    `json:"nickname"`
+	Balance     int64     `json:"balance"`
+	Stats       Stats     `json:"stats"`
+	Banned      bool      `json:"banned"`
+	BanReason   string    `json:"ban_reason,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
+// NewProfile 创建一个新玩家的档案，未指定昵称时使用 Player<playerID>
+func NewProfile(playerID int64, nickname string) *Profile {
+	if nickname == "" {
+		nickname = DefaultNickname(playerID)
+	}
+	return &Profile{
+		PlayerID:  playerID,
+		Nickname:  nickname,
+		CreatedAt: time.Now(),
+	}
+}
+
+// DefaultNickname 返回玩家的默认昵称
+func DefaultNickname(playerID int64) string {
+	return "Player" + strconv.FormatInt(playerID, 10)
+}
+
+// Ban 封禁玩家
+func (p *Profile) Ban(reason string) {
+	p.Banned = true
+	p.BanReason = reason
+}
+
+// Unban 解除封禁
+func (p *Profile) Unban() {
+	p.Banned = false
+	p.BanReason = ""
+}
+
+// RecordResult 把玩家一局的结算结果计入档案
+func (p *Profile) RecordResult(r logic.PlayerResult) {
+	p.Balance = r.FinalScore
+	p.Stats.RoundsPlayed++
+	switch {
+	case r.ScoreChange > 0:
+		p.Stats.RoundsWon++
+		p.Stats.TotalWon += r.ScoreChange
+		p.Stats.BiggestWin = max(p.Stats.BiggestWin, r.ScoreChange)
+	case r.ScoreChange < 0:
+		p.Stats.TotalLost -= r.ScoreChange
+	}
+}
+
+// Login 加载玩家档案，新玩家创建档案并开户赠送初始分数。
+// 账目中没有该玩家的账户时（例如账目文件被重置），按档案中的余额重新开户
+func Login(repo Repository, w *wallet.Wallet, playerID int64, nickname string) (*Profile, error) {
+	if playerID <= 0 {
+		return nil, errors.New("invalid player id")
+	}
+
+	p, err := repo.Load(playerID)
+	var grant int64
+	switch {
+	case errors.Is(err, ErrNotFound):
+		p = NewProfile(playerID, nickname)
+		grant = logic.InitialScore
+	case err != nil:
+		return nil, err
+	default:
+		grant = p.Balance
+	}
+	if p.Banned {
+		return nil, ErrBanned
+	}
+
+	if !w.HasAccount(playerID) && grant > 0 {
+		if _, err := w.OpenAccount(playerID, grant); err != nil {
+			return nil, err
+		}
+	}
+	if nickname != "" {
+		p.Nickname = nickname
+	}
+	p.Balance = w.Balance(playerID)
+	p.LastLoginAt = time.Now()
+	if err := repo.Save(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// RecordRound 把一局的结算结果写入参与玩家的档案，没有档案（未登录）的玩家跳过
+func RecordRound(repo Repository, result *logic.RoundResult) error {
+	var errs []error
+	for _, r := range result.Results {
+		p, err := repo.Load(r.PlayerID)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		p.RecordResult(r)
+		if err := repo.Save(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
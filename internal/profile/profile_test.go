@@ -0,0 +1,128 @@
+package profile
+
+import (
+	"errors"
+	"testing"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/wallet"
+)
+
+func newTestWallet(t *testing.T) *wallet.Wallet {
+	w, err := wallet.NewWallet(wallet.NewMemoryStore())
+	if err != nil {
+		t.Fatalf("NewWallet failed: %v", err)
+	}
+	return w
+}
+
+func TestLoginCreatesAndReloadsProfile(t *testing.T) {
+	repo := NewMemoryRepository()
+	w := newTestWallet(t)
+
+	p, err := Login(repo, w, 10001, "alice")
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if p.Nickname != "alice" || p.Balance != logic.InitialScore {
+		t.Errorf("Expected new profile alice with %d, got %+v", logic.InitialScore, p)
+	}
+
+	// 结算写回后重新登录，分数和战绩保留
+	result := &logic.RoundResult{Results: []logic.PlayerResult{
+		{PlayerID: 10001, ScoreChange: 50, FinalScore: 1050},
+		{PlayerID: 20002, ScoreChange: -50, FinalScore: 950}, // 未登录的玩家没有档案
+	}}
+	if err := RecordRound(repo, result); err != nil {
+		t.Fatalf("RecordRound failed: %v", err)
+	}
+	if _, err := repo.Load(20002); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected no profile for a player who never logged in, got %v", err)
+	}
+
+	p, err = Login(repo, newTestWallet(t), 10001, "")
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if p.Nickname != "alice" {
+		t.Errorf("Expected nickname to be kept, got %s", p.Nickname)
+	}
+	if p.Balance != 1050 {
+		t.Errorf("Expected balance to be restored from the profile, got %d", p.Balance)
+	}
+	if p.Stats.RoundsPlayed != 1 || p.Stats.RoundsWon != 1 || p.Stats.TotalWon != 50 || p.Stats.BiggestWin != 50 {
+		t.Errorf("Unexpected stats: %+v", p.Stats)
+	}
+}
+
+func TestLoginUsesWalletBalance(t *testing.T) {
+	repo := NewMemoryRepository()
+	w := newTestWallet(t)
+	if _, err := Login(repo, w, 10001, "alice"); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if err := w.Transfer(10001, wallet.HouseAccountID, 300, "r1", wallet.REASON_ROUND_SETTLEMENT); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+
+	p, err := Login(repo, w, 10001, "")
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if p.Balance != 700 || w.Balance(10001) != 700 {
+		t.Errorf("Expected the wallet balance 700 to win over the profile copy, got %d", p.Balance)
+	}
+}
+
+func TestLoginRejectsBannedPlayer(t *testing.T) {
+	repo := NewMemoryRepository()
+	w := newTestWallet(t)
+
+	p := NewProfile(10001, "")
+	p.Ban("cheating")
+	if err := repo.Save(p); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := Login(repo, w, 10001, ""); !errors.Is(err, ErrBanned) {
+		t.Errorf("Expected ErrBanned, got %v", err)
+	}
+	if w.HasAccount(10001) {
+		t.Error("Expected no account to be opened for a banned player")
+	}
+
+	p.Unban()
+	repo.Save(p)
+	if _, err := Login(repo, w, 10001, ""); err != nil {
+		t.Errorf("Expected login to succeed after unban, got %v", err)
+	}
+	if _, err := Login(repo, w, 0, ""); err == nil {
+		t.Error("Expected an invalid player id to be rejected")
+	}
+}
+
+func TestFileRepository(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := NewFileRepository(dir)
+	if err != nil {
+		t.Fatalf("NewFileRepository failed: %v", err)
+	}
+	if _, err := repo.Load(10001); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	p := NewProfile(10001, "alice")
+	p.Balance = 1200
+	p.Stats.RoundsPlayed = 3
+	if err := repo.Save(p); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// 重新打开目录，模拟服务器重启
+	reopened, _ := NewFileRepository(dir)
+	loaded, err := reopened.Load(10001)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Nickname != "alice" || loaded.Balance != 1200 || loaded.Stats.RoundsPlayed != 3 {
+		t.Errorf("Expected profile to survive reopening, got %+v", loaded)
+	}
+}
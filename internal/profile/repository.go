@@ -0,0 +1,147 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrNotFound 玩家档案不存在
+var ErrNotFound = errors.New("profile not found")
+
+// Repository 玩家档案存储
+type Repository interface {
+	// Load 读取玩家档案，档案不存在时返回 ErrNotFound
+	Load(playerID int64) (*Profile, error)
+	// Save 保存玩家档案，覆盖之前的档案
+	Save(profile *Profile) error
+}
+
+// MemoryRepository 内存中的档案存储
+type MemoryRepository struct {
+	profiles map[int64][]byte // key: playerID，保存序列化后的档案，调用方修改返回值不影响存储
+	mu       sync.Mutex
+}
+
+// NewMemoryRepository 创建一个内存档案存储
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{profiles: make(map[int64][]byte)}
+}
+
+// Load 读取玩家档案
+func (r *MemoryRepository) Load(playerID int64) (*Profile, error) {
+	r.mu.Lock()
+	data, exists := r.profiles[playerID]
+	r.mu.Unlock()
+	if !exists {
+		return nil, ErrNotFound
+	}
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Save 保存玩家档案
+func (r *MemoryRepository) Save(profile *Profile) error {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.profiles[profile.PlayerID] = data
+	return nil
+}
+
+// FileRepository 以 JSON 文件保存档案的存储，每名玩家一个文件 player-<playerID>.json。
+// 写入时先写临时文件再重命名，崩溃时不会留下写了一半的档案
+type FileRepository struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileRepository 打开或创建档案目录
+func NewFileRepository(dir string) (*FileRepository, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create profile directory: %w", err)
+	}
+	return &FileRepository{dir: dir}, nil
+}
+
+// path 返回玩家档案文件的路径
+func (r *FileRepository) path(playerID int64) string {
+	return filepath.Join(r.dir, fmt.Sprintf("player-%d.json", playerID))
+}
+
+// Load 读取玩家档案文件
+func (r *FileRepository) Load(playerID int64) (*Profile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := os.ReadFile(r.path(playerID))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read profile file: %w", err)
+	}
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("profile file of player %d: %w", playerID, err)
+	}
+	return &p, nil
+}
+
+// Save 将档案写入临时文件并刷盘，然后原子地替换旧档案
+func (r *FileRepository) Save(profile *Profile) error {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tmp, err := os.CreateTemp(r.dir, fmt.Sprintf("player-%d-*.tmp", profile.PlayerID))
+	if err != nil {
+		return fmt.Errorf("create profile file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write profile file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync profile file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close profile file: %w", err)
+	}
+	return os.Rename(tmp.Name(), r.path(profile.PlayerID))
+}
+
+var (
+	repositoryInstance Repository = NewMemoryRepository()
+	repositoryMu       sync.Mutex
+)
+
+// GetRepository 获取全局档案存储，未通过 SetRepository 指定时使用内存存储
+func GetRepository() Repository {
+	repositoryMu.Lock()
+	defer repositoryMu.Unlock()
+	return repositoryInstance
+}
+
+// SetRepository 替换全局档案存储，应在服务启动时调用
+func SetRepository(r Repository) {
+	repositoryMu.Lock()
+	defer repositoryMu.Unlock()
+	repositoryInstance = r
+}
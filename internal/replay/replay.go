@@ -0,0 +1,226 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"xizexcample/internal/history"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/wallet"
+)
+
+// replayRoomID 复盘使用的临时房间ID，复盘房间不登记到 RoomManager
+const replayRoomID int32 = 0
+
+// EventType 复盘事件类型
+type EventType string
+
+const (
+	EVENT_STATE    EventType = "state"    // 状态机进入新阶段
+	EVENT_DEAL     EventType = "deal"     // 给玩家发牌
+	EVENT_BANKER   EventType = "banker"   // 玩家成为庄家
+	EVENT_BET      EventType = "bet"      // 闲家下注
+	EVENT_SHOWDOWN EventType = "showdown" // 玩家亮牌
+	EVENT_SETTLE   EventType = "settle"   // 玩家本局结算
+)
+
+// Event 复盘事件，客户端按顺序播放即可还原一局
+type Event struct {
+	Seq         int             `json:"seq"`
+	Type        EventType       `json:"type"`
+	State       logic.GameState `json:"state"`
+	PlayerID    int64           `json:"player_id,omitempty"`
+	Seat        int             `json:"seat,omitempty"`
+	Hand        []logic.Card    `json:"hand,omitempty"`
+	CardType    logic.CardType  `json:"card_type,omitempty"`
+	Multiple    int32           `json:"multiple,omitempty"`
+	ScoreChange int64           `json:"score_change,omitempty"`
+	Rake        int64           `json:"rake,omitempty"`
+	FinalScore  int64           `json:"final_score,omitempty"`
+}
+
+// Divergence 复盘结果与记录不一致的一项
+type Divergence struct {
+	PlayerID int64  `json:"player_id"`
+	Field    string `json:"field"`
+	Recorded string `json:"recorded"`
+	Replayed string `json:"replayed"`
+}
+
+// String 返回不一致项的描述
+func (d Divergence) String() string {
+	return fmt.Sprintf("player %d %s: recorded %s, replayed %s", d.PlayerID, d.Field, d.Recorded, d.Replayed)
+}
+
+// Result 一局的复盘结果
+type Result struct {
+	RoundID     string       `json:"round_id"`
+	Events      []Event      `json:"events"`
+	Divergences []Divergence `json:"divergences"`
+}
+
+// Diverged 检查复盘结果是否与记录不一致，不一致通常意味着牌局逻辑出现了回归
+func (r *Result) Diverged() bool {
+	return len(r.Divergences) > 0
+}
+
+// Replay 用记录中的洗牌种子、座位、抢庄和下注重新打一遍这一局：
+// 牌局经过 RoomFSM 的各个阶段，由牌型计算和结算逻辑重新算出结果，再与记录逐项比对
+func Replay(record history.Record) (*Result, error) {
+	if len(record.Players) < logic.MinSeats {
+		return nil, errors.New("record has too few players to replay")
+	}
+
+	settings := logic.DefaultRoomSettings()
+	settings.BaseBet = record.BaseBet
+	settings.RuleSet = record.RuleSet
+	settings.PayoutPolicy = record.PayoutPolicy
+	settings.Rake = record.Rake
+	settings.MaxPlayers = record.MaxPlayers
+	for _, p := range record.Players {
+		settings.MaxPlayers = max(settings.MaxPlayers, p.Seat)
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid room settings in record: %w", err)
+	}
+
+	// 复盘使用独立的内存钱包，每名玩家的余额恢复到本局开始前
+	w, err := wallet.NewWallet(wallet.NewMemoryStore())
+	if err != nil {
+		return nil, err
+	}
+	room := logic.NewRoomWithSettings(replayRoomID, settings)
+	defer room.Close()
+	room.SetWallet(w)
+	room.SetSeedSource(func() int64 { return record.ShuffleSeed })
+
+	result := &Result{RoundID: record.RoundID}
+	emit := func(e Event) {
+		e.Seq = len(result.Events) + 1
+		e.State = room.GetFSM().GetCurrentState()
+		result.Events = append(result.Events, e)
+	}
+	room.GetFSM().SetTransitionHook(func(state logic.GameState) {
+		emit(Event{Type: EVENT_STATE})
+	})
+
+	for _, p := range record.Players {
+		before := p.FinalScore - p.ScoreChange
+		if before <= 0 {
+			return nil, fmt.Errorf("player %d had no balance before the round", p.PlayerID)
+		}
+		if _, err := w.OpenAccount(p.PlayerID, before); err != nil {
+			return nil, err
+		}
+		player := logic.NewPlayer(p.PlayerID, fmt.Sprintf("Player%d", p.PlayerID), nil)
+		if err := room.AddPlayerAtSeat(player, p.Seat); err != nil {
+			return nil, fmt.Errorf("seat player %d: %w", p.PlayerID, err)
+		}
+		player.SetStatus(logic.STATUS_READY)
+	}
+
+	// 发牌：同样的种子和座位顺序应发出记录中的手牌；固定庄家玩法由房主坐庄
+	room.SetOwner(record.BankerID)
+	fsm := room.GetFSM()
+	if err := fsm.StartGame(); err != nil {
+		return nil, err
+	}
+	if err := fsm.DealCards(); err != nil {
+		return nil, err
+	}
+	for _, p := range room.GetPlayers() {
+		emit(Event{Type: EVENT_DEAL, PlayerID: p.ID, Seat: p.GetSeat(), Hand: p.GetHand()})
+	}
+
+	// 抢庄和下注按记录进行，玩法没有的阶段跳过
+	if fsm.GetCurrentState() == logic.STATE_BIDDING {
+		for _, p := range record.Players {
+			player, _ := room.GetPlayer(p.PlayerID)
+			player.SetBidMultiple(p.BidMultiple)
+			if p.IsBanker {
+				room.SetBanker(p.PlayerID)
+			}
+		}
+		if err := fsm.BidBanker(); err != nil {
+			return nil, err
+		}
+	}
+	if banker, err := room.GetPlayer(room.GetBankerID()); err == nil {
+		emit(Event{Type: EVENT_BANKER, PlayerID: banker.ID, Seat: banker.GetSeat(), Multiple: banker.GetBidMultiple()})
+	}
+	if fsm.GetCurrentState() == logic.STATE_BETTING {
+		for _, p := range record.Players {
+			if p.IsBanker {
+				continue
+			}
+			player, _ := room.GetPlayer(p.PlayerID)
+			player.PlaceBet(p.BetMultiple)
+			emit(Event{Type: EVENT_BET, PlayerID: p.PlayerID, Seat: p.Seat, Multiple: p.BetMultiple})
+		}
+		if err := fsm.PlaceBet(); err != nil {
+			return nil, err
+		}
+	}
+
+	// 亮牌并结算
+	for _, p := range room.GetPlayers() {
+		cardType, _ := logic.CalculateBull(cardPointers(p.GetHand()))
+		emit(Event{Type: EVENT_SHOWDOWN, PlayerID: p.ID, Seat: p.GetSeat(), Hand: p.GetHand(), CardType: cardType})
+	}
+	if err := fsm.Showdown(); err != nil {
+		return nil, err
+	}
+	if err := fsm.Settlement(); err != nil {
+		return nil, err
+	}
+	replayed := fsm.GetLastResult()
+	for _, r := range replayed.Results {
+		emit(Event{Type: EVENT_SETTLE, PlayerID: r.PlayerID, Seat: r.Seat, ScoreChange: r.ScoreChange, Rake: r.Rake, FinalScore: r.FinalScore})
+	}
+
+	result.Divergences = compare(record, replayed)
+	return result, nil
+}
+
+// compare 逐项比对记录和复盘的结算结果
+func compare(record history.Record, replayed *logic.RoundResult) []Divergence {
+	divergences := make([]Divergence, 0)
+	diff := func(playerID int64, field string, recorded, replayed any) {
+		if !reflect.DeepEqual(recorded, replayed) {
+			divergences = append(divergences, Divergence{
+				PlayerID: playerID,
+				Field:    field,
+				Recorded: fmt.Sprint(recorded),
+				Replayed: fmt.Sprint(replayed),
+			})
+		}
+	}
+
+	diff(0, "banker", record.BankerID, replayed.BankerID)
+	results := make(map[int64]logic.PlayerResult, len(replayed.Results))
+	for _, r := range replayed.Results {
+		results[r.PlayerID] = r
+	}
+	for _, p := range record.Players {
+		r, exists := results[p.PlayerID]
+		if !exists {
+			diff(p.PlayerID, "participation", true, false)
+			continue
+		}
+		diff(p.PlayerID, "hand", p.Hand, r.Hand)
+		diff(p.PlayerID, "card type", p.CardType, r.CardType)
+		diff(p.PlayerID, "score change", p.ScoreChange, r.ScoreChange)
+		diff(p.PlayerID, "rake", p.Rake, r.Rake)
+		diff(p.PlayerID, "final score", p.FinalScore, r.FinalScore)
+	}
+	return divergences
+}
+
+// cardPointers 将手牌转换为牌型计算所需的指针切片
+func cardPointers(hand []logic.Card) []*logic.Card {
+	cards := make([]*logic.Card, len(hand))
+	for i := range hand {
+		cards[i] = &hand[i]
+	}
+	return cards
+}
@@ -0,0 +1,156 @@
+package replay
+
+import (
+	"testing"
+	"xizexcample/internal/history"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/wallet"
+)
+
+// playRecordedRound 在真实的房间里打完一局并返回牌局记录，p1 以2倍坐庄，p2、p3 分别下注3倍和1倍
+func playRecordedRound(t *testing.T, settings logic.RoomSettings) history.Record {
+	t.Helper()
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	room := logic.NewRoomWithSettings(901, settings)
+	defer room.Close()
+	room.SetWallet(w)
+	for i := int64(1); i <= 3; i++ {
+		p := logic.NewPlayer(i, "p", nil)
+		room.AddPlayer(p)
+		p.SetStatus(logic.STATUS_READY)
+	}
+
+	fsm := room.GetFSM()
+	if err := fsm.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	fsm.DealCards()
+	banker, _ := room.GetPlayer(1)
+	banker.SetBidMultiple(2)
+	room.SetBanker(1)
+	fsm.BidBanker()
+	p2, _ := room.GetPlayer(2)
+	p3, _ := room.GetPlayer(3)
+	p2.PlaceBet(3)
+	p3.PlaceBet(1)
+	fsm.PlaceBet()
+	fsm.Showdown()
+	if err := fsm.Settlement(); err != nil {
+		t.Fatalf("Settlement failed: %v", err)
+	}
+	return history.NewRecord(room.ID, room.Settings, fsm.GetLastResult())
+}
+
+func TestReplayMatchesRecord(t *testing.T) {
+	settings := logic.DefaultRoomSettings()
+	settings.BaseBet = 10
+	settings.Rake = logic.RakePolicy{Mode: logic.RAKE_PERCENT, Percent: 5}
+	record := playRecordedRound(t, settings)
+
+	result, err := Replay(record)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if result.Diverged() {
+		t.Fatalf("Expected replay to match the record, got %v", result.Divergences)
+	}
+
+	// 事件按顺序覆盖每个阶段：发牌 3 次、定庄 1 次、下注 2 次、亮牌 3 次、结算 3 次
+	counts := make(map[EventType]int)
+	for i, e := range result.Events {
+		if e.Seq != i+1 {
+			t.Errorf("Expected event %d to have sequence %d, got %d", i, i+1, e.Seq)
+		}
+		counts[e.Type]++
+	}
+	expected := map[EventType]int{EVENT_DEAL: 3, EVENT_BANKER: 1, EVENT_BET: 2, EVENT_SHOWDOWN: 3, EVENT_SETTLE: 3}
+	for eventType, count := range expected {
+		if counts[eventType] != count {
+			t.Errorf("Expected %d %s events, got %d", count, eventType, counts[eventType])
+		}
+	}
+	if counts[EVENT_STATE] == 0 {
+		t.Error("Expected state transition events")
+	}
+	if last := result.Events[len(result.Events)-1]; last.Type != EVENT_SETTLE {
+		t.Errorf("Expected the stream to end with settlement, got %s", last.Type)
+	}
+}
+
+func TestReplayFlagsDivergence(t *testing.T) {
+	record := playRecordedRound(t, logic.DefaultRoomSettings())
+
+	tampered := record
+	tampered.Players = append([]history.PlayerRecord(nil), record.Players...)
+	tampered.Players[1].ScoreChange++
+	result, err := Replay(tampered)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if !result.Diverged() || result.Divergences[0].PlayerID != 2 || result.Divergences[0].Field != "score change" {
+		t.Errorf("Expected a score change divergence for player 2, got %v", result.Divergences)
+	}
+
+	// 种子不同发出的手牌不同
+	reseeded := record
+	reseeded.ShuffleSeed++
+	result, _ = Replay(reseeded)
+	handDiverged := false
+	for _, d := range result.Divergences {
+		if d.Field == "hand" {
+			handDiverged = true
+		}
+	}
+	if !handDiverged {
+		t.Error("Expected a different shuffle seed to deal different hands")
+	}
+}
+
+func TestReplayRejectsIncompleteRecord(t *testing.T) {
+	if _, err := Replay(history.Record{Players: []history.PlayerRecord{{PlayerID: 1, Seat: 1}}}); err == nil {
+		t.Error("Expected error for a record with a single player, but got nil")
+	}
+}
+
+func TestReplayRuleSetsWithoutBidding(t *testing.T) {
+	for _, ruleSet := range []logic.RuleSet{logic.RULE_FIXED_BANKER, logic.RULE_ALL_COMPARE} {
+		settings := logic.DefaultRoomSettings()
+		settings.RuleSet = ruleSet
+		w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+		room := logic.NewRoomWithSettings(902, settings)
+		room.SetWallet(w)
+		for i := int64(1); i <= 3; i++ {
+			p := logic.NewPlayer(i, "p", nil)
+			room.AddPlayer(p)
+			p.SetStatus(logic.STATUS_READY)
+		}
+		room.SetOwner(3)
+
+		// 固定庄家由房主坐庄后直接下注，通比牛牛发牌后直接摊牌
+		fsm := room.GetFSM()
+		fsm.StartGame()
+		fsm.DealCards()
+		if fsm.GetCurrentState() == logic.STATE_BETTING {
+			for _, p := range room.GetPlayers() {
+				if !p.IsBanker() {
+					p.PlaceBet(2)
+				}
+			}
+			fsm.PlaceBet()
+		}
+		fsm.Showdown()
+		if err := fsm.Settlement(); err != nil {
+			t.Fatalf("rule set %d: Settlement failed: %v", ruleSet, err)
+		}
+		record := history.NewRecord(room.ID, room.Settings, fsm.GetLastResult())
+		room.Close()
+
+		result, err := Replay(record)
+		if err != nil {
+			t.Fatalf("rule set %d: Replay failed: %v", ruleSet, err)
+		}
+		if result.Diverged() {
+			t.Errorf("rule set %d: expected replay to match the record, got %v", ruleSet, result.Divergences)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package router
 import (
 	"encoding/json"
 	"github.com/aceld/zinx/ziface"
+	"time"
+	"xizexcample/internal/audit"
 	"xizexcample/internal/logic"
 	"xizexcample/internal/msg"
 	"xizexcample/internal/pkg/logger"
@@ -19,7 +21,7 @@ func (h *BidBankerHandler) Handle(request ziface.IRequest) {
 	var bidReq msg.C2S_BidBankerReq
 	err := json.Unmarshal(request.GetData(), &bidReq)
 	if err != nil {
-		logger.ErrorLogger.Printf("Failed to unmarshal bid banker request: %v", err)
+		requestLogger(request, nil).Error("Failed to unmarshal bid banker request", logger.Err(err))
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_BID_BANKER_ACK), "Invalid request data")
 		return
 	}
@@ -27,50 +29,109 @@ func (h *BidBankerHandler) Handle(request ziface.IRequest) {
 	// 2. 获取玩家和房间
 	targetPlayer, playerRoom, err := GetPlayerAndRoom(request)
 	if err != nil {
-		logger.ErrorLogger.Printf("Failed to get player and room: %v", err)
+		requestLogger(request, nil).Error("Failed to get player and room", logger.Err(err))
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_BID_BANKER_ACK), err.Error())
 		return
 	}
 
-	// 3. 检查游戏状态是否允许抢庄
-	if playerRoom.GetFSM().GetCurrentState() != logic.STATE_BIDDING {
+	// 3. 检查游戏状态是否允许抢庄，只有本局发到牌的玩家可以抢庄（强制开局时未准备的玩家不参与）
+	if !playerRoom.GetFSM().CanAct(logic.STATE_BIDDING) {
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_BID_BANKER_ACK), "Cannot bid banker at this time")
 		return
 	}
+	if targetPlayer.GetStatus() != logic.STATUS_PLAYING {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_BID_BANKER_ACK), "Player is not in this round")
+		return
+	}
 
-	// 4. 处理抢庄逻辑
-	if !playerRoom.HasBanker() {
-		playerRoom.SetBanker(targetPlayer.ID)
-		targetPlayer.SetBanker(true)
-		logger.InfoLogger.Printf("Player %d becomes the banker in room %d", targetPlayer.ID, playerRoom.ID)
-
-		// 5. 转换游戏状态到下注阶段
-		err = playerRoom.GetFSM().BidBanker()
-		if err != nil {
-			logger.ErrorLogger.Printf("Failed to transition to betting state in room %d: %v", playerRoom.ID, err)
-			sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_BID_BANKER_ACK), "Failed to transition to betting state")
-			return
-		}
+	// 4. 检查抢庄倍数是否允许，以及余额是否足够以该倍数坐庄
+	if err := playerRoom.CheckBidMultiple(targetPlayer, bidReq.Multiple); err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_BID_BANKER_ACK), err.Error())
+		return
+	}
 
-		// 6. 广播庄家信息
-		// broadcastBankerInfo(playerRoom) // TODO: S2C_BankerNtf is not defined
+	// 5. 记录抢庄倍数，每位玩家只能抢一次
+	if targetPlayer.HasBid() {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_BID_BANKER_ACK), "Player has already bid")
+		return
+	}
+	targetPlayer.PlaceBid(bidReq.Multiple)
+	requestLogger(request, playerRoom).Info("Player bid for banker", "multiple", bidReq.Multiple)
+	recordAudit(playerRoom, audit.ACTION_BID, targetPlayer.ID, audit.MultipleDetail{Multiple: bidReq.Multiple})
 
-		// 7. 发送确认响应
-		bidAck := &msg.S2C_BidBankerAck{
-			RetCode:  0,
-			PlayerId: int64(targetPlayer.ID),
-		}
-		ackData, err := json.Marshal(bidAck)
-		if err != nil {
-			sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_BID_BANKER_ACK), "Failed to marshal response")
-			return
-		}
-		request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_BID_BANKER_ACK), ackData)
+	// 6. 发送确认响应
+	bidAck := &msg.S2C_BidBankerAck{
+		RetCode:  0,
+		PlayerId: int64(targetPlayer.ID),
+	}
+	ackData, err := json.Marshal(bidAck)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_BID_BANKER_ACK), "Failed to marshal response")
+		return
+	}
+	request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_BID_BANKER_ACK), ackData)
+
+	// 7. 所有参与本局的玩家都已抢庄时选出庄家，否则广播抢庄进度，等待其他玩家或抢庄时限
+	if playerRoom.AllBidsPlaced() {
+		finishBidding(playerRoom)
+		return
+	}
+	broadcastRoomState(playerRoom)
+}
 
-		// 8. 广播房间状态更新
-		broadcastRoomState(playerRoom)
-	} else {
-		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_BID_BANKER_ACK), "Banker has already been chosen")
+// startBidTimer 开始抢庄阶段的计时，时限到时未抢庄的玩家视为不抢
+func startBidTimer(room *logic.Room) {
+	roundID := room.GetFSM().GetRoundID()
+	time.AfterFunc(logic.BidTimeout(), func() {
+		room.Do(func() {
+			expireBidding(room, roundID)
+		})
+	})
+}
+
+// expireBidding 抢庄时限到时结束仍在进行的抢庄，在房间的 Do 中调用，与玩家的请求依次执行；
+// 解散投票暂停了牌局时重新计时
+func expireBidding(room *logic.Room, roundID string) {
+	fsm := room.GetFSM()
+	if fsm.GetRoundID() != roundID || fsm.GetCurrentState() != logic.STATE_BIDDING {
+		return
+	}
+	if fsm.IsPaused() {
+		startBidTimer(room)
+		return
+	}
+	room.Logger().Info("Bid timer expired")
+	finishBidding(room)
+}
+
+// finishBidding 由抢庄倍数最高的玩家坐庄并进入下注阶段，向每位玩家推送可选的下注倍数
+func finishBidding(room *logic.Room) {
+	banker := room.ChooseBanker()
+	if banker == nil {
+		room.Logger().Error("No player to become the banker")
+		return
+	}
+	room.Logger().Info("Player becomes the banker", "player_id", banker.ID, "multiple", banker.GetBidMultiple())
+	recordAudit(room, audit.ACTION_BANKER, 0, audit.BankerDetail{BankerID: banker.ID, Multiple: banker.GetBidMultiple()})
+
+	if err := room.GetFSM().BidBanker(); err != nil {
+		room.Logger().Error("Failed to transition to betting state", logger.Err(err))
+		return
+	}
+
+	// broadcastBankerInfo(room) // TODO: S2C_BankerNtf is not defined
+	broadcastRoomState(room)
+	for _, p := range room.GetPlayers() {
+		sendBetOptions(room, p)
+	}
+}
+
+// ResumeBidTimers 为从快照恢复时仍在抢庄阶段的房间重新开始抢庄计时，应在恢复房间后调用
+func ResumeBidTimers(rooms []*logic.Room) {
+	for _, room := range rooms {
+		if room.GetFSM().GetCurrentState() == logic.STATE_BIDDING {
+			startBidTimer(room)
+		}
 	}
 }
 
@@ -0,0 +1,176 @@
+package router
+
+import (
+	"encoding/json"
+	"github.com/aceld/zinx/ziface"
+	"time"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/pkg/logger"
+)
+
+// DissolveProposeHandler 处理发起解散房间投票请求
+type DissolveProposeHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *DissolveProposeHandler) Handle(request ziface.IRequest) {
+	// 1. 获取玩家和房间
+	player, room, err := GetPlayerAndRoom(request)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_DISSOLVE_PROPOSE_ACK), err.Error())
+		return
+	}
+
+	// 2. 发起投票，牌局在投票期间暂停
+	timeout := logic.DissolveVoteTimeout()
+	outcome, err := room.ProposeDissolve(player.ID, timeout)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_DISSOLVE_PROPOSE_ACK), err.Error())
+		return
+	}
+	requestLogger(request, room).Info("Player proposed dissolving room")
+
+	// 3. 发送确认响应
+	sendDissolveAck(request.GetConnection(), msg.MsgID_S2C_DISSOLVE_PROPOSE_ACK)
+
+	// 4. 投票已有结果（例如房间里只有发起者）则直接结束，否则广播投票进度并等待截止
+	if outcome != logic.DISSOLVE_PENDING {
+		finishDissolveVote(room, outcome)
+		return
+	}
+	broadcastDissolveVote(room)
+	time.AfterFunc(timeout, func() {
+		room.Do(func() {
+			expireDissolveVote(room)
+		})
+	})
+}
+
+// DissolveVoteHandler 处理解散房间投票请求
+type DissolveVoteHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *DissolveVoteHandler) Handle(request ziface.IRequest) {
+	// 1. 解析客户端请求
+	var voteReq msg.C2S_DissolveVoteReq
+	err := json.Unmarshal(request.GetData(), &voteReq)
+	if err != nil {
+		requestLogger(request, nil).Error("Failed to unmarshal dissolve vote request", logger.Err(err))
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_DISSOLVE_VOTE_ACK), "Invalid request data")
+		return
+	}
+
+	// 2. 获取玩家和房间
+	player, room, err := GetPlayerAndRoom(request)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_DISSOLVE_VOTE_ACK), err.Error())
+		return
+	}
+
+	// 3. 投票
+	outcome, err := room.CastDissolveVote(player.ID, voteReq.Agree)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_DISSOLVE_VOTE_ACK), err.Error())
+		return
+	}
+	requestLogger(request, room).Info("Player voted on dissolving room", "agree", voteReq.Agree)
+
+	// 4. 发送确认响应，投票结束则处理结果，否则广播投票进度
+	sendDissolveAck(request.GetConnection(), msg.MsgID_S2C_DISSOLVE_VOTE_ACK)
+	if outcome != logic.DISSOLVE_PENDING {
+		finishDissolveVote(room, outcome)
+		return
+	}
+	broadcastDissolveVote(room)
+}
+
+// expireDissolveVote 投票截止时结束仍在进行的投票，在房间的 Do 中调用，与玩家的请求依次执行
+func expireDissolveVote(room *logic.Room) {
+	if outcome := room.ExpireDissolveVote(time.Now()); outcome != logic.DISSOLVE_PENDING {
+		room.Logger().Info("Dissolve vote expired")
+		finishDissolveVote(room, outcome)
+	}
+}
+
+// onDissolveDecided 投票者离开（主动离开、被踢出或断线超时）使解散投票结束时广播结果，
+// 在移除玩家的房间操作中调用
+func onDissolveDecided(room *logic.Room, outcome logic.DissolveOutcome) {
+	room.Logger().Info("Dissolve vote decided after a voter left")
+	finishDissolveVote(room, outcome)
+}
+
+// finishDissolveVote 广播投票结果；解散成功时附带最终分数并结束本场对局，否则牌局继续
+func finishDissolveVote(room *logic.Room, outcome logic.DissolveOutcome) {
+	if outcome != logic.DISSOLVE_PASSED {
+		resultNtf := &msg.S2C_DissolveResultNtf{Dissolved: false}
+		resultData, _ := json.Marshal(resultNtf)
+		broadcastToRoom(room, msg.MsgID_S2C_DISSOLVE_RESULT_NTF, resultData)
+		room.Logger().Info("Dissolve vote rejected, play resumes")
+		broadcastRoomState(room)
+		return
+	}
+
+	room.Logger().Info("Room dissolved by vote")
+	dissolveRoom(room)
+}
+
+// dissolveRoom 推送解散结果和各玩家的最终分数，然后结束本场对局
+func dissolveRoom(room *logic.Room) {
+	resultNtf := &msg.S2C_DissolveResultNtf{Dissolved: true}
+	for _, p := range room.GetPlayers() {
+		resultNtf.Players = append(resultNtf.Players, &msg.PlayerInfo{
+			PlayerId: p.ID,
+			Nickname: p.Nickname,
+			Score:    p.GetScore(),
+			Seat:     int32(p.GetSeat()),
+		})
+	}
+	resultData, _ := json.Marshal(resultNtf)
+	broadcastToRoom(room, msg.MsgID_S2C_DISSOLVE_RESULT_NTF, resultData)
+	endSession(room)
+}
+
+// buildDissolveVoteInfo 构建解散投票进度，没有进行中的投票时返回 nil
+func buildDissolveVoteInfo(room *logic.Room) *msg.DissolveVoteInfo {
+	vote := room.GetDissolveVote()
+	if vote == nil {
+		return nil
+	}
+
+	info := &msg.DissolveVoteInfo{
+		ProposerId: vote.ProposerID,
+		Deadline:   vote.Deadline.Unix(),
+		Pending:    vote.Pending(),
+		Rule:       msg.DissolveRule(vote.Rule),
+	}
+	for _, playerID := range vote.Voters {
+		agree, voted := vote.Ballots[playerID]
+		switch {
+		case voted && agree:
+			info.Agreed = append(info.Agreed, playerID)
+		case voted:
+			info.Refused = append(info.Refused, playerID)
+		}
+	}
+	return info
+}
+
+// broadcastDissolveVote 广播解散投票进度
+func broadcastDissolveVote(room *logic.Room) {
+	ntf := &msg.S2C_DissolveVoteNtf{
+		Vote: buildDissolveVoteInfo(room),
+	}
+	ntfData, _ := json.Marshal(ntf)
+	broadcastToRoom(room, msg.MsgID_S2C_DISSOLVE_VOTE_NTF, ntfData)
+}
+
+// sendDissolveAck 发送解散投票操作成功响应
+func sendDissolveAck(conn ziface.IConnection, msgID msg.MsgID) {
+	ack := &msg.S2C_DissolveAck{RetCode: 0}
+	ackData, _ := json.Marshal(ack)
+	conn.SendMsg(uint32(msgID), ackData)
+}
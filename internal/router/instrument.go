@@ -0,0 +1,38 @@
+package router
+
+import (
+	"github.com/aceld/zinx/ziface"
+	"time"
+	"xizexcample/internal/metrics"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/pkg/logger"
+)
+
+// instrumentedRouter 统计请求数和处理耗时的路由包装
+type instrumentedRouter struct {
+	ziface.IRouter
+	msgID string
+}
+
+// Handle 处理请求并记录耗时
+func (r *instrumentedRouter) Handle(request ziface.IRequest) {
+	start := time.Now()
+	r.IRouter.Handle(request)
+	metrics.RequestDuration.Observe(time.Since(start).Seconds(), r.msgID)
+	metrics.RequestsTotal.Inc(r.msgID)
+	logger.L().Debug("Request handled",
+		logger.ConnID(request.GetConnection().GetConnID()),
+		logger.MsgID(r.msgID),
+		"duration", time.Since(start),
+	)
+}
+
+// addRouter 为消息ID注册处理器，处理器会被包装以统计请求数和处理耗时
+func addRouter(server ziface.IServer, msgID msg.MsgID, router ziface.IRouter) {
+	server.AddRouter(uint32(msgID), &instrumentedRouter{IRouter: router, msgID: msgID.String()})
+}
+
+// addRoomRouter 为房间内的操作注册处理器，处理器在玩家所在房间的 Do 中执行
+func addRoomRouter(server ziface.IServer, msgID msg.MsgID, router ziface.IRouter) {
+	addRouter(server, msgID, &roomRouter{IRouter: router})
+}
@@ -2,9 +2,10 @@ package router
 
 import (
 	"encoding/json"
-	"fmt"
 	"github.com/aceld/zinx/ziface"
+	"xizexcample/internal/audit"
 	"xizexcample/internal/logic"
+	"xizexcample/internal/metrics"
 	"xizexcample/internal/msg"
 	"xizexcample/internal/pkg/logger"
 	"xizexcample/internal/server"
@@ -21,36 +22,61 @@ func (h *JoinRoomHandler) Handle(request ziface.IRequest) {
 	var joinReq msg.C2S_JoinRoomReq
 	err := json.Unmarshal(request.GetData(), &joinReq)
 	if err != nil {
-		logger.ErrorLogger.Printf("Failed to unmarshal join room request: %v", err)
+		requestLogger(request, nil).Error("Failed to unmarshal join room request", logger.Err(err))
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_JOIN_ROOM_ACK), "Invalid request data")
 		return
 	}
-	logger.InfoLogger.Printf("Player %d requests to join room %d", request.GetConnection().GetConnID(), joinReq.RoomId)
+	requestLogger(request, nil).Info("Player requests to join room", logger.RoomID(joinReq.RoomId))
 
-	// 2. 获取或创建房间
+	// 2. 获取房间，房间需通过大厅创建；未指定房间ID时按邀请码查找
 	roomManager := server.GetRoomManager()
-	room, err := roomManager.GetRoom(int32(joinReq.RoomId))
+	var room *logic.Room
+	if joinReq.RoomId == 0 && joinReq.InviteCode != "" {
+		room, err = roomManager.GetRoomByInviteCode(joinReq.InviteCode)
+	} else {
+		room, err = roomManager.GetRoom(int32(joinReq.RoomId))
+	}
 	if err != nil {
-		// 房间不存在，尝试创建
-		room, err = roomManager.CreateRoom(int32(joinReq.RoomId))
-		if err != nil {
-			logger.ErrorLogger.Printf("Failed to create room %d: %v", joinReq.RoomId, err)
-			sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_JOIN_ROOM_ACK), "Failed to create room")
-			return
-		}
-		logger.InfoLogger.Printf("Room %d created", joinReq.RoomId)
+		requestLogger(request, nil).Error("Room not found", logger.RoomID(joinReq.RoomId), "invite_code", joinReq.InviteCode, logger.Err(err))
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_JOIN_ROOM_ACK), "Room not found")
+		return
+	}
+
+	// 3. 已在其他房间中的玩家不能再加入
+	if current := roomManager.GetRoomByPlayerID(connPlayerID(request.GetConnection())); current != nil && current != room {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_JOIN_ROOM_ACK), "Player already in a room")
+		return
 	}
 
-	// 3. 检查是否是重连
-	playerID := request.GetConnection().GetConnID() // 在实际应用中，应该从请求中获取真实的玩家ID
-	existingPlayer, err := room.GetPlayer(int64(playerID))
-	if err == nil && !existingPlayer.IsOnline() {
+	// 4. 在房间的 Do 中加入，与房间内的其他操作依次执行
+	room.Do(func() {
+		joinRoom(request, room, &joinReq)
+	})
+}
+
+// joinRoom 让玩家重连、入座或观战，调用方需在房间的 Do 中调用
+func joinRoom(request ziface.IRequest, room *logic.Room, joinReq *msg.C2S_JoinRoomReq) {
+	roomManager := server.GetRoomManager()
+
+	// 1. 检查是否是重连，游客的连接断开后就不能再回到原来的座位
+	playerID := connPlayerID(request.GetConnection())
+	existingPlayer, err := room.GetPlayer(playerID)
+	if err == nil && !existingPlayer.IsOnline() && !logic.IsGuest(playerID) {
 		// 是重连玩家
-		logger.InfoLogger.Printf("Player %d reconnected to room %d", playerID, room.ID)
+		requestLogger(request, room).Info("Player reconnected to room")
+		metrics.ReconnectsTotal.Inc()
 		// T037: Re-associate connection with the existing Player object
 		existingPlayer.Conn = request.GetConnection()
 		existingPlayer.SetOnline(true)
-		existingPlayer.SetStatus(logic.STATUS_PLAYING) // Or whatever the status was
+		request.GetConnection().SetProperty("playerID", playerID)
+		roomManager.RegisterPlayer(playerID, room.ID)
+		recordAudit(room, audit.ACTION_JOIN, playerID, audit.JoinDetail{Seat: existingPlayer.GetSeat(), Reconnect: true})
+		// 本局已发牌的玩家继续参与牌局，否则等待下一局
+		if len(existingPlayer.GetHand()) > 0 {
+			existingPlayer.SetStatus(logic.STATUS_PLAYING)
+		} else {
+			existingPlayer.SetStatus(logic.STATUS_WAITING)
+		}
 
 		// T038: Send a full room state sync message to the reconnected player
 		sendFullRoomState(existingPlayer)
@@ -60,44 +86,41 @@ func (h *JoinRoomHandler) Handle(request ziface.IRequest) {
 		return
 	}
 
-	// 4. 创建新玩家对象
-	player := logic.NewPlayer(int64(playerID), fmt.Sprintf("Player%d", playerID), request.GetConnection())
+	// 2. 停服排空期间只允许重连，不再接受新玩家加入
+	if roomManager.IsDraining() {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_JOIN_ROOM_ACK), "Server is shutting down")
+		return
+	}
+
+	// 3. 私人房间需要校验邀请码和密码
+	if room.Settings.IsPrivate {
+		if joinReq.InviteCode != room.InviteCode {
+			sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_JOIN_ROOM_ACK), "Invalid invite code")
+			return
+		}
+		if room.HasPassword() && !room.CheckPassword(joinReq.Password) {
+			sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_JOIN_ROOM_ACK), "Incorrect room password")
+			return
+		}
+	}
 
-	// 5. 将玩家加入房间
-	err = room.AddPlayer(player)
+	// 4. 创建新玩家对象，以玩家或观战者身份加入房间
+	var player *logic.Player
+	if joinReq.AsSpectator {
+		player, err = addSpectatorToRoom(request.GetConnection(), room)
+	} else {
+		player, err = addPlayerToRoom(request.GetConnection(), room, int(joinReq.Seat))
+	}
 	if err != nil {
-		logger.ErrorLogger.Printf("Failed to add player %d to room %d: %v", player.ID, room.ID, err)
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_JOIN_ROOM_ACK), err.Error())
 		return
 	}
-	logger.InfoLogger.Printf("Player %d joined room %d", player.ID, room.ID)
-
-	// 将 playerID 设置到连接属性中
-	request.GetConnection().SetProperty("playerID", player.ID)
-	roomManager.RegisterPlayer(player.ID, room.ID)
 
-	// 6. 准备并发送成功响应
+	// 5. 准备并发送成功响应
 	joinAck := &msg.S2C_JoinRoomAck{
-		RetCode: 0,
-		RoomInfo: &msg.RoomInfo{
-			RoomId:    room.ID,
-			Players:   []*msg.PlayerInfo{},
-			GameState: msg.GameState(room.GetFSM().GetCurrentState()),
-			BankerId:  room.GetBankerID(),
-		},
-	}
-
-	for _, p := range room.GetPlayers() {
-		playerInfo := &msg.PlayerInfo{
-			PlayerId: p.ID,
-			Nickname: p.Nickname,
-			Score:    p.Score,
-			Status:   msg.PlayerStatus(p.GetStatus()),
-			IsBanker: p.IsBanker(),
-		}
-		joinAck.RoomInfo.Players = append(joinAck.RoomInfo.Players, playerInfo)
+		RetCode:  0,
+		RoomInfo: buildRoomInfo(room, player.ID),
 	}
-
 	ackData, err := json.Marshal(joinAck)
 	if err != nil {
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_JOIN_ROOM_ACK), "Failed to marshal response")
@@ -106,6 +129,43 @@ func (h *JoinRoomHandler) Handle(request ziface.IRequest) {
 
 	request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_JOIN_ROOM_ACK), ackData)
 
-	// 7. 广播房间状态更新给所有玩家
+	// 6. 广播房间状态更新给所有玩家
 	broadcastRoomState(room)
 }
+
+// addSpectatorToRoom 为连接创建玩家对象，以观战者身份加入房间并在 RoomManager 中登记
+func addSpectatorToRoom(conn ziface.IConnection, room *logic.Room) (*logic.Player, error) {
+	playerID := connPlayerID(conn)
+	spectator := logic.NewPlayer(playerID, connNickname(conn), conn)
+
+	err := room.AddSpectator(spectator)
+	if err != nil {
+		room.Logger().Error("Failed to add spectator", logger.PlayerID(spectator.ID), logger.Err(err))
+		return nil, err
+	}
+	room.Logger().Info("Player is spectating room", logger.PlayerID(spectator.ID))
+	recordAudit(room, audit.ACTION_JOIN, spectator.ID, audit.JoinDetail{Spectator: true})
+
+	conn.SetProperty("playerID", spectator.ID)
+	server.GetRoomManager().RegisterPlayer(spectator.ID, room.ID)
+	return spectator, nil
+}
+
+// addPlayerToRoom 为连接创建玩家对象，坐到指定座位（0 表示自动分配）并在 RoomManager 中登记
+func addPlayerToRoom(conn ziface.IConnection, room *logic.Room, seat int) (*logic.Player, error) {
+	playerID := connPlayerID(conn)
+	player := logic.NewPlayer(playerID, connNickname(conn), conn)
+
+	err := room.AddPlayerAtSeat(player, seat)
+	if err != nil {
+		room.Logger().Error("Failed to add player", logger.PlayerID(player.ID), logger.Err(err))
+		return nil, err
+	}
+	room.Logger().Info("Player joined room", logger.PlayerID(player.ID), "seat", player.GetSeat())
+	recordAudit(room, audit.ACTION_JOIN, player.ID, audit.JoinDetail{Seat: player.GetSeat()})
+
+	// 将 playerID 设置到连接属性中
+	conn.SetProperty("playerID", player.ID)
+	server.GetRoomManager().RegisterPlayer(player.ID, room.ID)
+	return player, nil
+}
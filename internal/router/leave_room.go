@@ -0,0 +1,68 @@
+package router
+
+import (
+	"encoding/json"
+	"github.com/aceld/zinx/ziface"
+	"xizexcample/internal/audit"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/server"
+)
+
+// LeaveRoomHandler 处理离开房间请求
+type LeaveRoomHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *LeaveRoomHandler) Handle(request ziface.IRequest) {
+	// 1. 获取玩家所在房间
+	playerID, err := request.GetConnection().GetProperty("playerID")
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_LEAVE_ROOM_ACK), "player not logged in")
+		return
+	}
+	roomManager := server.GetRoomManager()
+	room := roomManager.GetRoomByPlayerID(playerID.(int64))
+	if room == nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_LEAVE_ROOM_ACK), "player not in any room")
+		return
+	}
+
+	// 2. 观战者可以随时离开，玩家只能在不参与牌局时离开
+	spectator := room.IsSpectator(playerID.(int64))
+	if spectator {
+		err = room.RemoveSpectator(playerID.(int64))
+	} else {
+		player, getErr := room.GetPlayer(playerID.(int64))
+		if getErr == nil && player.GetStatus() == logic.STATUS_PLAYING {
+			sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_LEAVE_ROOM_ACK), "Cannot leave during a round")
+			return
+		}
+		err = room.RemovePlayer(playerID.(int64))
+	}
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_LEAVE_ROOM_ACK), err.Error())
+		return
+	}
+	roomManager.UnregisterPlayer(playerID.(int64))
+	requestLogger(request, room).Info("Player left room")
+	recordAudit(room, audit.ACTION_LEAVE, playerID.(int64), audit.LeaveDetail{Spectator: spectator})
+
+	// 3. 发送确认响应
+	leaveAck := &msg.S2C_LeaveRoomAck{RetCode: 0}
+	ackData, _ := json.Marshal(leaveAck)
+	request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_LEAVE_ROOM_ACK), ackData)
+
+	// 4. 离开使解散投票通过时房间已经关闭；房间空了则关闭，否则通知其他人
+	if room.IsDissolved() {
+		return
+	}
+	if room.GetPlayerCount() == 0 && room.GetSpectatorCount() == 0 {
+		roomManager.DeleteRoom(room.ID)
+		room.Logger().Info("Room closed as it is empty")
+		return
+	}
+	broadcastPlayerLeave(room, playerID.(int64))
+	broadcastRoomState(room)
+}
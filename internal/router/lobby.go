@@ -0,0 +1,124 @@
+package router
+
+import (
+	"encoding/json"
+	"github.com/aceld/zinx/ziface"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/server"
+)
+
+// RoomListHandler 处理大厅房间列表请求
+type RoomListHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *RoomListHandler) Handle(request ziface.IRequest) {
+	// 1. 解析客户端请求
+	var listReq msg.C2S_RoomListReq
+	err := json.Unmarshal(request.GetData(), &listReq)
+	if err != nil {
+		requestLogger(request, nil).Error("Failed to unmarshal room list request", logger.Err(err))
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_ROOM_LIST_ACK), "Invalid request data")
+		return
+	}
+
+	// 2. 按过滤条件查询房间
+	rooms := server.GetRoomManager().ListRooms(server.RoomFilter{
+		MinBaseBet:    listReq.MinBaseBet,
+		MaxBaseBet:    listReq.MaxBaseBet,
+		RuleSet:       logic.RuleSet(listReq.RuleSet),
+		OnlyAvailable: listReq.OnlyAvailable,
+	})
+
+	// 3. 发送房间列表
+	listAck := &msg.S2C_RoomListAck{
+		RetCode: 0,
+		Rooms:   make([]*msg.RoomSummary, 0, len(rooms)),
+	}
+	for _, room := range rooms {
+		listAck.Rooms = append(listAck.Rooms, buildRoomSummary(room))
+	}
+	ackData, err := json.Marshal(listAck)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_ROOM_LIST_ACK), "Failed to marshal response")
+		return
+	}
+	request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_ROOM_LIST_ACK), ackData)
+}
+
+// CreateRoomHandler 处理创建房间请求，创建者自动加入新房间
+type CreateRoomHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *CreateRoomHandler) Handle(request ziface.IRequest) {
+	// 1. 解析客户端请求
+	var createReq msg.C2S_CreateRoomReq
+	err := json.Unmarshal(request.GetData(), &createReq)
+	if err != nil {
+		requestLogger(request, nil).Error("Failed to unmarshal create room request", logger.Err(err))
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_CREATE_ROOM_ACK), "Invalid request data")
+		return
+	}
+
+	// 2. 已在房间中的玩家不能再创建房间
+	if playerID, err := request.GetConnection().GetProperty("playerID"); err == nil {
+		if server.GetRoomManager().GetRoomByPlayerID(playerID.(int64)) != nil {
+			sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_CREATE_ROOM_ACK), "Player already in a room")
+			return
+		}
+	}
+
+	// 3. 创建房间，房间ID由 RoomManager 分配，只有私人房间可以设置密码
+	roomManager := server.GetRoomManager()
+	room, err := roomManager.CreateRoomWithSettings(fromMsgRoomSettings(createReq.Settings), createReq.Password)
+	if err != nil {
+		requestLogger(request, nil).Error("Failed to create room", logger.Err(err))
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_CREATE_ROOM_ACK), err.Error())
+		return
+	}
+	requestLogger(request, room).Info("Room created", "settings", room.Settings)
+
+	// 4. 创建者加入房间并成为房主，房间已在大厅中可见，需要与其他玩家的加入依次执行
+	var player *logic.Player
+	room.Do(func() {
+		player, err = addPlayerToRoom(request.GetConnection(), room, 0)
+		if err == nil {
+			room.SetOwner(player.ID)
+		}
+	})
+	if err != nil {
+		roomManager.DeleteRoom(room.ID)
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_CREATE_ROOM_ACK), err.Error())
+		return
+	}
+
+	// 5. 发送成功响应
+	createAck := &msg.S2C_CreateRoomAck{
+		RetCode:  0,
+		RoomInfo: buildRoomInfo(room, player.ID),
+	}
+	ackData, err := json.Marshal(createAck)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_CREATE_ROOM_ACK), "Failed to marshal response")
+		return
+	}
+	request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_CREATE_ROOM_ACK), ackData)
+}
+
+// buildRoomSummary 构建大厅中展示的房间摘要
+func buildRoomSummary(room *logic.Room) *msg.RoomSummary {
+	return &msg.RoomSummary{
+		RoomId:      room.ID,
+		PlayerCount: int32(room.GetPlayerCount()),
+		MaxPlayers:  int32(room.Settings.MaxPlayers),
+		BaseBet:     room.Settings.BaseBet,
+		RuleSet:     msg.RuleSet(room.Settings.RuleSet),
+		Rounds:      int32(room.Settings.Rounds),
+		GameState:   msg.GameState(room.GetFSM().GetCurrentState()),
+	}
+}
@@ -0,0 +1,89 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/aceld/zinx/ziface"
+	"time"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/profile"
+	"xizexcample/internal/wallet"
+)
+
+// LoginHandler 处理登录请求
+type LoginHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *LoginHandler) Handle(request ziface.IRequest) {
+	// 1. 解析客户端请求
+	var loginReq msg.C2S_LoginReq
+	err := json.Unmarshal(request.GetData(), &loginReq)
+	if err != nil {
+		requestLogger(request, nil).Error("Failed to unmarshal login request", logger.Err(err))
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_LOGIN_ACK), "Invalid request data")
+		return
+	}
+
+	// 2. 已经登录、进入房间或开始排队的连接不能再切换身份
+	conn := request.GetConnection()
+	if _, err := conn.GetProperty("playerID"); err == nil {
+		sendErrorResponse(conn, uint32(msg.MsgID_S2C_LOGIN_ACK), "Already logged in")
+		return
+	}
+
+	// 3. 校验账号服务签发的登录令牌，未配置签名密钥时不允许登录
+	auth := profile.GetAuthenticator()
+	if auth == nil {
+		sendErrorResponse(conn, uint32(msg.MsgID_S2C_LOGIN_ACK), "Login is not available")
+		return
+	}
+	if err := auth.Verify(loginReq.PlayerId, loginReq.Token, time.Now()); err != nil {
+		requestLogger(request, nil).Warn("Rejected login with an invalid token", "login_player_id", loginReq.PlayerId)
+		sendErrorResponse(conn, uint32(msg.MsgID_S2C_LOGIN_ACK), "Invalid login token")
+		return
+	}
+
+	// 4. 加载玩家档案，新玩家创建档案并开户
+	p, err := profile.Login(profile.GetRepository(), wallet.GetWallet(), loginReq.PlayerId, loginReq.Nickname)
+	if err != nil {
+		requestLogger(request, nil).Error("Player failed to log in", "login_player_id", loginReq.PlayerId, logger.Err(err))
+		message := "Login failed"
+		if errors.Is(err, profile.ErrBanned) {
+			message = "Player is banned"
+		}
+		sendErrorResponse(conn, uint32(msg.MsgID_S2C_LOGIN_ACK), message)
+		return
+	}
+	conn.SetProperty("playerID", p.PlayerID)
+	conn.SetProperty("nickname", p.Nickname)
+	requestLogger(request, nil).Info("Player logged in", "nickname", p.Nickname, "balance", p.Balance)
+
+	// 5. 发送登录响应
+	loginAck := &msg.S2C_LoginAck{
+		RetCode: 0,
+		Profile: toMsgProfile(p),
+	}
+	ackData, err := json.Marshal(loginAck)
+	if err != nil {
+		sendErrorResponse(conn, uint32(msg.MsgID_S2C_LOGIN_ACK), "Failed to marshal response")
+		return
+	}
+	conn.SendMsg(uint32(msg.MsgID_S2C_LOGIN_ACK), ackData)
+}
+
+// toMsgProfile 将玩家档案转换为消息格式
+func toMsgProfile(p *profile.Profile) *msg.ProfileInfo {
+	return &msg.ProfileInfo{
+		PlayerId:     p.PlayerID,
+		Nickname:     p.Nickname,
+		Balance:      p.Balance,
+		RoundsPlayed: int32(p.Stats.RoundsPlayed),
+		RoundsWon:    int32(p.Stats.RoundsWon),
+		TotalWon:     p.Stats.TotalWon,
+		TotalLost:    p.Stats.TotalLost,
+		BiggestWin:   p.Stats.BiggestWin,
+	}
+}
@@ -0,0 +1,64 @@
+package router
+
+import (
+	"encoding/json"
+	"github.com/aceld/zinx/ziface"
+	"time"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/server"
+)
+
+// broadcastToAll 向服务器上的所有连接发送消息，返回发送成功的连接数
+func broadcastToAll(s ziface.IServer, msgID msg.MsgID, data []byte) int {
+	sent := 0
+	s.GetConnMgr().Range(func(_ uint64, conn ziface.IConnection, _ interface{}) error {
+		if err := conn.SendMsg(uint32(msgID), data); err == nil {
+			sent++
+		}
+		return nil
+	}, nil)
+	return sent
+}
+
+// BroadcastMaintenance 向所有连接推送维护通知，active 为 false 表示维护结束；
+// shutdownAt 为零值时表示维护期间不停服。返回收到通知的连接数
+func BroadcastMaintenance(s ziface.IServer, active bool, message string, shutdownAt time.Time) int {
+	ntf := &msg.S2C_MaintenanceNtf{
+		Message: message,
+		Active:  active,
+	}
+	if !shutdownAt.IsZero() {
+		ntf.ShutdownAt = shutdownAt.Unix()
+	}
+	ntfData, _ := json.Marshal(ntf)
+	sent := broadcastToAll(s, msg.MsgID_S2C_MAINTENANCE_NTF, ntfData)
+	logger.L().Info("Maintenance notice sent", "active", active, "connections", sent)
+	return sent
+}
+
+// BroadcastAnnouncement 向所有连接推送系统公告，返回收到公告的连接数
+func BroadcastAnnouncement(s ziface.IServer, message string) int {
+	ntf := &msg.S2C_AnnouncementNtf{
+		Message: message,
+		SentAt:  time.Now().Unix(),
+	}
+	ntfData, _ := json.Marshal(ntf)
+	sent := broadcastToAll(s, msg.MsgID_S2C_ANNOUNCEMENT_NTF, ntfData)
+	logger.L().Info("Announcement sent", "message", message, "connections", sent)
+	return sent
+}
+
+// ResumeRounds 维护结束后，为所有玩家都已准备好的房间开始新的一局，返回开局的房间数
+func ResumeRounds() int {
+	started := 0
+	for _, room := range server.GetRoomManager().GetAllRooms() {
+		room.Do(func() {
+			if tryStartRound(room) {
+				broadcastRoomState(room)
+				started++
+			}
+		})
+	}
+	return started
+}
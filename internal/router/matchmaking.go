@@ -0,0 +1,124 @@
+package router
+
+import (
+	"encoding/json"
+	"github.com/aceld/zinx/ziface"
+	"time"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/server"
+	"xizexcample/internal/wallet"
+)
+
+// QuickMatchHandler 处理快速匹配请求
+type QuickMatchHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *QuickMatchHandler) Handle(request ziface.IRequest) {
+	// 1. 解析客户端请求
+	var matchReq msg.C2S_QuickMatchReq
+	err := json.Unmarshal(request.GetData(), &matchReq)
+	if err != nil {
+		requestLogger(request, nil).Error("Failed to unmarshal quick match request", logger.Err(err))
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_QUICK_MATCH_ACK), "Invalid request data")
+		return
+	}
+
+	// 2. 已在房间中的玩家不能排队
+	playerID := connPlayerID(request.GetConnection())
+	if server.GetRoomManager().GetRoomByPlayerID(playerID) != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_QUICK_MATCH_ACK), "Player already in a room")
+		return
+	}
+
+	// 3. 按钱包余额加入匹配队列，新玩家先开户
+	balance, err := wallet.GetWallet().OpenAccount(playerID, logic.InitialScore)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_QUICK_MATCH_ACK), err.Error())
+		return
+	}
+	position, err := server.GetMatchmaker().Enqueue(&server.MatchTicket{
+		PlayerID: playerID,
+		Balance:  balance,
+		TierID:   matchReq.TierId,
+		Conn:     request.GetConnection(),
+	})
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_QUICK_MATCH_ACK), err.Error())
+		return
+	}
+	// 将 playerID 设置到连接属性中，断线时用于移出队列
+	request.GetConnection().SetProperty("playerID", playerID)
+	requestLogger(request, nil).Info("Player queued for quick match", "tier", matchReq.TierId, "position", position)
+
+	// 4. 发送确认响应
+	matchAck := &msg.S2C_QuickMatchAck{
+		RetCode:  0,
+		TierId:   matchReq.TierId,
+		Position: int32(position),
+	}
+	ackData, err := json.Marshal(matchAck)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_QUICK_MATCH_ACK), "Failed to marshal response")
+		return
+	}
+	request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_QUICK_MATCH_ACK), ackData)
+}
+
+// CancelMatchHandler 处理取消匹配请求
+type CancelMatchHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *CancelMatchHandler) Handle(request ziface.IRequest) {
+	playerID := connPlayerID(request.GetConnection())
+	if err := server.GetMatchmaker().Cancel(playerID); err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_CANCEL_MATCH_ACK), err.Error())
+		return
+	}
+	requestLogger(request, nil).Info("Player left the match queue")
+
+	cancelAck := &msg.S2C_CancelMatchAck{RetCode: 0}
+	ackData, _ := json.Marshal(cancelAck)
+	request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_CANCEL_MATCH_ACK), ackData)
+}
+
+// onMatchFound 匹配成功时把玩家加入房间并通知，在匹配协程中调用
+func onMatchFound(ticket *server.MatchTicket, room *logic.Room) error {
+	var err error
+	room.Do(func() {
+		err = seatMatchedPlayer(ticket, room)
+	})
+	return err
+}
+
+// seatMatchedPlayer 把匹配到的玩家加入房间并通知，调用方需在房间的 Do 中调用
+func seatMatchedPlayer(ticket *server.MatchTicket, room *logic.Room) error {
+	if _, err := addPlayerToRoom(ticket.Conn, room, 0); err != nil {
+		return err
+	}
+
+	ntf := &msg.S2C_MatchFoundNtf{
+		RoomInfo: buildRoomInfo(room, ticket.PlayerID),
+	}
+	ntfData, _ := json.Marshal(ntf)
+	ticket.Conn.SendMsg(uint32(msg.MsgID_S2C_MATCH_FOUND_NTF), ntfData)
+
+	broadcastRoomState(room)
+	return nil
+}
+
+// onMatchQueueUpdate 推送排队位置变化
+func onMatchQueueUpdate(ticket *server.MatchTicket, position int) {
+	ntf := &msg.S2C_MatchQueueNtf{
+		TierId:      ticket.TierID,
+		Position:    int32(position),
+		WaitSeconds: int32(time.Since(ticket.EnqueuedAt).Seconds()),
+	}
+	ntfData, _ := json.Marshal(ntf)
+	ticket.Conn.SendMsg(uint32(msg.MsgID_S2C_MATCH_QUEUE_NTF), ntfData)
+}
@@ -0,0 +1,105 @@
+package router
+
+import (
+	"errors"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/server"
+	"xizexcample/internal/wallet"
+)
+
+// ErrInActiveRound 玩家正在参与一局尚未结算的牌局
+var ErrInActiveRound = errors.New("player is in a round in progress")
+
+// RemoveFromRoom 由管理员将玩家或观战者移出房间并通知；入座的玩家只能在两局之间移出。
+// 在房间的 Do 中执行，与玩家的请求依次处理
+func RemoveFromRoom(room *logic.Room, playerID int64) error {
+	var err error
+	room.Do(func() {
+		err = removeFromRoom(room, playerID)
+	})
+	return err
+}
+
+// removeFromRoom 将玩家或观战者移出房间并通知，调用方需在房间的 Do 中调用
+func removeFromRoom(room *logic.Room, playerID int64) error {
+	for _, spectator := range room.GetSpectators() {
+		if spectator.ID != playerID {
+			continue
+		}
+		if err := room.RemoveSpectator(playerID); err != nil {
+			return err
+		}
+		server.GetRoomManager().UnregisterPlayer(playerID)
+		notifyKicked(room, spectator)
+		room.Logger().Info("Admin removed spectator", logger.PlayerID(playerID))
+		return nil
+	}
+
+	target, err := room.GetPlayer(playerID)
+	if err != nil {
+		return err
+	}
+	if !room.GetFSM().CanAct(logic.STATE_WAITING_FOR_PLAYERS) {
+		return errors.New("can only kick players between rounds")
+	}
+	if err := room.RemovePlayer(playerID); err != nil {
+		return err
+	}
+	server.GetRoomManager().UnregisterPlayer(playerID)
+	notifyKicked(room, target)
+	room.Logger().Info("Admin kicked player", logger.PlayerID(playerID))
+	return nil
+}
+
+// CloseRoom 由管理员关闭房间：进行中的牌局作废并退回已记账的分数，
+// 向房间内所有人推送最终分数和本场对局总结后删除房间。在房间的 Do 中执行
+func CloseRoom(room *logic.Room) error {
+	var err error
+	room.Do(func() {
+		if err = room.AbortRound(); err != nil {
+			return
+		}
+		room.Logger().Info("Admin closed room")
+		dissolveRoom(room)
+	})
+	return err
+}
+
+// AdjustBalance 由管理员通过钱包账目调整玩家余额，返回调整后的余额。
+// 玩家在房间中时（包括断线后仍占着座位的玩家），检查牌局和调整余额都在房间的 Do 中执行，
+// 调整期间不会开局；玩家正在参与的牌局结算前返回 ErrInActiveRound
+func AdjustBalance(playerID int64, amount int64) (int64, error) {
+	room := server.GetRoomManager().FindPlayerRoom(playerID)
+	if room == nil {
+		return wallet.GetWallet().Adjust(playerID, amount)
+	}
+
+	var balance int64
+	var err error
+	room.Do(func() {
+		balance, err = adjustBalanceInRoom(room, playerID, amount)
+	})
+	return balance, err
+}
+
+// adjustBalanceInRoom 调整房间内玩家的余额并同步分数，调用方需在房间的 Do 中调用
+func adjustBalanceInRoom(room *logic.Room, playerID int64, amount int64) (int64, error) {
+	player, err := room.GetPlayer(playerID)
+	if err != nil {
+		// 观战者不参与牌局，直接调整
+		return wallet.GetWallet().Adjust(playerID, amount)
+	}
+	state := room.GetFSM().GetCurrentState()
+	if state != logic.STATE_WAITING_FOR_PLAYERS && state != logic.STATE_CLOSED && len(player.GetHand()) > 0 {
+		return 0, ErrInActiveRound
+	}
+
+	balance, err := wallet.GetWallet().Adjust(playerID, amount)
+	if err != nil {
+		return 0, err
+	}
+	player.SetScore(balance)
+	broadcastRoomState(room)
+	return balance, nil
+}
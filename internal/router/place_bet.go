@@ -3,6 +3,7 @@ package router
 import (
 	"encoding/json"
 	"github.com/aceld/zinx/ziface"
+	"xizexcample/internal/audit"
 	"xizexcample/internal/logic"
 	"xizexcample/internal/msg"
 	"xizexcample/internal/pkg/logger"
@@ -19,7 +20,7 @@ func (h *PlaceBetHandler) Handle(request ziface.IRequest) {
 	var betReq msg.C2S_PlaceBetReq
 	err := json.Unmarshal(request.GetData(), &betReq)
 	if err != nil {
-		logger.ErrorLogger.Printf("Failed to unmarshal place bet request: %v", err)
+		requestLogger(request, nil).Error("Failed to unmarshal place bet request", logger.Err(err))
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_PLACE_BET_ACK), "Invalid request data")
 		return
 	}
@@ -27,38 +28,41 @@ func (h *PlaceBetHandler) Handle(request ziface.IRequest) {
 	// 2. 获取玩家和房间
 	targetPlayer, playerRoom, err := GetPlayerAndRoom(request)
 	if err != nil {
-		logger.ErrorLogger.Printf("Failed to get player and room: %v", err)
+		requestLogger(request, nil).Error("Failed to get player and room", logger.Err(err))
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_PLACE_BET_ACK), err.Error())
 		return
 	}
 
 	// 3. 检查游戏状态是否允许下注
-	if playerRoom.GetFSM().GetCurrentState() != logic.STATE_BETTING {
+	if !playerRoom.GetFSM().CanAct(logic.STATE_BETTING) {
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_PLACE_BET_ACK), "Cannot place bet at this time")
 		return
 	}
 
-	// 4. 检查玩家是否已经下注
+	// 4. 只有本局发到牌的玩家可以下注，庄家不下注，检查玩家是否已经下注
+	if targetPlayer.GetStatus() != logic.STATUS_PLAYING {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_PLACE_BET_ACK), "Player is not in this round")
+		return
+	}
+	if targetPlayer.IsBanker() {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_PLACE_BET_ACK), "Banker does not place a bet")
+		return
+	}
 	if targetPlayer.HasBet() {
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_PLACE_BET_ACK), "Player has already placed a bet")
 		return
 	}
 
-	// 5. 检查下注金额是否有效
-	if betReq.Multiple <= 0 {
-		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_PLACE_BET_ACK), "Invalid bet amount")
+	// 5. 检查下注倍数是否允许（普通倍数或本局可用的推注倍数），以及闲家和庄家的余额是否足够
+	if err := playerRoom.CheckBetMultiple(targetPlayer, betReq.Multiple); err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_PLACE_BET_ACK), err.Error())
 		return
 	}
 
-	// TODO: 检查玩家余额是否足够
-	// if targetPlayer.GetScore() < betReq.Multiple {
-	//     sendErrorResponse(request.GetConnection(), "Insufficient balance")
-	//     return
-	// }
-
 	// 6. 处理下注逻辑
 	targetPlayer.PlaceBet(betReq.Multiple)
-	logger.InfoLogger.Printf("Player %d in room %d placed a bet of %d", targetPlayer.ID, playerRoom.ID, betReq.Multiple)
+	requestLogger(request, playerRoom).Info("Player placed a bet", "multiple", betReq.Multiple)
+	recordAudit(playerRoom, audit.ACTION_BET, targetPlayer.ID, audit.MultipleDetail{Multiple: betReq.Multiple})
 
 	// 7. 广播下注信息
 	// broadcastBetInfo(playerRoom, targetPlayer, betReq.Multiple) // TODO: S2C_BetNtf has different fields
@@ -66,7 +70,7 @@ func (h *PlaceBetHandler) Handle(request ziface.IRequest) {
 	// 8. 检查是否所有玩家都已下注
 	allBetsPlaced := true
 	for _, p := range playerRoom.GetPlayers() {
-		if !p.HasBet() && p.GetStatus() == logic.STATUS_PLAYING {
+		if !p.HasBet() && !p.IsBanker() && p.GetStatus() == logic.STATUS_PLAYING {
 			allBetsPlaced = false
 			break
 		}
@@ -74,10 +78,10 @@ func (h *PlaceBetHandler) Handle(request ziface.IRequest) {
 
 	// 9. 如果所有玩家都已下注，转换游戏状态到摊牌
 	if allBetsPlaced {
-		logger.InfoLogger.Printf("All players in room %d have placed their bets", playerRoom.ID)
+		playerRoom.Logger().Info("All players have placed their bets")
 		err = playerRoom.GetFSM().PlaceBet()
 		if err != nil {
-			logger.ErrorLogger.Printf("Failed to transition to showdown state in room %d: %v", playerRoom.ID, err)
+			requestLogger(request, playerRoom).Error("Failed to transition to showdown state", logger.Err(err))
 			sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_PLACE_BET_ACK), "Failed to transition to showdown state")
 			return
 		}
@@ -98,8 +102,15 @@ func (h *PlaceBetHandler) Handle(request ziface.IRequest) {
 	}
 	request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_PLACE_BET_ACK), ackData)
 
-	// 11. 广播房间状态更新
+	// 11. 广播房间状态更新；庄家可赔付的余额减少了，向尚未下注的闲家推送新的可选倍数
 	broadcastRoomState(playerRoom)
+	if !allBetsPlaced {
+		for _, p := range playerRoom.GetPlayers() {
+			if !p.HasBet() {
+				sendBetOptions(playerRoom, p)
+			}
+		}
+	}
 }
 
 // broadcastBetInfo 广播下注信息给所有玩家
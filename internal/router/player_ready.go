@@ -3,6 +3,7 @@ package router
 import (
 	"encoding/json"
 	"github.com/aceld/zinx/ziface"
+	"xizexcample/internal/audit"
 	"xizexcample/internal/logic"
 	"xizexcample/internal/msg"
 	"xizexcample/internal/pkg/logger"
@@ -19,7 +20,7 @@ func (h *PlayerReadyHandler) Handle(request ziface.IRequest) {
 	var readyReq msg.C2S_PlayerReadyReq
 	err := json.Unmarshal(request.GetData(), &readyReq)
 	if err != nil {
-		logger.ErrorLogger.Printf("Failed to unmarshal player ready request: %v", err)
+		requestLogger(request, nil).Error("Failed to unmarshal player ready request", logger.Err(err))
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_SYNC_ROOM_STATE_NTF), "Invalid request data")
 		return
 	}
@@ -27,43 +28,33 @@ func (h *PlayerReadyHandler) Handle(request ziface.IRequest) {
 	// 2. 获取玩家和房间
 	player, room, err := GetPlayerAndRoom(request)
 	if err != nil {
-		logger.ErrorLogger.Printf("Failed to get player and room: %v", err)
+		requestLogger(request, nil).Error("Failed to get player and room", logger.Err(err))
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_SYNC_ROOM_STATE_NTF), err.Error())
 		return
 	}
 
 	// 3. 检查游戏状态
-	if room.GetFSM().GetCurrentState() != logic.STATE_WAITING_FOR_PLAYERS {
+	if !room.GetFSM().CanAct(logic.STATE_WAITING_FOR_PLAYERS) {
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_SYNC_ROOM_STATE_NTF), "Game has already started")
 		return
 	}
 
-	// 4. 设置玩家状态
+	// 4. 设置玩家状态，准备前检查余额是否足够参与一局
 	if readyReq.IsReady {
+		if err := room.CheckPlayBalance(player); err != nil {
+			sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_SYNC_ROOM_STATE_NTF), err.Error())
+			return
+		}
 		player.SetStatus(logic.STATUS_READY)
 	} else {
 		player.SetStatus(logic.STATUS_WAITING)
 	}
-	logger.InfoLogger.Printf("Player %d in room %d set status to %v", player.ID, room.ID, player.GetStatus())
-
-	// 5. 检查是否所有玩家都已准备好开始游戏
-	allReady := true
-	for _, p := range room.GetPlayers() {
-		if p.GetStatus() != logic.STATUS_READY {
-			allReady = false
-			break
-		}
-	}
+	requestLogger(request, room).Info("Player set status", "status", player.GetStatus().String())
+	recordAudit(room, audit.ACTION_READY, player.ID, audit.ReadyDetail{Ready: readyReq.IsReady})
 
-	// 6. 如果满足开始条件，则开始游戏
-	if allReady && room.GetFSM().CanStartGame() {
-		logger.InfoLogger.Printf("All players are ready. Starting game in room %d", room.ID)
-		err := room.GetFSM().StartGame()
-		if err != nil {
-			logger.ErrorLogger.Printf("Failed to start game in room %d: %v", room.ID, err)
-		}
-	}
+	// 5. 如果所有玩家都已准备好且满足开始条件，则开始游戏
+	tryStartRound(room)
 
-	// 7. 广播房间状态更新
+	// 6. 广播房间状态更新
 	broadcastRoomState(room)
 }
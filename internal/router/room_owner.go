@@ -0,0 +1,166 @@
+package router
+
+import (
+	"encoding/json"
+	"github.com/aceld/zinx/ziface"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/server"
+)
+
+// KickPlayerHandler 处理房主踢人请求
+type KickPlayerHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *KickPlayerHandler) Handle(request ziface.IRequest) {
+	// 1. 解析客户端请求
+	var kickReq msg.C2S_KickPlayerReq
+	err := json.Unmarshal(request.GetData(), &kickReq)
+	if err != nil {
+		requestLogger(request, nil).Error("Failed to unmarshal kick player request", logger.Err(err))
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_KICK_PLAYER_ACK), "Invalid request data")
+		return
+	}
+
+	// 2. 获取玩家和房间
+	owner, room, err := GetPlayerAndRoom(request)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_KICK_PLAYER_ACK), err.Error())
+		return
+	}
+	target, err := room.GetPlayer(kickReq.PlayerId)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_KICK_PLAYER_ACK), err.Error())
+		return
+	}
+
+	// 3. 踢出玩家，房主权限和牌局阶段由房间校验
+	if err := room.KickPlayer(owner.ID, target.ID); err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_KICK_PLAYER_ACK), err.Error())
+		return
+	}
+	server.GetRoomManager().UnregisterPlayer(target.ID)
+	requestLogger(request, room).Info("Owner kicked player", "target_id", target.ID)
+
+	// 4. 发送确认响应，通知被踢玩家并广播
+	sendRoomOwnerAck(request.GetConnection(), msg.MsgID_S2C_KICK_PLAYER_ACK)
+	notifyKicked(room, target)
+}
+
+// notifyKicked 通知被踢玩家，并向房间内其他人广播玩家离开
+func notifyKicked(room *logic.Room, target *logic.Player) {
+	if target.Conn != nil && target.IsOnline() {
+		kickedNtf := &msg.S2C_KickedNtf{RoomId: room.ID}
+		kickedData, _ := json.Marshal(kickedNtf)
+		target.Conn.SendMsg(uint32(msg.MsgID_S2C_KICKED_NTF), kickedData)
+	}
+	broadcastPlayerLeave(room, target.ID)
+	broadcastRoomState(room)
+}
+
+// LockSeatHandler 处理房主锁定/解锁座位请求
+type LockSeatHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *LockSeatHandler) Handle(request ziface.IRequest) {
+	// 1. 解析客户端请求
+	var lockReq msg.C2S_LockSeatReq
+	err := json.Unmarshal(request.GetData(), &lockReq)
+	if err != nil {
+		requestLogger(request, nil).Error("Failed to unmarshal lock seat request", logger.Err(err))
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_LOCK_SEAT_ACK), "Invalid request data")
+		return
+	}
+
+	// 2. 获取玩家和房间
+	owner, room, err := GetPlayerAndRoom(request)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_LOCK_SEAT_ACK), err.Error())
+		return
+	}
+
+	// 3. 锁定或解锁座位
+	if err := room.LockSeat(owner.ID, int(lockReq.Seat), lockReq.Locked); err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_LOCK_SEAT_ACK), err.Error())
+		return
+	}
+	requestLogger(request, room).Info("Owner set seat lock", "seat", lockReq.Seat, "locked", lockReq.Locked)
+
+	// 4. 发送确认响应并广播
+	sendRoomOwnerAck(request.GetConnection(), msg.MsgID_S2C_LOCK_SEAT_ACK)
+	broadcastRoomState(room)
+}
+
+// ForceStartHandler 处理房主强制开局请求
+type ForceStartHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *ForceStartHandler) Handle(request ziface.IRequest) {
+	// 1. 获取玩家和房间
+	owner, room, err := GetPlayerAndRoom(request)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_FORCE_START_ACK), err.Error())
+		return
+	}
+
+	// 2. 强制开局，未准备的玩家不参与本局
+	if err := room.ForceStart(owner.ID); err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_FORCE_START_ACK), err.Error())
+		return
+	}
+	requestLogger(request, room).Info("Owner force-started a round", "ready_players", room.GetReadyPlayerCount())
+
+	// 3. 发送确认响应，发牌并广播
+	sendRoomOwnerAck(request.GetConnection(), msg.MsgID_S2C_FORCE_START_ACK)
+	beginRound(room)
+	broadcastRoomState(room)
+}
+
+// TransferOwnerHandler 处理转让房主请求
+type TransferOwnerHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *TransferOwnerHandler) Handle(request ziface.IRequest) {
+	// 1. 解析客户端请求
+	var transferReq msg.C2S_TransferOwnerReq
+	err := json.Unmarshal(request.GetData(), &transferReq)
+	if err != nil {
+		requestLogger(request, nil).Error("Failed to unmarshal transfer owner request", logger.Err(err))
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_TRANSFER_OWNER_ACK), "Invalid request data")
+		return
+	}
+
+	// 2. 获取玩家和房间
+	owner, room, err := GetPlayerAndRoom(request)
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_TRANSFER_OWNER_ACK), err.Error())
+		return
+	}
+
+	// 3. 转让房主
+	if err := room.TransferOwnership(owner.ID, transferReq.PlayerId); err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_TRANSFER_OWNER_ACK), err.Error())
+		return
+	}
+	requestLogger(request, room).Info("Owner transferred room", "target_id", transferReq.PlayerId)
+
+	// 4. 发送确认响应并广播
+	sendRoomOwnerAck(request.GetConnection(), msg.MsgID_S2C_TRANSFER_OWNER_ACK)
+	broadcastRoomState(room)
+}
+
+// sendRoomOwnerAck 发送房主操作成功响应
+func sendRoomOwnerAck(conn ziface.IConnection, msgID msg.MsgID) {
+	ack := &msg.S2C_RoomOwnerAck{RetCode: 0}
+	ackData, _ := json.Marshal(ack)
+	conn.SendMsg(uint32(msgID), ackData)
+}
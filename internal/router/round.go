@@ -0,0 +1,147 @@
+package router
+
+import (
+	"encoding/json"
+	"xizexcample/internal/audit"
+	"xizexcample/internal/history"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/metrics"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/profile"
+	"xizexcample/internal/server"
+)
+
+// tryStartRound 所有玩家都已准备好且满足开局条件时开始新的一局，返回是否开局
+func tryStartRound(room *logic.Room) bool {
+	for _, p := range room.GetPlayers() {
+		if p.GetStatus() != logic.STATUS_READY {
+			return false
+		}
+	}
+	if !room.GetFSM().CanStartGame() {
+		return false
+	}
+
+	room.Logger().Info("All players are ready, starting game")
+	if err := room.GetFSM().StartGame(); err != nil {
+		room.Logger().Error("Failed to start game", logger.Err(err))
+		return false
+	}
+	beginRound(room)
+	return true
+}
+
+// beginRound 在房间进入发牌阶段后通知开局、发牌并把手牌推送给参与本局的玩家，
+// 再按玩法推送抢庄或下注的可选倍数；通比牛牛发牌后直接摊牌
+func beginRound(room *logic.Room) {
+	startNtf := &msg.S2C_GameStartNtf{
+		BankerId: room.GetBankerID(),
+	}
+	startData, _ := json.Marshal(startNtf)
+	broadcastToRoom(room, msg.MsgID_S2C_GAME_START_NTF, startData)
+
+	if err := room.GetFSM().DealCards(); err != nil {
+		room.Logger().Error("Failed to deal cards", logger.Err(err))
+		return
+	}
+	deal := audit.DealDetail{ShuffleSeed: room.GetShuffleSeed()}
+	for _, p := range room.GetPlayers() {
+		if p.GetStatus() == logic.STATUS_PLAYING {
+			deal.Hands = append(deal.Hands, audit.HandDetail{PlayerID: p.ID, Seat: p.GetSeat(), Hand: p.GetHand()})
+		}
+	}
+	recordAudit(room, audit.ACTION_DEAL, 0, deal)
+	state := room.GetFSM().GetCurrentState()
+	if state == logic.STATE_BETTING {
+		if banker, err := room.GetPlayer(room.GetBankerID()); err == nil {
+			recordAudit(room, audit.ACTION_BANKER, 0, audit.BankerDetail{BankerID: banker.ID, Multiple: banker.GetBidMultiple()})
+		}
+	}
+
+	for _, p := range room.GetPlayers() {
+		if p.GetStatus() != logic.STATUS_PLAYING || p.Conn == nil || !p.IsOnline() {
+			continue
+		}
+		dealNtf := &msg.S2C_DealCardsNtf{
+			Hand: toMsgCards(p.GetHand()),
+		}
+		dealData, _ := json.Marshal(dealNtf)
+		p.Conn.SendMsg(uint32(msg.MsgID_S2C_DEAL_CARDS_NTF), dealData)
+		switch state {
+		case logic.STATE_BIDDING:
+			sendBidOptions(room, p)
+		case logic.STATE_BETTING:
+			sendBetOptions(room, p)
+		}
+	}
+	if state == logic.STATE_BIDDING {
+		startBidTimer(room)
+	}
+}
+
+// finishRound 结算本局并广播结果，打满局数时结束本场对局
+func finishRound(room *logic.Room) {
+	fsm := room.GetFSM()
+	if err := fsm.Settlement(); err != nil {
+		room.Logger().Error("Failed to settle round", logger.Err(err))
+		return
+	}
+
+	result := fsm.GetLastResult()
+	if _, err := audit.GetLog().Append(audit.NewSettleEvent(room.ID, result)); err != nil {
+		room.Logger().Error("Failed to write audit log", "action", string(audit.ACTION_SETTLE), logger.Err(err))
+	}
+	resultNtf := &msg.S2C_GameResultNtf{
+		Round:       int32(result.Round),
+		TotalRounds: int32(room.Settings.Rounds),
+		BankerId:    result.BankerID,
+	}
+	for _, r := range result.Results {
+		resultNtf.Results = append(resultNtf.Results, &msg.PlayerResult{
+			PlayerId:    r.PlayerID,
+			Hand:        toMsgCards(r.Hand),
+			CardPattern: toMsgCardPattern(r.CardType),
+			ScoreChange: r.ScoreChange,
+			FinalScore:  r.FinalScore,
+			Rake:        r.Rake,
+		})
+	}
+	resultData, _ := json.Marshal(resultNtf)
+	broadcastToRoom(room, msg.MsgID_S2C_GAME_RESULT_NTF, resultData)
+	room.Logger().Info("Round settled", "round", result.Round)
+	metrics.RoundsCompleted.Inc()
+	if !result.StartedAt.IsZero() {
+		metrics.RoundDuration.Observe(result.SettledAt.Sub(result.StartedAt).Seconds())
+	}
+
+	if err := history.GetRecorder().Record(history.NewRecord(room.ID, room.Settings, result)); err != nil {
+		room.Logger().Error("Failed to record hand history", logger.Err(err))
+	}
+	if err := profile.RecordRound(profile.GetRepository(), result); err != nil {
+		room.Logger().Error("Failed to update player profiles", logger.Err(err))
+	}
+
+	if fsm.GetCurrentState() == logic.STATE_CLOSED {
+		endSession(room)
+		return
+	}
+	broadcastRoomState(room)
+}
+
+// endSession 向房间内所有人推送本场对局总结，然后关闭房间
+func endSession(room *logic.Room) {
+	summaryNtf := &msg.S2C_SessionSummaryNtf{
+		RoomId:  room.ID,
+		Session: buildSessionInfo(room),
+	}
+	summaryData, _ := json.Marshal(summaryNtf)
+	broadcastToRoom(room, msg.MsgID_S2C_SESSION_SUMMARY_NTF, summaryData)
+	room.Logger().Info("Session ended", "rounds", summaryNtf.Session.RoundsPlayed)
+
+	roomManager := server.GetRoomManager()
+	for _, p := range append(room.GetPlayers(), room.GetSpectators()...) {
+		roomManager.UnregisterPlayer(p.ID)
+	}
+	roomManager.DeleteRoom(room.ID)
+}
@@ -3,6 +3,8 @@ package router
 import (
 	"github.com/aceld/zinx/ziface"
 	"github.com/aceld/zinx/znet"
+	"xizexcample/internal/msg"
+	gameserver "xizexcample/internal/server"
 )
 
 // InitRouter 初始化路由
@@ -16,22 +18,38 @@ func InitRouter(server ziface.IServer) {
 		// TODO: 实现连接断开时的逻辑，例如从房间移除玩家
 	})
 
-	// 注册消息路由
-	// TODO: 将在后续任务中为每个消息ID添加具体的处理函数
-	server.AddRouter(101, &JoinRoomHandler{})    // C2S_JOIN_ROOM_REQ
-	server.AddRouter(102, &PlayerReadyHandler{}) // C2S_PLAYER_READY_REQ
-	server.AddRouter(103, &BidBankerHandler{})   // C2S_BID_BANKER_REQ
-	server.AddRouter(104, &PlaceBetHandler{})    // C2S_PLACE_BET_REQ
-	server.AddRouter(105, &ShowdownHandler{})    // C2S_SHOWDOWN_REQ
-	server.AddRouter(106, &LeaveRoomHandler{})   // C2S_LEAVE_ROOM_REQ
+	// 注册大厅消息路由，所有处理器都会统计请求数和处理耗时；
+	// 这些处理器在玩家进入房间之前调用，需要时自己进入目标房间的 Do
+	addRouter(server, msg.MsgID_C2S_LOGIN_REQ, &LoginHandler{})
+	addRouter(server, msg.MsgID_C2S_ROOM_LIST_REQ, &RoomListHandler{})
+	addRouter(server, msg.MsgID_C2S_CREATE_ROOM_REQ, &CreateRoomHandler{})
+	addRouter(server, msg.MsgID_C2S_JOIN_ROOM_REQ, &JoinRoomHandler{})
+	addRouter(server, msg.MsgID_C2S_QUICK_MATCH_REQ, &QuickMatchHandler{})
+	addRouter(server, msg.MsgID_C2S_CANCEL_MATCH_REQ, &CancelMatchHandler{})
+
+	// 注册房间内的消息路由，同一房间的请求依次处理
+	addRoomRouter(server, msg.MsgID_C2S_PLAYER_READY_REQ, &PlayerReadyHandler{})
+	addRoomRouter(server, msg.MsgID_C2S_BID_BANKER_REQ, &BidBankerHandler{})
+	addRoomRouter(server, msg.MsgID_C2S_PLACE_BET_REQ, &PlaceBetHandler{})
+	addRoomRouter(server, msg.MsgID_C2S_SHOWDOWN_REQ, &ShowdownHandler{})
+	addRoomRouter(server, msg.MsgID_C2S_LEAVE_ROOM_REQ, &LeaveRoomHandler{})
+	addRoomRouter(server, msg.MsgID_C2S_TAKE_SEAT_REQ, &TakeSeatHandler{})
+	addRoomRouter(server, msg.MsgID_C2S_KICK_PLAYER_REQ, &KickPlayerHandler{})
+	addRoomRouter(server, msg.MsgID_C2S_LOCK_SEAT_REQ, &LockSeatHandler{})
+	addRoomRouter(server, msg.MsgID_C2S_FORCE_START_REQ, &ForceStartHandler{})
+	addRoomRouter(server, msg.MsgID_C2S_TRANSFER_OWNER_REQ, &TransferOwnerHandler{})
+	addRoomRouter(server, msg.MsgID_C2S_DISSOLVE_PROPOSE_REQ, &DissolveProposeHandler{})
+	addRoomRouter(server, msg.MsgID_C2S_DISSOLVE_VOTE_REQ, &DissolveVoteHandler{})
+
+	// 注册快速匹配回调
+	gameserver.GetMatchmaker().SetOnMatch(onMatchFound)
+	gameserver.GetMatchmaker().SetOnQueueUpdate(onMatchQueueUpdate)
+
+	// 注册投票者离开使解散投票结束时的回调
+	gameserver.GetRoomManager().SetDissolveHook(onDissolveDecided)
 }
 
 // BaseRouter 基础路由器
 type BaseRouter struct {
 	znet.BaseRouter
 }
-
-// LeaveRoomHandler 离开房间处理器
-type LeaveRoomHandler struct {
-	BaseRouter
-}
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"github.com/aceld/zinx/ziface"
+	"xizexcample/internal/server"
+)
+
+// roomRouter 在玩家所在房间的 Do 中处理请求。zinx 按连接把请求分给不同的工作协程，
+// 同一房间的玩家的请求会并发处理，经过 Do 后与投票截止、管理后台的修改一起依次执行。
+// 只用于房间内的操作，玩家不在任何房间时直接处理（处理器会返回错误）
+type roomRouter struct {
+	ziface.IRouter
+}
+
+// Handle 在玩家所在房间的 Do 中处理请求
+func (r *roomRouter) Handle(request ziface.IRequest) {
+	room := server.GetRoomManager().GetRoomByPlayerID(connPlayerID(request.GetConnection()))
+	if room == nil {
+		r.IRouter.Handle(request)
+		return
+	}
+	room.Do(func() {
+		r.IRouter.Handle(request)
+	})
+}
@@ -3,6 +3,7 @@ package router
 import (
 	"encoding/json"
 	"github.com/aceld/zinx/ziface"
+	"xizexcample/internal/audit"
 	"xizexcample/internal/logic"
 	"xizexcample/internal/msg"
 	"xizexcample/internal/pkg/logger"
@@ -19,7 +20,7 @@ func (h *ShowdownHandler) Handle(request ziface.IRequest) {
 	var showdownReq msg.C2S_ShowdownReq
 	err := json.Unmarshal(request.GetData(), &showdownReq)
 	if err != nil {
-		logger.ErrorLogger.Printf("Failed to unmarshal showdown request: %v", err)
+		requestLogger(request, nil).Error("Failed to unmarshal showdown request", logger.Err(err))
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_SHOWDOWN_ACK), "Invalid request data")
 		return
 	}
@@ -27,45 +28,55 @@ func (h *ShowdownHandler) Handle(request ziface.IRequest) {
 	// 2. 获取玩家和房间
 	player, room, err := GetPlayerAndRoom(request)
 	if err != nil {
-		logger.ErrorLogger.Printf("Failed to get player and room: %v", err)
+		requestLogger(request, nil).Error("Failed to get player and room", logger.Err(err))
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_SHOWDOWN_ACK), err.Error())
 		return
 	}
 
 	// 3. 检查游戏状态
-	if room.GetFSM().GetCurrentState() != logic.STATE_SHOWDOWN {
+	if !room.GetFSM().CanAct(logic.STATE_SHOWDOWN) {
 		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_SHOWDOWN_ACK), "Cannot showdown at this time")
 		return
 	}
 
-	// 4. 计算牌型和分数 (简化逻辑)
-	// 在实际应用中，这里应该有复杂的牌型计算逻辑
-	// player.CalculateHand()
-	logger.InfoLogger.Printf("Player %d in room %d shows hand", player.ID, room.ID)
+	// 4. 标记摊牌，牌型在结算时由服务器计算
+	if player.GetStatus() != logic.STATUS_PLAYING {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_SHOWDOWN_ACK), "Player is not in this round")
+		return
+	}
+	if player.HasShown() {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_SHOWDOWN_ACK), "Player has already shown")
+		return
+	}
+	player.SetShown()
+	requestLogger(request, room).Info("Player shows hand")
+	recordAudit(room, audit.ACTION_SHOW, player.ID, nil)
 
 	// 5. 检查是否所有人都已摊牌
 	allShowdown := true
 	for _, p := range room.GetPlayers() {
-		// 简化检查逻辑，实际应检查玩家是否已摊牌的状态
-		if p.GetStatus() == logic.STATUS_PLAYING {
-			// allShowdown = false
-			// break
+		if p.GetStatus() == logic.STATUS_PLAYING && !p.HasShown() {
+			allShowdown = false
+			break
 		}
 	}
 
-	// 6. 如果所有人都已摊牌，进入结算状态
+	// 6. 发送确认响应
+	ack := &msg.S2C_ShowdownAck{RetCode: 0}
+	ackData, _ := json.Marshal(ack)
+	request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_SHOWDOWN_ACK), ackData)
+
+	// 7. 如果所有人都已摊牌，进入结算状态并结算本局
 	if allShowdown {
 		err := room.GetFSM().Showdown()
 		if err != nil {
-			logger.ErrorLogger.Printf("Failed to transition to settlement state in room %d: %v", room.ID, err)
+			requestLogger(request, room).Error("Failed to transition to settlement state", logger.Err(err))
+		} else {
+			finishRound(room)
+			return
 		}
 	}
 
-	// 7. 发送确认响应
-	ack := &msg.S2C_ShowdownAck{RetCode: 0}
-	ackData, _ := json.Marshal(ack)
-	request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_SHOWDOWN_ACK), ackData)
-
 	// 8. 广播房间状态
 	broadcastRoomState(room)
 }
@@ -0,0 +1,57 @@
+package router
+
+import (
+	"encoding/json"
+	"github.com/aceld/zinx/ziface"
+	"xizexcample/internal/audit"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/server"
+)
+
+// TakeSeatHandler 处理观战者入座请求
+type TakeSeatHandler struct {
+	BaseRouter
+}
+
+// Handle 处理请求
+func (h *TakeSeatHandler) Handle(request ziface.IRequest) {
+	// 1. 解析客户端请求
+	var seatReq msg.C2S_TakeSeatReq
+	err := json.Unmarshal(request.GetData(), &seatReq)
+	if err != nil {
+		requestLogger(request, nil).Error("Failed to unmarshal take seat request", logger.Err(err))
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_TAKE_SEAT_ACK), "Invalid request data")
+		return
+	}
+
+	// 2. 获取观战者所在房间
+	playerID, err := request.GetConnection().GetProperty("playerID")
+	if err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_TAKE_SEAT_ACK), "player not logged in")
+		return
+	}
+	room := server.GetRoomManager().GetRoomByPlayerID(playerID.(int64))
+	if room == nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_TAKE_SEAT_ACK), "player not in any room")
+		return
+	}
+
+	// 3. 入座，只能在两局之间进行
+	if err := room.TakeSeat(playerID.(int64), int(seatReq.Seat)); err != nil {
+		sendErrorResponse(request.GetConnection(), uint32(msg.MsgID_S2C_TAKE_SEAT_ACK), err.Error())
+		return
+	}
+	requestLogger(request, room).Info("Spectator took a seat")
+	if player, err := room.GetPlayer(playerID.(int64)); err == nil {
+		recordAudit(room, audit.ACTION_JOIN, player.ID, audit.JoinDetail{Seat: player.GetSeat()})
+	}
+
+	// 4. 发送确认响应
+	seatAck := &msg.S2C_TakeSeatAck{RetCode: 0}
+	ackData, _ := json.Marshal(seatAck)
+	request.GetConnection().SendMsg(uint32(msg.MsgID_S2C_TAKE_SEAT_ACK), ackData)
+
+	// 5. 广播房间状态更新
+	broadcastRoomState(room)
+}
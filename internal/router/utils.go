@@ -4,8 +4,16 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/aceld/zinx/ziface"
+	"log/slog"
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+	"xizexcample/internal/audit"
 	"xizexcample/internal/logic"
+	"xizexcample/internal/metrics"
 	"xizexcample/internal/msg"
+	"xizexcample/internal/pkg/logger"
 	"xizexcample/internal/server"
 )
 
@@ -17,6 +25,60 @@ func sendErrorResponse(conn ziface.IConnection, msgID uint32, errorMsg string) {
 	}
 	ackData, _ := json.Marshal(errAck)
 	conn.SendMsg(msgID, ackData)
+	metrics.ErrorsTotal.Inc(msg.MsgID(msgID).String(), "1")
+}
+
+// connPlayerID 返回连接对应的玩家ID，已登录的连接使用登录的玩家ID，否则使用游客ID
+func connPlayerID(conn ziface.IConnection) int64 {
+	if playerID, err := conn.GetProperty("playerID"); err == nil {
+		return playerID.(int64)
+	}
+	return guestPlayerID(conn)
+}
+
+// guestNamespace 本次启动的游客ID命名空间。zinx 每次启动都从 1 开始分配连接ID，
+// 混入启动时随机生成的命名空间后，重启后的游客ID不会与重启前的游客ID相同
+var guestNamespace = rand.Int63n(1<<30) + 1
+
+// guestPlayerID 返回未登录连接的游客ID：命名空间和连接ID组合后取负数，
+// 不会与登录的玩家ID（正数）和平台账户（0）冲突
+func guestPlayerID(conn ziface.IConnection) int64 {
+	return -(guestNamespace<<32 | int64(conn.GetConnID()&math.MaxUint32))
+}
+
+// connNickname 返回连接对应的玩家昵称，未登录的连接使用游客昵称
+func connNickname(conn ziface.IConnection) string {
+	if nickname, err := conn.GetProperty("nickname"); err == nil {
+		return nickname.(string)
+	}
+	return "Guest" + strconv.FormatUint(conn.GetConnID(), 10)
+}
+
+// requestLogger 返回带有请求上下文的日志记录器：连接ID、消息ID和玩家ID，
+// room 不为空时还带有房间ID和当前牌局ID
+func requestLogger(request ziface.IRequest, room *logic.Room) *slog.Logger {
+	l := logger.L()
+	if room != nil {
+		l = room.Logger()
+	}
+	conn := request.GetConnection()
+	return l.With(
+		logger.ConnID(conn.GetConnID()),
+		logger.MsgID(msg.MsgID(request.GetMsgID()).String()),
+		logger.PlayerID(connPlayerID(conn)),
+	)
+}
+
+// recordAudit 把房间内被接受的玩家操作或服务器决定写入审计日志，牌局ID取房间当前的牌局。
+// 审计日志写入失败只记录错误，不影响游戏流程
+func recordAudit(room *logic.Room, action audit.Action, playerID int64, detail any) {
+	event := audit.Event{Action: action, RoomID: room.ID, PlayerID: playerID, Detail: detail}
+	if room.FSM != nil {
+		event.RoundID = room.FSM.GetRoundID()
+	}
+	if _, err := audit.GetLog().Append(event); err != nil {
+		room.Logger().Error("Failed to write audit log", "action", string(action), logger.PlayerID(playerID), logger.Err(err))
+	}
 }
 
 // GetPlayerAndRoom 从连接中获取玩家和房间
@@ -33,45 +95,215 @@ func GetPlayerAndRoom(request ziface.IRequest) (*logic.Player, *logic.Room, erro
 
 	player, err := room.GetPlayer(playerID.(int64))
 	if err != nil {
+		if room.IsSpectator(playerID.(int64)) {
+			return nil, nil, errors.New("spectators cannot perform game actions")
+		}
 		return nil, nil, errors.New("player not found in room")
 	}
 
 	return player, room, nil
 }
 
-// broadcastRoomState 广播房间状态给所有玩家
-func broadcastRoomState(room *logic.Room) {
+// buildRoomInfo 构建 viewerID 视角下的房间信息
+// 玩家只能看到自己的手牌，摊牌之后所有人（包括观战者）才能看到全部手牌
+func buildRoomInfo(room *logic.Room, viewerID int64) *msg.RoomInfo {
+	state := room.GetFSM().GetCurrentState()
+	revealAll := state == logic.STATE_SHOWDOWN || state == logic.STATE_SETTLEMENT
+
 	players := room.GetPlayers()
 	playerInfos := make([]*msg.PlayerInfo, len(players))
 	for i, p := range players {
 		playerInfos[i] = &msg.PlayerInfo{
 			PlayerId: p.ID,
 			Nickname: p.Nickname,
-			Score:    p.Score,
+			Score:    p.GetScore(),
 			Status:   msg.PlayerStatus(p.GetStatus()),
 			IsBanker: p.IsBanker(),
+			Seat:     int32(p.GetSeat()),
+		}
+		if revealAll || p.ID == viewerID {
+			playerInfos[i].Hand = toMsgCards(p.GetHand())
 		}
 	}
 
-	roomInfo := &msg.RoomInfo{
-		RoomId:    room.ID,
-		Players:   playerInfos,
-		GameState: msg.GameState(room.GetFSM().GetCurrentState()),
-		BankerId:  room.GetBankerID(),
+	return &msg.RoomInfo{
+		RoomId:         room.ID,
+		Players:        playerInfos,
+		GameState:      msg.GameState(state),
+		BankerId:       room.GetBankerID(),
+		Settings:       toMsgRoomSettings(room.Settings),
+		InviteCode:     room.InviteCode,
+		SpectatorCount: int32(room.GetSpectatorCount()),
+		OwnerId:        room.GetOwnerID(),
+		LockedSeats:    toInt32s(room.GetLockedSeats()),
+		DissolveVote:   buildDissolveVoteInfo(room),
+		Session:        buildSessionInfo(room),
 	}
+}
 
-	ntf := &msg.S2C_SyncRoomStateNtf{
-		RoomInfo: roomInfo,
+// buildSessionInfo 构建本场对局的进度和分数表
+func buildSessionInfo(room *logic.Room) *msg.SessionInfo {
+	session := room.GetSession()
+	info := &msg.SessionInfo{
+		RoundsPlayed: int32(session.RoundsPlayed()),
+		TotalRounds:  int32(session.TotalRounds),
 	}
-	ntfData, _ := json.Marshal(ntf)
+	for _, round := range session.Rounds {
+		roundScore := &msg.RoundScore{
+			Round:    int32(round.Round),
+			BankerId: round.BankerID,
+		}
+		for _, r := range round.Results {
+			roundScore.Changes = append(roundScore.Changes, &msg.ScoreChange{
+				PlayerId:    r.PlayerID,
+				ScoreChange: r.ScoreChange,
+			})
+		}
+		info.Rounds = append(info.Rounds, roundScore)
+	}
+	for _, stats := range session.Standings() {
+		info.Scores = append(info.Scores, &msg.SessionScore{
+			PlayerId:     stats.PlayerID,
+			Nickname:     stats.Nickname,
+			RoundsPlayed: int32(stats.RoundsPlayed),
+			Wins:         int32(stats.Wins),
+			NetScore:     stats.NetScore,
+			BestHand:     toMsgCards(stats.BestHand),
+			BestPattern:  toMsgCardPattern(stats.BestCardType),
+		})
+	}
+	return info
+}
+
+// toMsgCardPattern 将牌型转换为协议中的牌型
+func toMsgCardPattern(cardType logic.CardType) msg.CardPattern {
+	switch cardType {
+	case logic.CARD_TYPE_FIVE_SMALL:
+		return msg.CardPattern_FIVE_SMALL_NIU
+	case logic.CARD_TYPE_BOMB:
+		return msg.CardPattern_BOMB_NIU
+	case logic.CARD_TYPE_GOLDEN_FLOWER:
+		return msg.CardPattern_STRAIGHT_FLUSH_NIU
+	default:
+		// 无牛到牛牛与协议中的 NO_NIU 到 NIU_NIU 一一对应
+		return msg.CardPattern(cardType) + msg.CardPattern_NO_NIU
+	}
+}
+
+// toInt32s 将 []int 转换为 []int32
+func toInt32s(values []int) []int32 {
+	result := make([]int32, len(values))
+	for i, v := range values {
+		result[i] = int32(v)
+	}
+	return result
+}
+
+// toMsgCards 将手牌转换为协议结构
+func toMsgCards(cards []logic.Card) []*msg.Card {
+	result := make([]*msg.Card, len(cards))
+	for i, c := range cards {
+		result[i] = &msg.Card{
+			Suit: msg.Suit(c.Suit),
+			Rank: msg.Rank(c.Rank),
+		}
+	}
+	return result
+}
 
-	for _, p := range players {
+// toMsgRoomSettings 将房间设置转换为协议结构
+func toMsgRoomSettings(settings logic.RoomSettings) *msg.RoomSettings {
+	return &msg.RoomSettings{
+		BaseBet:       settings.BaseBet,
+		MaxPlayers:    int32(settings.MaxPlayers),
+		RuleSet:       msg.RuleSet(settings.RuleSet),
+		Rounds:        int32(settings.Rounds),
+		IsPrivate:     settings.IsPrivate,
+		MaxSpectators: int32(settings.MaxSpectators),
+		MinPlayers:    int32(settings.MinPlayers),
+		DissolveRule:  msg.DissolveRule(settings.DissolveRule),
+		PayoutPolicy:  msg.PayoutPolicy(settings.PayoutPolicy),
+		Rake: &msg.RakeInfo{
+			Mode:    msg.RakeMode(settings.Rake.Mode),
+			Percent: settings.Rake.Percent,
+			Cap:     settings.Rake.Cap,
+			Fee:     settings.Rake.Fee,
+		},
+		BidMultiples: settings.BidMultiples,
+		BetMultiples: settings.BetMultiples,
+		PushBetCap:   settings.PushBetCap,
+		PushCooldown: int32(settings.PushCooldown),
+	}
+}
+
+// fromMsgRoomSettings 将协议中的房间设置转换为逻辑层结构，未填写的字段使用服务器配置的默认值；
+// 抽水策略由服务器配置，忽略客户端填写的值
+func fromMsgRoomSettings(settings *msg.RoomSettings) logic.RoomSettings {
+	result := server.GetRoomManager().DefaultSettings()
+	if settings == nil {
+		return result
+	}
+	if settings.BaseBet != 0 {
+		result.BaseBet = settings.BaseBet
+	}
+	if settings.MaxPlayers != 0 {
+		result.MaxPlayers = int(settings.MaxPlayers)
+	}
+	if settings.MinPlayers != 0 {
+		result.MinPlayers = int(settings.MinPlayers)
+	}
+	if settings.RuleSet != msg.RuleSet_RULE_SET_UNKNOWN {
+		result.RuleSet = logic.RuleSet(settings.RuleSet)
+	}
+	result.Rounds = int(settings.Rounds)
+	result.IsPrivate = settings.IsPrivate
+	result.MaxSpectators = int(settings.MaxSpectators)
+	result.DissolveRule = logic.DissolveRule(settings.DissolveRule)
+	result.PayoutPolicy = logic.PayoutPolicy(settings.PayoutPolicy)
+	if len(settings.BidMultiples) > 0 {
+		result.BidMultiples = settings.BidMultiples
+	}
+	if len(settings.BetMultiples) > 0 {
+		result.BetMultiples = settings.BetMultiples
+	}
+	result.PushBetCap = settings.PushBetCap
+	result.PushCooldown = int(settings.PushCooldown)
+	return result
+}
+
+// broadcastRoomState 广播房间状态给所有玩家和观战者，每个人收到自己视角的房间信息
+func broadcastRoomState(room *logic.Room) {
+	recipients := append(room.GetPlayers(), room.GetSpectators()...)
+	for _, p := range recipients {
 		if p.Conn != nil && p.IsOnline() {
+			ntf := &msg.S2C_SyncRoomStateNtf{
+				RoomInfo: buildRoomInfo(room, p.ID),
+			}
+			ntfData, _ := json.Marshal(ntf)
 			p.Conn.SendMsg(uint32(msg.MsgID_S2C_SYNC_ROOM_STATE_NTF), ntfData)
 		}
 	}
 }
 
+// broadcastToRoom 向房间内所有在线的玩家和观战者发送同一条消息
+func broadcastToRoom(room *logic.Room, msgID msg.MsgID, data []byte) {
+	recipients := append(room.GetPlayers(), room.GetSpectators()...)
+	for _, p := range recipients {
+		if p.Conn != nil && p.IsOnline() {
+			p.Conn.SendMsg(uint32(msgID), data)
+		}
+	}
+}
+
+// broadcastPlayerLeave 广播玩家离开房间
+func broadcastPlayerLeave(room *logic.Room, playerID int64) {
+	ntf := &msg.S2C_PlayerLeaveNtf{
+		PlayerId: playerID,
+	}
+	ntfData, _ := json.Marshal(ntf)
+	broadcastToRoom(room, msg.MsgID_S2C_PLAYER_LEAVE_NTF, ntfData)
+}
+
 // sendFullRoomState 向单个玩家发送完整的房间状态
 func sendFullRoomState(player *logic.Player) {
 	room := server.GetRoomManager().GetRoomByPlayerID(player.ID)
@@ -79,31 +311,48 @@ func sendFullRoomState(player *logic.Player) {
 		return
 	}
 
-	players := room.GetPlayers()
-	playerInfos := make([]*msg.PlayerInfo, len(players))
-	for i, p := range players {
-		playerInfos[i] = &msg.PlayerInfo{
-			PlayerId: p.ID,
-			Nickname: p.Nickname,
-			Score:    p.Score,
-			Status:   msg.PlayerStatus(p.GetStatus()),
-			IsBanker: p.IsBanker(),
-		}
+	ntf := &msg.S2C_SyncRoomStateNtf{
+		RoomInfo: buildRoomInfo(room, player.ID),
 	}
+	ntfData, _ := json.Marshal(ntf)
 
-	roomInfo := &msg.RoomInfo{
-		RoomId:    room.ID,
-		Players:   playerInfos,
-		GameState: msg.GameState(room.GetFSM().GetCurrentState()),
-		BankerId:  room.GetBankerID(),
+	if player.Conn != nil && player.IsOnline() {
+		player.Conn.SendMsg(uint32(msg.MsgID_S2C_SYNC_ROOM_STATE_NTF), ntfData)
 	}
 
-	ntf := &msg.S2C_SyncRoomStateNtf{
-		RoomInfo: roomInfo,
+	// 断线重连时补发当前阶段的可选倍数
+	switch room.GetFSM().GetCurrentState() {
+	case logic.STATE_BIDDING:
+		sendBidOptions(room, player)
+	case logic.STATE_BETTING:
+		sendBetOptions(room, player)
+	}
+}
+
+// sendBidOptions 向参与本局的玩家推送抢庄通知和可选的抢庄倍数
+func sendBidOptions(room *logic.Room, player *logic.Player) {
+	if player.GetStatus() != logic.STATUS_PLAYING || player.Conn == nil || !player.IsOnline() {
+		return
+	}
+	ntf := &msg.S2C_BidBankerNtf{
+		Multiples: room.AllowedBidMultiples(player),
 	}
 	ntfData, _ := json.Marshal(ntf)
+	player.Conn.SendMsg(uint32(msg.MsgID_S2C_BID_BANKER_NTF), ntfData)
+}
 
-	if player.Conn != nil && player.IsOnline() {
-		player.Conn.SendMsg(uint32(msg.MsgID_S2C_SYNC_ROOM_STATE_NTF), ntfData)
+// sendBetOptions 向参与本局的玩家推送下注通知和可选的下注倍数
+func sendBetOptions(room *logic.Room, player *logic.Player) {
+	if player.GetStatus() != logic.STATUS_PLAYING || player.Conn == nil || !player.IsOnline() {
+		return
+	}
+	ntf := &msg.S2C_BetNtf{
+		BankerId:  room.GetBankerID(),
+		Multiples: room.AllowedBetMultiples(player),
 	}
+	if push := room.PushBetMultiple(player); slices.Contains(ntf.Multiples, push) {
+		ntf.PushMultiple = push
+	}
+	ntfData, _ := json.Marshal(ntf)
+	player.Conn.SendMsg(uint32(msg.MsgID_S2C_BET_NTF), ntfData)
 }
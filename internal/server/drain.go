@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/pkg/logger"
+)
+
+// drainPollInterval 停服排空时检查牌局是否结束的间隔
+const drainPollInterval = 200 * time.Millisecond
+
+// ErrDraining 服务器停服排空中，不再接受新的加入和新的一局
+var ErrDraining = errors.New("server is shutting down")
+
+// installHooksLocked 为房间安装开局检查、解散投票和快照回调，调用方需持有写锁
+func (rm *RoomManager) installHooksLocked(room *logic.Room) {
+	room.GetFSM().SetStartGate(rm.acceptingRounds)
+	room.SetDissolveHook(func(outcome logic.DissolveOutcome) {
+		rm.dissolveDecided(room, outcome)
+	})
+	rm.installSnapshotHookLocked(room)
+}
+
+// acceptingRounds 检查是否允许开始新的一局，停服排空和维护模式期间都不允许
+func (rm *RoomManager) acceptingRounds() bool {
+	return !rm.IsDraining() && !rm.InMaintenance()
+}
+
+// BeginDrain 进入停服排空：不再创建房间、不再开始新的一局，进行中的牌局可以继续打完
+func (rm *RoomManager) BeginDrain() {
+	if rm.draining.CompareAndSwap(false, true) {
+		logger.L().Info("Draining: no new rooms or rounds will be started")
+	}
+}
+
+// IsDraining 检查服务器是否处于停服排空中
+func (rm *RoomManager) IsDraining() bool {
+	return rm.draining.Load()
+}
+
+// ActiveRounds 返回正在进行中（已开局、尚未结算完成）的牌局数
+func (rm *RoomManager) ActiveRounds() int {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	active := 0
+	for _, room := range rm.rooms {
+		switch room.GetFSM().GetCurrentState() {
+		case logic.STATE_WAITING_FOR_PLAYERS, logic.STATE_CLOSED:
+		default:
+			active++
+		}
+	}
+	return active
+}
+
+// WaitForRounds 等待进行中的牌局全部结束，ctx 到期或取消时停止等待，返回仍未结束的牌局数
+func (rm *RoomManager) WaitForRounds(ctx context.Context) int {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		active := rm.ActiveRounds()
+		if active == 0 {
+			return 0
+		}
+		select {
+		case <-ctx.Done():
+			return active
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/wallet"
+)
+
+func TestRoomManagerDrain(t *testing.T) {
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+	rm := newRoomManager()
+	room, err := rm.CreateRoomWithSettings(logic.DefaultRoomSettings(), "")
+	if err != nil {
+		t.Fatalf("CreateRoomWithSettings failed: %v", err)
+	}
+	defer room.Close()
+	room.SetWallet(w)
+	room.AddPlayer(logic.NewPlayer(1, "p1", nil))
+	room.AddPlayer(logic.NewPlayer(2, "p2", nil))
+	for _, p := range room.GetPlayers() {
+		p.SetStatus(logic.STATUS_READY)
+	}
+	fsm := room.GetFSM()
+	if err := fsm.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	fsm.DealCards()
+
+	// 排空开始后不再创建房间，进行中的牌局继续
+	rm.BeginDrain()
+	if _, err := rm.CreateRoomWithSettings(logic.DefaultRoomSettings(), ""); !errors.Is(err, ErrDraining) {
+		t.Errorf("Expected ErrDraining when creating a room, got %v", err)
+	}
+	if active := rm.ActiveRounds(); active != 1 {
+		t.Errorf("Expected 1 active round, got %d", active)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if remaining := rm.WaitForRounds(ctx); remaining != 1 {
+		t.Errorf("Expected the wait to time out with 1 round in progress, got %d", remaining)
+	}
+
+	// 打完这一局后不能再开始新的一局
+	room.SetBanker(1)
+	p2, _ := room.GetPlayer(2)
+	p2.PlaceBet(1)
+	for _, step := range []func() error{fsm.BidBanker, fsm.PlaceBet, fsm.Showdown, fsm.Settlement} {
+		if err := step(); err != nil {
+			t.Fatalf("Failed to finish the round: %v", err)
+		}
+	}
+	if remaining := rm.WaitForRounds(context.Background()); remaining != 0 {
+		t.Errorf("Expected no rounds in progress, got %d", remaining)
+	}
+	for _, p := range room.GetPlayers() {
+		p.SetStatus(logic.STATUS_READY)
+	}
+	if fsm.CanStartGame() || fsm.CanForceStart() {
+		t.Error("Expected no new round to start while draining")
+	}
+}
+
+func TestMatchmakerDrain(t *testing.T) {
+	rm := newRoomManager()
+	m := NewMatchmaker(rm, testStakeTiers, time.Minute)
+	m.Enqueue(&MatchTicket{PlayerID: 1, Balance: 100, TierID: 1})
+	m.Enqueue(&MatchTicket{PlayerID: 2, Balance: 100, TierID: 1})
+
+	rm.BeginDrain()
+	if _, err := m.Enqueue(&MatchTicket{PlayerID: 3, Balance: 100, TierID: 1}); !errors.Is(err, ErrDraining) {
+		t.Errorf("Expected ErrDraining when queueing, got %v", err)
+	}
+	if tickets := m.CancelAll(); len(tickets) != 2 {
+		t.Errorf("Expected 2 tickets to be removed, got %d", len(tickets))
+	}
+	if pos := m.Position(1); pos != 0 {
+		t.Errorf("Expected the queue to be empty, got position %d", pos)
+	}
+}
@@ -1,31 +1,56 @@
 package server
 
 import (
-	"fmt"
 	"github.com/aceld/zinx/ziface"
+	"log/slog"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/pkg/logger"
 )
 
 // OnConnStop is the handler for when a connection is closed
 func OnConnStop(conn ziface.IConnection) {
-	fmt.Println("OnConnStop: conn stopped")
+	log := logger.L().With(logger.ConnID(conn.GetConnID()))
+	log.Debug("Connection stopped")
 
 	// Get player ID from connection property
 	playerID, err := conn.GetProperty("playerID")
 	if err != nil {
-		fmt.Println("OnConnStop: playerID not set on connection")
+		log.Debug("Connection closed before login")
 		return
 	}
+	log = log.With(logger.PlayerID(playerID.(int64)))
+
+	// Drop the player from the quick-match queue, if queued
+	GetMatchmaker().Cancel(playerID.(int64))
 
 	// Find the room the player was in
 	room := GetRoomManager().GetRoomByPlayerID(playerID.(int64))
 	if room == nil {
-		fmt.Printf("OnConnStop: player %d was not in a room\n", playerID)
+		log.Debug("Player was not in a room")
+		return
+	}
+
+	// Leave the room in step with the room's other actions
+	room.Do(func() {
+		leaveOnDisconnect(room, playerID.(int64), log)
+	})
+}
+
+// leaveOnDisconnect removes a disconnected spectator or marks a seated player offline;
+// the caller must run it inside room.Do
+func leaveOnDisconnect(room *logic.Room, playerID int64, log *slog.Logger) {
+	// Spectators hold no seat, so they simply leave
+	if room.IsSpectator(playerID) {
+		room.RemoveSpectator(playerID)
+		GetRoomManager().UnregisterPlayer(playerID)
+		log.Info("Spectator disconnected", logger.RoomID(room.ID))
 		return
 	}
 
 	// Mark the player as offline in the room
-	room.SetPlayerOffline(playerID.(int64))
+	room.SetPlayerOffline(playerID)
 
 	// Unregister player from the room manager
-	GetRoomManager().UnregisterPlayer(playerID.(int64))
+	GetRoomManager().UnregisterPlayer(playerID)
+	log.Info("Player went offline", logger.RoomID(room.ID))
 }
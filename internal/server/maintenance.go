@@ -0,0 +1,41 @@
+package server
+
+import (
+	"time"
+	"xizexcample/internal/pkg/logger"
+)
+
+// Maintenance 维护模式的状态
+type Maintenance struct {
+	Enabled bool      `json:"enabled"`
+	Message string    `json:"message,omitempty"`
+	Since   time.Time `json:"since,omitempty"`
+}
+
+// SetMaintenance 开启或关闭维护模式。维护期间不再开始新的一局，进行中的牌局可以继续打完，
+// 玩家仍然可以进入房间和准备
+func (rm *RoomManager) SetMaintenance(enabled bool, message string) Maintenance {
+	status := Maintenance{Enabled: enabled}
+	if enabled {
+		status.Message = message
+		status.Since = time.Now()
+		logger.L().Info("Maintenance mode enabled", "message", message)
+	} else {
+		logger.L().Info("Maintenance mode disabled")
+	}
+	rm.maintenance.Store(&status)
+	return status
+}
+
+// GetMaintenance 获取维护模式的当前状态
+func (rm *RoomManager) GetMaintenance() Maintenance {
+	if status := rm.maintenance.Load(); status != nil {
+		return *status
+	}
+	return Maintenance{}
+}
+
+// InMaintenance 检查服务器是否处于维护模式
+func (rm *RoomManager) InMaintenance() bool {
+	return rm.GetMaintenance().Enabled
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"testing"
+	"xizexcample/internal/logic"
+)
+
+func TestRoomManagerMaintenance(t *testing.T) {
+	rm := newRoomManager()
+	room, err := rm.CreateRoomWithSettings(logic.DefaultRoomSettings(), "")
+	if err != nil {
+		t.Fatalf("CreateRoomWithSettings failed: %v", err)
+	}
+	defer room.Close()
+	room.AddPlayer(logic.NewPlayer(1, "p1", nil))
+	room.AddPlayer(logic.NewPlayer(2, "p2", nil))
+	for _, p := range room.GetPlayers() {
+		p.SetStatus(logic.STATUS_READY)
+	}
+
+	status := rm.SetMaintenance(true, "restarting in 10 minutes")
+	if !status.Enabled || status.Message != "restarting in 10 minutes" || status.Since.IsZero() {
+		t.Errorf("Unexpected maintenance status: %+v", status)
+	}
+	if room.GetFSM().CanStartGame() {
+		t.Error("Expected no new round to start during maintenance")
+	}
+	// 维护期间仍然可以创建房间
+	other, err := rm.CreateRoomWithSettings(logic.DefaultRoomSettings(), "")
+	if err != nil {
+		t.Errorf("Expected rooms to be created during maintenance, got %v", err)
+	} else {
+		other.Close()
+	}
+
+	rm.SetMaintenance(false, "")
+	if rm.InMaintenance() || rm.GetMaintenance().Message != "" {
+		t.Errorf("Expected maintenance to be cleared, got %+v", rm.GetMaintenance())
+	}
+	if !room.GetFSM().CanStartGame() {
+		t.Error("Expected rounds to start again after maintenance")
+	}
+}
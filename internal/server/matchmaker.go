@@ -0,0 +1,314 @@
+package server
+
+import (
+	"errors"
+	"github.com/aceld/zinx/ziface"
+	"sort"
+	"sync"
+	"time"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/pkg/logger"
+)
+
+// StakeTier 快速匹配的场次
+type StakeTier struct {
+	ID         int32
+	Name       string
+	BaseBet    int64 // 该场次房间的底注
+	MinBalance int64 // 进入该场次所需的最低余额
+}
+
+// DefaultStakeTiers 默认的匹配场次
+var DefaultStakeTiers = []StakeTier{
+	{ID: 1, Name: "初级场", BaseBet: 1, MinBalance: 20},
+	{ID: 2, Name: "中级场", BaseBet: 5, MinBalance: 100},
+	{ID: 3, Name: "高级场", BaseBet: 20, MinBalance: 400},
+}
+
+// defaultMatchMaxWait 排队超过该时长仍未匹配到房间时开新房间
+const defaultMatchMaxWait = 10 * time.Second
+
+// MatchTicket 排队中的玩家
+type MatchTicket struct {
+	PlayerID   int64
+	Balance    int64
+	TierID     int32
+	Conn       ziface.IConnection
+	EnqueuedAt time.Time
+	position   int // 最近一次通知的排队位置
+}
+
+// Matchmaker 快速匹配服务，按场次排队并把玩家分配到房间
+type Matchmaker struct {
+	rooms   *RoomManager
+	tiers   map[int32]StakeTier
+	queues  map[int32][]*MatchTicket // key: tierID，按入队时间排序
+	tickets map[int64]*MatchTicket   // key: playerID
+	maxWait time.Duration
+
+	// onMatch 在玩家被分配到房间时调用，返回错误时玩家重新回到队首
+	onMatch func(ticket *MatchTicket, room *logic.Room) error
+	// onQueueUpdate 在玩家排队位置变化时调用
+	onQueueUpdate func(ticket *MatchTicket, position int)
+
+	mu sync.Mutex
+}
+
+var (
+	matchmakerInstance *Matchmaker
+	matchmakerOnce     sync.Once
+)
+
+// GetMatchmaker 获取 Matchmaker 单例
+func GetMatchmaker() *Matchmaker {
+	matchmakerOnce.Do(func() {
+		matchmakerInstance = NewMatchmaker(GetRoomManager(), DefaultStakeTiers, defaultMatchMaxWait)
+	})
+	return matchmakerInstance
+}
+
+// NewMatchmaker 创建一个匹配服务
+func NewMatchmaker(rooms *RoomManager, tiers []StakeTier, maxWait time.Duration) *Matchmaker {
+	m := &Matchmaker{
+		rooms:   rooms,
+		tiers:   make(map[int32]StakeTier),
+		queues:  make(map[int32][]*MatchTicket),
+		tickets: make(map[int64]*MatchTicket),
+		maxWait: maxWait,
+	}
+	for _, tier := range tiers {
+		m.tiers[tier.ID] = tier
+	}
+	return m
+}
+
+// SetOnMatch 设置匹配成功回调
+func (m *Matchmaker) SetOnMatch(fn func(ticket *MatchTicket, room *logic.Room) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onMatch = fn
+}
+
+// SetOnQueueUpdate 设置排队位置变化回调
+func (m *Matchmaker) SetOnQueueUpdate(fn func(ticket *MatchTicket, position int)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onQueueUpdate = fn
+}
+
+// SetMaxWait 设置排队多久仍未匹配到房间时开新房间
+func (m *Matchmaker) SetMaxWait(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxWait = d
+}
+
+// Enqueue 将玩家加入指定场次的队列，返回排队位置（从1开始）
+func (m *Matchmaker) Enqueue(ticket *MatchTicket) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.rooms.IsDraining() {
+		return 0, ErrDraining
+	}
+	tier, exists := m.tiers[ticket.TierID]
+	if !exists {
+		return 0, errors.New("unknown stake tier")
+	}
+	if ticket.Balance < tier.MinBalance {
+		return 0, errors.New("insufficient balance for stake tier")
+	}
+	if _, queued := m.tickets[ticket.PlayerID]; queued {
+		return 0, errors.New("player already in match queue")
+	}
+
+	if ticket.EnqueuedAt.IsZero() {
+		ticket.EnqueuedAt = time.Now()
+	}
+	m.queues[ticket.TierID] = append(m.queues[ticket.TierID], ticket)
+	m.tickets[ticket.PlayerID] = ticket
+	ticket.position = len(m.queues[ticket.TierID])
+	return ticket.position, nil
+}
+
+// Cancel 将玩家移出队列
+func (m *Matchmaker) Cancel(playerID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ticket, queued := m.tickets[playerID]
+	if !queued {
+		return errors.New("player not in match queue")
+	}
+	m.removeTicket(ticket)
+	return nil
+}
+
+// CancelAll 清空所有队列，返回被移出队列的票据
+func (m *Matchmaker) CancelAll() []*MatchTicket {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tickets := make([]*MatchTicket, 0, len(m.tickets))
+	for _, queue := range m.queues {
+		tickets = append(tickets, queue...)
+	}
+	m.queues = make(map[int32][]*MatchTicket)
+	m.tickets = make(map[int64]*MatchTicket)
+	return tickets
+}
+
+// Position 返回玩家当前的排队位置，不在队列中时返回0
+func (m *Matchmaker) Position(playerID int64) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ticket, queued := m.tickets[playerID]
+	if !queued {
+		return 0
+	}
+	for i, t := range m.queues[ticket.TierID] {
+		if t == ticket {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// removeTicket 从队列中移除票据，调用方需持有锁
+func (m *Matchmaker) removeTicket(ticket *MatchTicket) {
+	queue := m.queues[ticket.TierID]
+	for i, t := range queue {
+		if t == ticket {
+			m.queues[ticket.TierID] = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+	delete(m.tickets, ticket.PlayerID)
+}
+
+// Start 启动匹配循环
+func (m *Matchmaker) Start() {
+	go func() {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			m.Tick(now)
+		}
+	}()
+}
+
+// matchAssignment 一次匹配的结果
+type matchAssignment struct {
+	ticket *MatchTicket
+	room   *logic.Room
+}
+
+// Tick 执行一轮匹配：优先填满已有的空位房间，排队超时的玩家开新房间
+func (m *Matchmaker) Tick(now time.Time) {
+	if m.rooms.IsDraining() {
+		return
+	}
+	m.mu.Lock()
+	var assignments []matchAssignment
+	for tierID := range m.queues {
+		assignments = append(assignments, m.matchTier(m.tiers[tierID], now)...)
+	}
+	onMatch := m.onMatch
+	m.mu.Unlock()
+
+	for _, a := range assignments {
+		if onMatch == nil {
+			continue
+		}
+		if err := onMatch(a.ticket, a.room); err != nil {
+			a.room.Logger().Error("Matchmaker failed to seat player", logger.PlayerID(a.ticket.PlayerID), logger.Err(err))
+			m.requeue(a.ticket)
+		}
+	}
+
+	m.notifyPositions()
+}
+
+// matchTier 为一个场次分配房间，调用方需持有锁
+func (m *Matchmaker) matchTier(tier StakeTier, now time.Time) []matchAssignment {
+	var assignments []matchAssignment
+	queue := m.queues[tier.ID]
+	if len(queue) == 0 {
+		return nil
+	}
+
+	// 1. 优先填入人数最多的未满房间
+	rooms := m.rooms.ListRooms(RoomFilter{MinBaseBet: tier.BaseBet, MaxBaseBet: tier.BaseBet, OnlyAvailable: true})
+	sort.SliceStable(rooms, func(i, j int) bool {
+		return rooms[i].GetPlayerCount() > rooms[j].GetPlayerCount()
+	})
+	for _, room := range rooms {
+		for free := room.FreeSeatCount(); free > 0 && len(queue) > 0; free-- {
+			assignments = append(assignments, matchAssignment{ticket: queue[0], room: room})
+			delete(m.tickets, queue[0].PlayerID)
+			queue = queue[1:]
+		}
+	}
+
+	// 2. 队首玩家等待过久时开新房间
+	for len(queue) > 0 && now.Sub(queue[0].EnqueuedAt) >= m.maxWait {
+		settings := m.rooms.DefaultSettings()
+		settings.BaseBet = tier.BaseBet
+		room, err := m.rooms.CreateRoomWithSettings(settings, "")
+		if err != nil {
+			logger.L().Error("Matchmaker failed to open room", "tier", tier.ID, logger.Err(err))
+			break
+		}
+		room.Logger().Info("Matchmaker opened room", "tier", tier.ID)
+		for free := settings.MaxPlayers; free > 0 && len(queue) > 0; free-- {
+			assignments = append(assignments, matchAssignment{ticket: queue[0], room: room})
+			delete(m.tickets, queue[0].PlayerID)
+			queue = queue[1:]
+		}
+	}
+
+	m.queues[tier.ID] = queue
+	return assignments
+}
+
+// requeue 将匹配失败的玩家放回队首
+func (m *Matchmaker) requeue(ticket *MatchTicket) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, queued := m.tickets[ticket.PlayerID]; queued {
+		return
+	}
+	m.queues[ticket.TierID] = append([]*MatchTicket{ticket}, m.queues[ticket.TierID]...)
+	m.tickets[ticket.PlayerID] = ticket
+}
+
+// notifyPositions 通知排队位置发生变化的玩家
+func (m *Matchmaker) notifyPositions() {
+	type update struct {
+		ticket   *MatchTicket
+		position int
+	}
+
+	m.mu.Lock()
+	var updates []update
+	for _, queue := range m.queues {
+		for i, ticket := range queue {
+			if ticket.position != i+1 {
+				ticket.position = i + 1
+				updates = append(updates, update{ticket: ticket, position: i + 1})
+			}
+		}
+	}
+	onQueueUpdate := m.onQueueUpdate
+	m.mu.Unlock()
+
+	if onQueueUpdate == nil {
+		return
+	}
+	for _, u := range updates {
+		onQueueUpdate(u.ticket, u.position)
+	}
+}
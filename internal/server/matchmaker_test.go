@@ -0,0 +1,166 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"xizexcample/internal/logic"
+)
+
+var testStakeTiers = []StakeTier{
+	{ID: 1, Name: "test", BaseBet: 1, MinBalance: 20},
+}
+
+// seatOnMatch 返回一个直接把玩家加入房间的匹配回调
+func seatOnMatch(rm *RoomManager) func(ticket *MatchTicket, room *logic.Room) error {
+	return func(ticket *MatchTicket, room *logic.Room) error {
+		if err := room.AddPlayer(logic.NewPlayer(ticket.PlayerID, "p", nil)); err != nil {
+			return err
+		}
+		rm.RegisterPlayer(ticket.PlayerID, room.ID)
+		return nil
+	}
+}
+
+func TestMatchmakerEnqueueAndCancel(t *testing.T) {
+	m := NewMatchmaker(newRoomManager(), testStakeTiers, time.Minute)
+
+	// 余额不足不能进入场次
+	if _, err := m.Enqueue(&MatchTicket{PlayerID: 1, Balance: 10, TierID: 1}); err == nil {
+		t.Error("Expected error for insufficient balance, but got nil")
+	}
+	// 未知场次
+	if _, err := m.Enqueue(&MatchTicket{PlayerID: 1, Balance: 100, TierID: 9}); err == nil {
+		t.Error("Expected error for unknown tier, but got nil")
+	}
+
+	pos1, err := m.Enqueue(&MatchTicket{PlayerID: 1, Balance: 100, TierID: 1})
+	if err != nil || pos1 != 1 {
+		t.Fatalf("Expected position 1, got %d (%v)", pos1, err)
+	}
+	pos2, _ := m.Enqueue(&MatchTicket{PlayerID: 2, Balance: 100, TierID: 1})
+	if pos2 != 2 {
+		t.Errorf("Expected position 2, got %d", pos2)
+	}
+	if _, err := m.Enqueue(&MatchTicket{PlayerID: 1, Balance: 100, TierID: 1}); err == nil {
+		t.Error("Expected error for duplicate enqueue, but got nil")
+	}
+
+	// 取消后后面的玩家前移
+	if err := m.Cancel(1); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	if pos := m.Position(2); pos != 1 {
+		t.Errorf("Expected player 2 to move to position 1, got %d", pos)
+	}
+	if err := m.Cancel(1); err == nil {
+		t.Error("Expected error for cancelling twice, but got nil")
+	}
+}
+
+func TestMatchmakerFillsExistingRoomFirst(t *testing.T) {
+	rm := newRoomManager()
+	m := NewMatchmaker(rm, testStakeTiers, time.Minute)
+	m.SetOnMatch(seatOnMatch(rm))
+
+	// 已有一个同场次的房间，其中有一名玩家
+	settings := logic.DefaultRoomSettings()
+	settings.BaseBet = 1
+	room, _ := rm.CreateRoomWithSettings(settings, "")
+	room.AddPlayer(logic.NewPlayer(100, "host", nil))
+
+	now := time.Now()
+	m.Enqueue(&MatchTicket{PlayerID: 1, Balance: 100, TierID: 1, EnqueuedAt: now})
+	m.Tick(now)
+
+	if rm.GetRoomByPlayerID(1) != room {
+		t.Fatal("Expected player to be seated in the existing room")
+	}
+	if m.Position(1) != 0 {
+		t.Error("Expected player to leave the queue after matching")
+	}
+}
+
+func TestMatchmakerSkipsLockedSeats(t *testing.T) {
+	rm := newRoomManager()
+	m := NewMatchmaker(rm, testStakeTiers, time.Minute)
+	matched := 0
+	seat := seatOnMatch(rm)
+	m.SetOnMatch(func(ticket *MatchTicket, room *logic.Room) error {
+		matched++
+		return seat(ticket, room)
+	})
+
+	// 已有一个同场次的房间，房主锁定了三个座位，只剩一个空座位
+	settings := logic.DefaultRoomSettings()
+	settings.BaseBet = 1
+	room, _ := rm.CreateRoomWithSettings(settings, "")
+	room.AddPlayer(logic.NewPlayer(100, "host", nil))
+	room.SetOwner(100)
+	for _, s := range []int{3, 4, 5} {
+		if err := room.LockSeat(100, s, true); err != nil {
+			t.Fatalf("LockSeat failed: %v", err)
+		}
+	}
+
+	now := time.Now()
+	for id := int64(1); id <= 3; id++ {
+		m.Enqueue(&MatchTicket{PlayerID: id, Balance: 100, TierID: 1, EnqueuedAt: now})
+	}
+	m.Tick(now)
+
+	// 只给空座位分配一名玩家，其余玩家留在队列中
+	if matched != 1 || rm.GetRoomByPlayerID(1) != room {
+		t.Errorf("Expected only player 1 to be matched into the room, got %d matches", matched)
+	}
+	if m.Position(2) != 1 || m.Position(3) != 2 {
+		t.Errorf("Expected players 2 and 3 to stay queued in order, got %d and %d", m.Position(2), m.Position(3))
+	}
+}
+
+func TestMatchmakerOpensRoomAfterMaxWait(t *testing.T) {
+	rm := newRoomManager()
+	m := NewMatchmaker(rm, testStakeTiers, 10*time.Second)
+	m.SetOnMatch(seatOnMatch(rm))
+
+	start := time.Now()
+	for id := int64(1); id <= 7; id++ {
+		m.Enqueue(&MatchTicket{PlayerID: id, Balance: 100, TierID: 1, EnqueuedAt: start})
+	}
+
+	// 未超时不开新房间
+	m.Tick(start.Add(5 * time.Second))
+	if len(rm.ListRooms(RoomFilter{})) != 0 {
+		t.Fatal("Expected no room to be opened before max wait")
+	}
+
+	// 超时后开新房间，前5人进入第一个房间，剩余2人进入第二个房间
+	m.Tick(start.Add(10 * time.Second))
+	rooms := rm.ListRooms(RoomFilter{})
+	if len(rooms) != 2 {
+		t.Fatalf("Expected 2 rooms to be opened, got %d", len(rooms))
+	}
+	if rooms[0].GetPlayerCount() != 5 || rooms[1].GetPlayerCount() != 2 {
+		t.Errorf("Expected 5 and 2 players, got %d and %d", rooms[0].GetPlayerCount(), rooms[1].GetPlayerCount())
+	}
+	if rooms[0].Settings.BaseBet != 1 {
+		t.Errorf("Expected new room to use tier base bet, got %d", rooms[0].Settings.BaseBet)
+	}
+}
+
+func TestMatchmakerRequeuesOnFailedMatch(t *testing.T) {
+	rm := newRoomManager()
+	m := NewMatchmaker(rm, testStakeTiers, 0)
+	m.SetOnMatch(func(ticket *MatchTicket, room *logic.Room) error {
+		return errTestSeat
+	})
+
+	m.Enqueue(&MatchTicket{PlayerID: 1, Balance: 100, TierID: 1})
+	m.Tick(time.Now())
+
+	if m.Position(1) != 1 {
+		t.Error("Expected player to be back at the head of the queue")
+	}
+}
+
+var errTestSeat = errors.New("seat taken")
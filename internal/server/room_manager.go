@@ -2,10 +2,20 @@ package server
 
 import (
 	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"xizexcample/internal/logic"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/snapshot"
 )
 
+// firstAllocatedRoomID 是由 RoomManager 自动分配的第一个房间ID
+const firstAllocatedRoomID int32 = 100000
+
 // RoomManager 是一个全局单例，用于管理所有房间
 var (
 	roomManagerInstance *RoomManager
@@ -14,36 +24,261 @@ var (
 
 // RoomManager 房间管理器
 type RoomManager struct {
-	rooms      map[int32]*logic.Room // key: roomID
-	playerRoom map[int64]int32       // key: playerID, value: roomID
-	mu         sync.RWMutex
+	rooms       map[int32]*logic.Room // key: roomID
+	playerRoom  map[int64]int32       // key: playerID, value: roomID
+	inviteCodes map[string]int32      // key: inviteCode, value: roomID
+	nextRoomID  int32
+	rake        logic.RakePolicy                         // 新建房间使用的抽水策略
+	defaults    *logic.RoomSettings                      // 新建房间的默认设置，nil 表示使用 logic.DefaultRoomSettings
+	snapshots   snapshot.Store                           // 房间快照存储，nil 表示不保存快照
+	onDissolve  func(*logic.Room, logic.DissolveOutcome) // 投票者离开使解散投票有了结果时调用
+	draining    atomic.Bool                              // 停服排空中，不再创建房间和开始新的一局
+	maintenance atomic.Pointer[Maintenance]              // 维护模式状态，nil 表示未开启
+
+	snapshotInterval atomic.Int64  // 定期快照的间隔，不大于 0 表示不定期保存
+	snapshotReset    chan struct{} // 快照间隔变化时唤醒快照循环
+	mu               sync.RWMutex
+}
+
+// RoomFilter 大厅房间列表的过滤条件，零值字段表示不过滤
+type RoomFilter struct {
+	MinBaseBet    int64
+	MaxBaseBet    int64
+	RuleSet       logic.RuleSet
+	OnlyAvailable bool // 只返回未满且处于等待阶段的房间
 }
 
 // GetRoomManager 获取 RoomManager 单例
 func GetRoomManager() *RoomManager {
 	once.Do(func() {
-		roomManagerInstance = &RoomManager{
-			rooms:      make(map[int32]*logic.Room),
-			playerRoom: make(map[int64]int32),
-		}
+		roomManagerInstance = newRoomManager()
 	})
 	return roomManagerInstance
 }
 
+// newRoomManager 创建一个空的房间管理器
+func newRoomManager() *RoomManager {
+	return &RoomManager{
+		rooms:       make(map[int32]*logic.Room),
+		playerRoom:  make(map[int64]int32),
+		inviteCodes: make(map[string]int32),
+		nextRoomID:  firstAllocatedRoomID,
+
+		snapshotReset: make(chan struct{}, 1),
+	}
+}
+
 // CreateRoom 创建一个新房间
 func (rm *RoomManager) CreateRoom(roomID int32) (*logic.Room, error) {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
+	if rm.IsDraining() {
+		return nil, ErrDraining
+	}
 	if _, exists := rm.rooms[roomID]; exists {
 		return nil, errors.New("room already exists")
 	}
 
-	room := logic.NewRoom(roomID)
+	settings := rm.defaultSettingsLocked()
+	room := logic.NewRoomWithSettings(roomID, settings)
 	rm.rooms[roomID] = room
+	rm.installHooksLocked(room)
 	return room, nil
 }
 
+// SetDissolveHook 设置投票者离开使解散投票有了结果时的回调，对已有和之后创建的房间都生效
+func (rm *RoomManager) SetDissolveHook(hook func(*logic.Room, logic.DissolveOutcome)) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.onDissolve = hook
+}
+
+// dissolveDecided 调用解散投票回调，没有设置回调时不做任何事
+func (rm *RoomManager) dissolveDecided(room *logic.Room, outcome logic.DissolveOutcome) {
+	rm.mu.RLock()
+	hook := rm.onDissolve
+	rm.mu.RUnlock()
+	if hook != nil {
+		hook(room, outcome)
+	}
+}
+
+// SetRakePolicy 设置之后新建房间使用的抽水策略
+func (rm *RoomManager) SetRakePolicy(policy logic.RakePolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.rake = policy
+	return nil
+}
+
+// SetDefaultSettings 设置之后新建房间的默认设置，客户端未填写的字段使用这些值；
+// 抽水策略由 SetRakePolicy 设置，忽略 settings 中的抽水策略
+func (rm *RoomManager) SetDefaultSettings(settings logic.RoomSettings) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	settings.Rake = rm.rake
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	rm.defaults = &settings
+	return nil
+}
+
+// DefaultSettings 返回新建房间的默认设置
+func (rm *RoomManager) DefaultSettings() logic.RoomSettings {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	return rm.defaultSettingsLocked()
+}
+
+// defaultSettingsLocked 返回新建房间的默认设置，调用方需持有锁
+func (rm *RoomManager) defaultSettingsLocked() logic.RoomSettings {
+	settings := logic.DefaultRoomSettings()
+	if rm.defaults != nil {
+		settings = *rm.defaults
+		settings.BidMultiples = slices.Clone(settings.BidMultiples)
+		settings.BetMultiples = slices.Clone(settings.BetMultiples)
+	}
+	settings.Rake = rm.rake
+	return settings
+}
+
+// ErrPasswordNotPrivate 只有私人房间可以设置密码
+var ErrPasswordNotPrivate = errors.New("only private rooms can have a password")
+
+// CreateRoomWithSettings 按指定设置创建房间，房间ID由 RoomManager 分配，抽水策略使用服务器配置
+// 私人房间会同时分配一个6位数字邀请码；password 为空表示不需要密码，房间在大厅中可见之前就已设置好
+func (rm *RoomManager) CreateRoomWithSettings(settings logic.RoomSettings, password string) (*logic.Room, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if rm.IsDraining() {
+		return nil, ErrDraining
+	}
+	settings.Rake = rm.rake
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	if password != "" && !settings.IsPrivate {
+		return nil, ErrPasswordNotPrivate
+	}
+
+	roomID := rm.allocateRoomID()
+	room := logic.NewRoomWithSettings(roomID, settings)
+	room.SetPassword(password)
+	if settings.IsPrivate {
+		room.InviteCode = rm.allocateInviteCode()
+		rm.inviteCodes[room.InviteCode] = roomID
+	}
+	rm.rooms[roomID] = room
+	rm.installHooksLocked(room)
+	return room, nil
+}
+
+// allocateInviteCode 生成一个未被占用的6位数字邀请码，调用方需持有写锁
+func (rm *RoomManager) allocateInviteCode() string {
+	for {
+		code := fmt.Sprintf("%06d", rand.Intn(1000000))
+		if _, exists := rm.inviteCodes[code]; !exists {
+			return code
+		}
+	}
+}
+
+// GetRoomByInviteCode 根据邀请码获取私人房间
+func (rm *RoomManager) GetRoomByInviteCode(code string) (*logic.Room, error) {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	roomID, exists := rm.inviteCodes[code]
+	if !exists {
+		return nil, errors.New("invalid invite code")
+	}
+	room, exists := rm.rooms[roomID]
+	if !exists {
+		return nil, errors.New("room not found")
+	}
+	return room, nil
+}
+
+// allocateRoomID 分配一个未被占用的房间ID，调用方需持有写锁
+func (rm *RoomManager) allocateRoomID() int32 {
+	for {
+		roomID := rm.nextRoomID
+		rm.nextRoomID++
+		if rm.nextRoomID <= 0 {
+			rm.nextRoomID = firstAllocatedRoomID
+		}
+		if _, exists := rm.rooms[roomID]; !exists {
+			return roomID
+		}
+	}
+}
+
+// ListRooms 返回满足过滤条件的公开房间，按房间ID排序，私人房间不会出现在列表中
+func (rm *RoomManager) ListRooms(filter RoomFilter) []*logic.Room {
+	rm.mu.RLock()
+	rooms := make([]*logic.Room, 0, len(rm.rooms))
+	for _, room := range rm.rooms {
+		rooms = append(rooms, room)
+	}
+	rm.mu.RUnlock()
+
+	result := make([]*logic.Room, 0, len(rooms))
+	for _, room := range rooms {
+		if filter.match(room) {
+			result = append(result, room)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// GetAllRooms 返回所有房间（包括私人房间），按房间ID排序
+func (rm *RoomManager) GetAllRooms() []*logic.Room {
+	rm.mu.RLock()
+	rooms := make([]*logic.Room, 0, len(rm.rooms))
+	for _, room := range rm.rooms {
+		rooms = append(rooms, room)
+	}
+	rm.mu.RUnlock()
+
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].ID < rooms[j].ID
+	})
+	return rooms
+}
+
+// match 检查房间是否满足过滤条件
+func (f RoomFilter) match(room *logic.Room) bool {
+	settings := room.Settings
+	if settings.IsPrivate {
+		return false
+	}
+	if f.MinBaseBet > 0 && settings.BaseBet < f.MinBaseBet {
+		return false
+	}
+	if f.MaxBaseBet > 0 && settings.BaseBet > f.MaxBaseBet {
+		return false
+	}
+	if f.RuleSet != logic.RULE_SET_UNKNOWN && settings.RuleSet != f.RuleSet {
+		return false
+	}
+	if f.OnlyAvailable {
+		if room.IsFull() || room.GetFSM().GetCurrentState() != logic.STATE_WAITING_FOR_PLAYERS {
+			return false
+		}
+	}
+	return true
+}
+
 // GetRoom 根据房间ID获取房间
 func (rm *RoomManager) GetRoom(roomID int32) (*logic.Room, error) {
 	rm.mu.RLock()
@@ -61,11 +296,21 @@ func (rm *RoomManager) DeleteRoom(roomID int32) error {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
-	if _, exists := rm.rooms[roomID]; !exists {
+	room, exists := rm.rooms[roomID]
+	if !exists {
 		return errors.New("room not found")
 	}
 
+	if room.InviteCode != "" {
+		delete(rm.inviteCodes, room.InviteCode)
+	}
 	delete(rm.rooms, roomID)
+	room.Close()
+	if rm.snapshots != nil {
+		if err := rm.snapshots.Delete(roomID); err != nil {
+			logger.L().Error("Failed to delete snapshot", logger.RoomID(roomID), logger.Err(err))
+		}
+	}
 	return nil
 }
 
@@ -87,6 +332,20 @@ func (rm *RoomManager) GetRoomByPlayerID(playerID int64) *logic.Room {
 	return room
 }
 
+// FindPlayerRoom 查找玩家所在的房间，包括断线后已注销但仍占着座位等待重连的玩家，
+// 玩家不在任何房间中时返回 nil
+func (rm *RoomManager) FindPlayerRoom(playerID int64) *logic.Room {
+	if room := rm.GetRoomByPlayerID(playerID); room != nil {
+		return room
+	}
+	for _, room := range rm.GetAllRooms() {
+		if _, err := room.GetPlayer(playerID); err == nil {
+			return room
+		}
+	}
+	return nil
+}
+
 // RegisterPlayer 将玩家注册到房间
 func (rm *RoomManager) RegisterPlayer(playerID int64, roomID int32) {
 	rm.mu.Lock()
@@ -1,7 +1,11 @@
 package server
 
 import (
+	"errors"
 	"testing"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/snapshot"
+	"xizexcample/internal/wallet"
 )
 
 func TestRoomManagerCreateAndGetRoom(t *testing.T) {
@@ -71,3 +75,274 @@ func TestRoomManagerDeleteRoom(t *testing.T) {
 		t.Error("Expected error for getting deleted room, but got nil")
 	}
 }
+
+func TestRoomManagerCreateRoomWithSettings(t *testing.T) {
+	rm := GetRoomManager()
+
+	settings := logic.DefaultRoomSettings()
+	settings.BaseBet = 10
+	room1, err := rm.CreateRoomWithSettings(settings, "")
+	if err != nil {
+		t.Fatalf("CreateRoomWithSettings failed: %v", err)
+	}
+	room2, err := rm.CreateRoomWithSettings(settings, "")
+	if err != nil {
+		t.Fatalf("CreateRoomWithSettings failed: %v", err)
+	}
+
+	// 自动分配的房间ID应互不相同
+	if room1.ID == room2.ID {
+		t.Errorf("Expected distinct room IDs, both got %d", room1.ID)
+	}
+	if room1.Settings.BaseBet != 10 {
+		t.Errorf("Expected base bet 10, got %d", room1.Settings.BaseBet)
+	}
+
+	// 非法设置应被拒绝
+	settings.MaxPlayers = 1
+	if _, err := rm.CreateRoomWithSettings(settings, ""); err == nil {
+		t.Error("Expected error for invalid settings, but got nil")
+	}
+}
+
+func TestRoomManagerCreateRoomWithPassword(t *testing.T) {
+	rm := GetRoomManager()
+
+	// 非私人房间不能设置密码，也不会创建房间
+	rm.mu.RLock()
+	before := len(rm.rooms)
+	rm.mu.RUnlock()
+	if _, err := rm.CreateRoomWithSettings(logic.DefaultRoomSettings(), "8888"); !errors.Is(err, ErrPasswordNotPrivate) {
+		t.Errorf("Expected ErrPasswordNotPrivate for a public room with a password, got %v", err)
+	}
+	rm.mu.RLock()
+	after := len(rm.rooms)
+	rm.mu.RUnlock()
+	if after != before {
+		t.Errorf("Expected no room to be created, room count went from %d to %d", before, after)
+	}
+
+	// 私人房间创建时就已设置好密码
+	settings := logic.DefaultRoomSettings()
+	settings.IsPrivate = true
+	room, err := rm.CreateRoomWithSettings(settings, "8888")
+	if err != nil {
+		t.Fatalf("CreateRoomWithSettings failed: %v", err)
+	}
+	defer rm.DeleteRoom(room.ID)
+	if !room.HasPassword() || !room.CheckPassword("8888") {
+		t.Error("Expected the private room to have the password set")
+	}
+}
+
+func TestRoomManagerListRooms(t *testing.T) {
+	rm := GetRoomManager()
+
+	// 使用独特的底注以免与其他测试创建的房间混淆
+	classic := logic.DefaultRoomSettings()
+	classic.BaseBet = 7001
+	allCompare := logic.DefaultRoomSettings()
+	allCompare.BaseBet = 7002
+	allCompare.RuleSet = logic.RULE_ALL_COMPARE
+	allCompare.MaxPlayers = 2
+
+	classicRoom, _ := rm.CreateRoomWithSettings(classic, "")
+	fullRoom, _ := rm.CreateRoomWithSettings(allCompare, "")
+	fullRoom.AddPlayer(logic.NewPlayer(7101, "p1", nil))
+	fullRoom.AddPlayer(logic.NewPlayer(7102, "p2", nil))
+
+	rooms := rm.ListRooms(RoomFilter{MinBaseBet: 7001, MaxBaseBet: 7002})
+	if len(rooms) != 2 || rooms[0].ID != classicRoom.ID || rooms[1].ID != fullRoom.ID {
+		t.Fatalf("Expected both rooms ordered by ID, got %d rooms", len(rooms))
+	}
+
+	rooms = rm.ListRooms(RoomFilter{MinBaseBet: 7001, MaxBaseBet: 7002, RuleSet: logic.RULE_ALL_COMPARE})
+	if len(rooms) != 1 || rooms[0].ID != fullRoom.ID {
+		t.Errorf("Expected only the all-compare room, got %d rooms", len(rooms))
+	}
+
+	rooms = rm.ListRooms(RoomFilter{MinBaseBet: 7001, MaxBaseBet: 7002, OnlyAvailable: true})
+	if len(rooms) != 1 || rooms[0].ID != classicRoom.ID {
+		t.Errorf("Expected full room to be filtered out, got %d rooms", len(rooms))
+	}
+}
+
+func TestRoomManagerPrivateRoom(t *testing.T) {
+	rm := GetRoomManager()
+
+	settings := logic.DefaultRoomSettings()
+	settings.BaseBet = 7003
+	settings.IsPrivate = true
+	room, err := rm.CreateRoomWithSettings(settings, "")
+	if err != nil {
+		t.Fatalf("CreateRoomWithSettings failed: %v", err)
+	}
+
+	// 私人房间应分配6位数字邀请码
+	if len(room.InviteCode) != 6 {
+		t.Fatalf("Expected 6-digit invite code, got %q", room.InviteCode)
+	}
+
+	found, err := rm.GetRoomByInviteCode(room.InviteCode)
+	if err != nil || found.ID != room.ID {
+		t.Fatalf("GetRoomByInviteCode failed: %v", err)
+	}
+
+	// 私人房间不出现在大厅列表中
+	if rooms := rm.ListRooms(RoomFilter{MinBaseBet: 7003, MaxBaseBet: 7003}); len(rooms) != 0 {
+		t.Errorf("Expected private room to be hidden from listing, got %d rooms", len(rooms))
+	}
+
+	// 删除房间后邀请码失效
+	rm.DeleteRoom(room.ID)
+	if _, err := rm.GetRoomByInviteCode(room.InviteCode); err == nil {
+		t.Error("Expected error for invite code of deleted room, but got nil")
+	}
+}
+
+func TestRoomManagerInviteCodesUnique(t *testing.T) {
+	rm := GetRoomManager()
+
+	settings := logic.DefaultRoomSettings()
+	settings.IsPrivate = true
+	codes := make(map[string]bool)
+	for i := 0; i < 200; i++ {
+		room, err := rm.CreateRoomWithSettings(settings, "")
+		if err != nil {
+			t.Fatalf("CreateRoomWithSettings failed: %v", err)
+		}
+		if codes[room.InviteCode] {
+			t.Fatalf("Duplicate invite code %s", room.InviteCode)
+		}
+		codes[room.InviteCode] = true
+		defer rm.DeleteRoom(room.ID)
+	}
+}
+
+func TestRoomManagerRakePolicy(t *testing.T) {
+	rm := newRoomManager()
+
+	if err := rm.SetRakePolicy(logic.RakePolicy{Mode: logic.RAKE_PERCENT}); err == nil {
+		t.Error("Expected error for invalid rake policy, but got nil")
+	}
+	policy := logic.RakePolicy{Mode: logic.RAKE_PERCENT, Percent: 5, Cap: 20}
+	if err := rm.SetRakePolicy(policy); err != nil {
+		t.Fatalf("SetRakePolicy failed: %v", err)
+	}
+
+	// 客户端填写的抽水策略会被服务器配置覆盖
+	settings := logic.DefaultRoomSettings()
+	settings.Rake = logic.RakePolicy{}
+	room, err := rm.CreateRoomWithSettings(settings, "")
+	if err != nil {
+		t.Fatalf("CreateRoomWithSettings failed: %v", err)
+	}
+	if room.Settings.Rake != policy {
+		t.Errorf("Expected rake policy %+v, got %+v", policy, room.Settings.Rake)
+	}
+}
+
+func TestRoomManagerDefaultSettings(t *testing.T) {
+	rm := newRoomManager()
+	policy := logic.RakePolicy{Mode: logic.RAKE_FLAT_FEE, Fee: 1}
+	if err := rm.SetRakePolicy(policy); err != nil {
+		t.Fatalf("SetRakePolicy failed: %v", err)
+	}
+
+	invalid := logic.DefaultRoomSettings()
+	invalid.MaxPlayers = 1
+	if err := rm.SetDefaultSettings(invalid); err == nil {
+		t.Error("Expected error for invalid default settings, but got nil")
+	}
+
+	defaults := logic.DefaultRoomSettings()
+	defaults.BaseBet = 5
+	defaults.MaxPlayers = 6
+	if err := rm.SetDefaultSettings(defaults); err != nil {
+		t.Fatalf("SetDefaultSettings failed: %v", err)
+	}
+	room, err := rm.CreateRoom(200001)
+	if err != nil {
+		t.Fatalf("CreateRoom failed: %v", err)
+	}
+	defer rm.DeleteRoom(room.ID)
+	if room.Settings.BaseBet != 5 || room.Settings.MaxPlayers != 6 || room.Settings.Rake != policy {
+		t.Errorf("Expected the configured defaults with the rake policy, got %+v", room.Settings)
+	}
+
+	// 返回的默认设置是副本，修改它不影响之后新建的房间
+	settings := rm.DefaultSettings()
+	settings.BetMultiples[0] = 9
+	if rm.DefaultSettings().BetMultiples[0] != 1 {
+		t.Error("Expected DefaultSettings to return a copy")
+	}
+}
+
+func TestRoomManagerSnapshotsAndRestore(t *testing.T) {
+	store := snapshot.NewMemoryStore()
+	w, _ := wallet.NewWallet(wallet.NewMemoryStore())
+
+	rm := newRoomManager()
+	rm.EnableSnapshots(store)
+	settings := logic.DefaultRoomSettings()
+	settings.IsPrivate = true
+	room, err := rm.CreateRoomWithSettings(settings, "")
+	if err != nil {
+		t.Fatalf("CreateRoomWithSettings failed: %v", err)
+	}
+	room.SetWallet(w)
+	room.AddPlayer(logic.NewPlayer(1, "p1", nil))
+	room.AddPlayer(logic.NewPlayer(2, "p2", nil))
+	closed, _ := rm.CreateRoomWithSettings(logic.DefaultRoomSettings(), "")
+
+	// 状态转换时自动保存快照
+	for _, p := range room.GetPlayers() {
+		p.SetStatus(logic.STATUS_READY)
+	}
+	if err := room.GetFSM().StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	room.GetFSM().DealCards()
+	snaps, _ := store.LoadAll()
+	if len(snaps) != 1 || snaps[0].State != logic.STATE_BIDDING {
+		t.Fatalf("Expected a bidding snapshot after the transition, got %d snapshots", len(snaps))
+	}
+
+	// 定期快照覆盖所有房间，删除房间时删除快照
+	rm.SnapshotAll()
+	rm.DeleteRoom(closed.ID)
+	snaps, _ = store.LoadAll()
+	if len(snaps) != 1 {
+		t.Fatalf("Expected the deleted room's snapshot to be removed, got %d snapshots", len(snaps))
+	}
+
+	// 模拟重启
+	room.Close()
+	restarted := newRoomManager()
+	restarted.EnableSnapshots(store)
+	restored, err := restarted.RestoreRooms(w)
+	if err != nil {
+		t.Fatalf("RestoreRooms failed: %v", err)
+	}
+	if restored != 1 {
+		t.Fatalf("Expected 1 restored room, got %d", restored)
+	}
+	restoredRoom, err := restarted.GetRoomByInviteCode(room.InviteCode)
+	if err != nil {
+		t.Fatalf("Expected the restored room to keep its invite code: %v", err)
+	}
+	defer restoredRoom.Close()
+	if restoredRoom.ID != room.ID || restoredRoom.GetPlayerCount() != 2 {
+		t.Errorf("Expected room %d with 2 players, got room %d with %d players", room.ID, restoredRoom.ID, restoredRoom.GetPlayerCount())
+	}
+	if restoredRoom.GetFSM().GetCurrentState() != logic.STATE_BIDDING {
+		t.Errorf("Expected the round to resume in bidding, got %d", restoredRoom.GetFSM().GetCurrentState())
+	}
+
+	// 新分配的房间ID不与恢复的房间冲突
+	next, _ := restarted.CreateRoomWithSettings(logic.DefaultRoomSettings(), "")
+	defer next.Close()
+	if next.ID <= restoredRoom.ID {
+		t.Errorf("Expected a new room ID after %d, got %d", restoredRoom.ID, next.ID)
+	}
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"time"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/snapshot"
+	"xizexcample/internal/wallet"
+)
+
+// EnableSnapshots 开启房间快照：每个房间在每次状态转换后保存一次快照，
+// 之后创建或恢复的房间同样会保存快照
+func (rm *RoomManager) EnableSnapshots(store snapshot.Store) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.snapshots = store
+	for _, room := range rm.rooms {
+		rm.installSnapshotHookLocked(room)
+	}
+}
+
+// installSnapshotHookLocked 让房间在每次状态转换后保存快照，调用方需持有写锁
+func (rm *RoomManager) installSnapshotHookLocked(room *logic.Room) {
+	if rm.snapshots == nil {
+		return
+	}
+	store := rm.snapshots
+	room.GetFSM().SetTransitionHook(func(logic.GameState) {
+		saveSnapshot(store, room)
+	})
+}
+
+// saveSnapshot 保存房间快照，失败时只记录日志，不影响牌局进行
+func saveSnapshot(store snapshot.Store, room *logic.Room) {
+	if err := store.Save(room.Snapshot()); err != nil {
+		room.Logger().Error("Failed to save snapshot", logger.Err(err))
+	}
+}
+
+// SnapshotAll 为所有房间保存一次快照，用于记录两次状态转换之间的操作（例如下注）；
+// 快照在房间的 Do 中保存，不会落在一次请求的处理过程中间
+func (rm *RoomManager) SnapshotAll() {
+	rm.mu.RLock()
+	store := rm.snapshots
+	rooms := make([]*logic.Room, 0, len(rm.rooms))
+	for _, room := range rm.rooms {
+		rooms = append(rooms, room)
+	}
+	rm.mu.RUnlock()
+
+	if store == nil {
+		return
+	}
+	for _, room := range rooms {
+		room.Do(func() {
+			saveSnapshot(store, room)
+		})
+	}
+}
+
+// StartSnapshots 启动定期快照循环，间隔可以在运行中通过 SetSnapshotInterval 修改
+func (rm *RoomManager) StartSnapshots(interval time.Duration) {
+	rm.SetSnapshotInterval(interval)
+	go func() {
+		for {
+			interval := rm.SnapshotInterval()
+			if interval <= 0 {
+				<-rm.snapshotReset
+				continue
+			}
+
+			timer := time.NewTimer(interval)
+			select {
+			case <-timer.C:
+				rm.SnapshotAll()
+			case <-rm.snapshotReset:
+				timer.Stop()
+			}
+		}
+	}()
+}
+
+// SetSnapshotInterval 修改定期快照的间隔，不大于 0 时只在状态转换时保存快照
+func (rm *RoomManager) SetSnapshotInterval(interval time.Duration) {
+	rm.snapshotInterval.Store(int64(interval))
+	select {
+	case rm.snapshotReset <- struct{}{}:
+	default:
+	}
+}
+
+// SnapshotInterval 返回定期快照的间隔
+func (rm *RoomManager) SnapshotInterval() time.Duration {
+	return time.Duration(rm.snapshotInterval.Load())
+}
+
+// RestoreRooms 从快照恢复服务器重启前的房间，玩家需要重连才能回到房间；
+// 无法恢复的快照会被删除，返回恢复的房间数
+func (rm *RoomManager) RestoreRooms(w *wallet.Wallet) (int, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if rm.snapshots == nil {
+		return 0, nil
+	}
+	snaps, err := rm.snapshots.LoadAll()
+	if err != nil {
+		return 0, err
+	}
+
+	restored := 0
+	for _, snap := range snaps {
+		if _, exists := rm.rooms[snap.ID]; exists {
+			logger.L().Error("Skipping snapshot: room already exists", logger.RoomID(snap.ID))
+			continue
+		}
+		room, err := logic.RestoreRoom(snap, w)
+		if err != nil {
+			logger.L().Info("Discarding snapshot", logger.RoomID(snap.ID), logger.Err(err))
+			if err := rm.snapshots.Delete(snap.ID); err != nil {
+				logger.L().Error("Failed to delete snapshot", logger.RoomID(snap.ID), logger.Err(err))
+			}
+			continue
+		}
+
+		rm.rooms[room.ID] = room
+		if room.InviteCode != "" {
+			rm.inviteCodes[room.InviteCode] = room.ID
+		}
+		if room.ID >= rm.nextRoomID {
+			rm.nextRoomID = room.ID + 1
+		}
+		rm.installHooksLocked(room)
+		saveSnapshot(rm.snapshots, room)
+		restored++
+		room.Logger().Info("Restored room", "state", room.GetFSM().GetCurrentState().String(), "players", room.GetPlayerCount())
+	}
+	return restored, nil
+}
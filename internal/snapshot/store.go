@@ -0,0 +1,165 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"xizexcample/internal/logic"
+)
+
+// Store 房间快照存储，每个房间只保留最新的一份快照
+type Store interface {
+	// Save 保存房间快照，覆盖该房间之前的快照
+	Save(snap *logic.RoomSnapshot) error
+	// Delete 删除房间的快照，快照不存在时不报错
+	Delete(roomID int32) error
+	// LoadAll 按房间ID顺序返回所有快照
+	LoadAll() ([]*logic.RoomSnapshot, error)
+}
+
+// MemoryStore 内存中的快照存储
+type MemoryStore struct {
+	snapshots map[int32][]byte // key: roomID，保存序列化后的快照，与文件存储行为一致
+	mu        sync.Mutex
+}
+
+// NewMemoryStore 创建一个内存快照存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{snapshots: make(map[int32][]byte)}
+}
+
+// Save 保存房间快照
+func (s *MemoryStore) Save(snap *logic.RoomSnapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots[snap.ID] = data
+	return nil
+}
+
+// Delete 删除房间的快照
+func (s *MemoryStore) Delete(roomID int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.snapshots, roomID)
+	return nil
+}
+
+// LoadAll 返回所有快照
+func (s *MemoryStore) LoadAll() ([]*logic.RoomSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]*logic.RoomSnapshot, 0, len(s.snapshots))
+	for _, data := range s.snapshots {
+		var snap logic.RoomSnapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return nil, err
+		}
+		result = append(result, &snap)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+// FileStore 以 JSON 文件保存快照的存储，每个房间一个文件 room-<roomID>.json。
+// 写入时先写临时文件再重命名，崩溃时不会留下写了一半的快照
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore 打开或创建快照目录
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create snapshot directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// path 返回房间快照文件的路径
+func (s *FileStore) path(roomID int32) string {
+	return filepath.Join(s.dir, fmt.Sprintf("room-%d.json", roomID))
+}
+
+// Save 将快照写入临时文件并刷盘，然后原子地替换旧快照
+func (s *FileStore) Save(snap *logic.RoomSnapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(s.dir, fmt.Sprintf("room-%d-*.tmp", snap.ID))
+	if err != nil {
+		return fmt.Errorf("create snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write snapshot file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync snapshot file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close snapshot file: %w", err)
+	}
+	return os.Rename(tmp.Name(), s.path(snap.ID))
+}
+
+// Delete 删除房间的快照文件
+func (s *FileStore) Delete(roomID int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(roomID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove snapshot file: %w", err)
+	}
+	return nil
+}
+
+// LoadAll 读取目录中的所有快照文件
+func (s *FileStore) LoadAll() ([]*logic.RoomSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot directory: %w", err)
+	}
+
+	var result []*logic.RoomSnapshot
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, "room-") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot file %s: %w", name, err)
+		}
+		var snap logic.RoomSnapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return nil, fmt.Errorf("snapshot file %s: %w", name, err)
+		}
+		result = append(result, &snap)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
@@ -0,0 +1,62 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"xizexcample/internal/logic"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+
+	room := logic.NewRoom(102)
+	room.AddPlayer(logic.NewPlayer(1, "p1", nil))
+	defer room.Close()
+	other := logic.NewRoom(101)
+	defer other.Close()
+
+	if err := store.Save(room.Snapshot()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := store.Save(other.Snapshot()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	// 再次保存覆盖旧快照
+	room.AddPlayer(logic.NewPlayer(2, "p2", nil))
+	if err := store.Save(room.Snapshot()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	snaps, err := store.LoadAll()
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	if len(snaps) != 2 || snaps[0].ID != 101 || snaps[1].ID != 102 {
+		t.Fatalf("Expected snapshots of rooms 101 and 102, got %d snapshots", len(snaps))
+	}
+	if len(snaps[1].Players) != 2 || snaps[1].Players[1].Nickname != "p2" {
+		t.Errorf("Expected the latest snapshot of room 102, got %+v", snaps[1].Players)
+	}
+
+	// 不留下临时文件
+	files, _ := os.ReadDir(dir)
+	if len(files) != 2 {
+		t.Errorf("Expected 2 snapshot files, got %d", len(files))
+	}
+
+	if err := store.Delete(102); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := store.Delete(102); err != nil {
+		t.Errorf("Expected deleting a missing snapshot to succeed, got %v", err)
+	}
+	snaps, _ = store.LoadAll()
+	if len(snaps) != 1 || snaps[0].ID != 101 {
+		t.Errorf("Expected only room 101 to remain, got %d snapshots", len(snaps))
+	}
+}
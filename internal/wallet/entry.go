@@ -0,0 +1,32 @@
+package wallet
+
+import (
+	"time"
+)
+
+// HouseAccountID 庄家平台账户，初始赠送的分数从该账户划出
+const HouseAccountID int64 = 0
+
+// Reason 分数变动原因
+type Reason string
+
+const (
+	REASON_INITIAL_GRANT    Reason = "initial_grant"    // 新账户初始赠送
+	REASON_ROUND_SETTLEMENT Reason = "round_settlement" // 牌局结算
+	REASON_RAKE             Reason = "rake"             // 平台抽水
+	REASON_ROUND_REFUND     Reason = "round_refund"     // 作废牌局的退款
+	REASON_ADMIN_ADJUSTMENT Reason = "admin_adjustment" // 管理员调整余额
+	REASON_ACCOUNT_CLOSED   Reason = "account_closed"   // 关闭账户，余额划回平台账户
+)
+
+// Entry 一条账目，每次转账会在双方账户上各记一条
+type Entry struct {
+	ID             int64     `json:"id"`
+	AccountID      int64     `json:"account_id"`
+	Amount         int64     `json:"amount"`  // 正数为收入，负数为支出
+	Balance        int64     `json:"balance"` // 记账后的账户余额
+	RoundID        string    `json:"round_id,omitempty"`
+	Reason         Reason    `json:"reason"`
+	CounterpartyID int64     `json:"counterparty_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
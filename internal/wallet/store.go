@@ -0,0 +1,111 @@
+package wallet
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Store 账目存储，只追加不修改
+type Store interface {
+	// Append 原子地追加一组账目
+	Append(entries ...Entry) error
+	// Load 按记账顺序返回所有账目
+	Load() ([]Entry, error)
+}
+
+// MemoryStore 内存中的账目存储
+type MemoryStore struct {
+	entries []Entry
+	mu      sync.Mutex
+}
+
+// NewMemoryStore 创建一个内存账目存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Append 追加账目
+func (s *MemoryStore) Append(entries ...Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entries...)
+	return nil
+}
+
+// Load 返回所有账目的副本
+func (s *MemoryStore) Load() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Entry(nil), s.entries...), nil
+}
+
+// FileStore 以 JSON Lines 格式保存账目的文件存储，每行一条账目
+type FileStore struct {
+	path string
+	file *os.File
+	mu   sync.Mutex
+}
+
+// NewFileStore 打开或创建一个账目文件
+func NewFileStore(path string) (*FileStore, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open ledger file: %w", err)
+	}
+	return &FileStore{path: path, file: file}, nil
+}
+
+// Append 将账目写入文件末尾并刷盘，一组账目在一次写入中完成
+func (s *FileStore) Append(entries ...Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var buf []byte
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	if _, err := s.file.Write(buf); err != nil {
+		return fmt.Errorf("write ledger file: %w", err)
+	}
+	return s.file.Sync()
+}
+
+// Load 从文件读取所有账目
+func (s *FileStore) Load() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("open ledger file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("ledger file line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Close 关闭账目文件
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,296 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrInsufficientBalance 账户余额不足以支付时返回
+var ErrInsufficientBalance = errors.New("insufficient balance")
+
+// Wallet 基于账目的钱包，余额由账目累加而来，所有分数变动都必须通过账目记录
+type Wallet struct {
+	store    Store
+	balances map[int64]int64 // key: accountID
+	nextID   int64
+	mu       sync.Mutex
+}
+
+var (
+	walletInstance *Wallet
+	walletMu       sync.Mutex
+)
+
+// GetWallet 获取全局钱包，未通过 SetWallet 指定时使用内存存储
+func GetWallet() *Wallet {
+	walletMu.Lock()
+	defer walletMu.Unlock()
+	if walletInstance == nil {
+		walletInstance, _ = NewWallet(NewMemoryStore())
+	}
+	return walletInstance
+}
+
+// SetWallet 替换全局钱包，应在服务启动时、创建房间之前调用
+func SetWallet(w *Wallet) {
+	walletMu.Lock()
+	defer walletMu.Unlock()
+	walletInstance = w
+}
+
+// NewWallet 从账目存储中回放所有账目，恢复每个账户的余额
+func NewWallet(store Store) (*Wallet, error) {
+	entries, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	w := &Wallet{
+		store:    store,
+		balances: make(map[int64]int64),
+	}
+	for _, entry := range entries {
+		w.balances[entry.AccountID] += entry.Amount
+		if entry.ID > w.nextID {
+			w.nextID = entry.ID
+		}
+	}
+	return w, nil
+}
+
+// HasAccount 检查账户是否存在
+func (w *Wallet) HasAccount(accountID int64) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, exists := w.balances[accountID]
+	return exists
+}
+
+// Balance 获取账户余额，账户不存在时返回 0
+func (w *Wallet) Balance(accountID int64) int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.balances[accountID]
+}
+
+// OpenAccount 账户不存在时开户并从平台账户赠送初始分数，返回账户余额
+func (w *Wallet) OpenAccount(accountID int64, grant int64) (int64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if balance, exists := w.balances[accountID]; exists {
+		return balance, nil
+	}
+	if err := w.transferLocked(HouseAccountID, accountID, grant, "", REASON_INITIAL_GRANT); err != nil {
+		return 0, err
+	}
+	return w.balances[accountID], nil
+}
+
+// Transfer 从一个账户向另一个账户转账，双方各记一条账目；除平台账户外余额不能为负
+func (w *Wallet) Transfer(from, to int64, amount int64, roundID string, reason Reason) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.transferLocked(from, to, amount, roundID, reason)
+}
+
+// transferLocked 执行转账，调用方需持有锁
+func (w *Wallet) transferLocked(from, to int64, amount int64, roundID string, reason Reason) error {
+	if amount <= 0 {
+		return errors.New("transfer amount must be positive")
+	}
+	if from == to {
+		return errors.New("cannot transfer to the same account")
+	}
+	if from != HouseAccountID && w.balances[from] < amount {
+		return fmt.Errorf("account %d: %w", from, ErrInsufficientBalance)
+	}
+
+	now := time.Now()
+	debit := Entry{
+		ID:             w.nextID + 1,
+		AccountID:      from,
+		Amount:         -amount,
+		Balance:        w.balances[from] - amount,
+		RoundID:        roundID,
+		Reason:         reason,
+		CounterpartyID: to,
+		CreatedAt:      now,
+	}
+	credit := Entry{
+		ID:             w.nextID + 2,
+		AccountID:      to,
+		Amount:         amount,
+		Balance:        w.balances[to] + amount,
+		RoundID:        roundID,
+		Reason:         reason,
+		CounterpartyID: from,
+		CreatedAt:      now,
+	}
+	if err := w.store.Append(debit, credit); err != nil {
+		return err
+	}
+
+	w.nextID += 2
+	w.balances[from] = debit.Balance
+	w.balances[to] = credit.Balance
+	return nil
+}
+
+// Entries 获取一个账户的所有账目
+func (w *Wallet) Entries(accountID int64) ([]Entry, error) {
+	entries, err := w.store.Load()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Entry, 0)
+	for _, entry := range entries {
+		if entry.AccountID == accountID {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// HasRoundEntries 检查一局是否已经记过账
+func (w *Wallet) HasRoundEntries(roundID string) (bool, error) {
+	entries, err := w.store.Load()
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		if entry.RoundID == roundID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RefundRound 作废一局：按相反的顺序冲回该局的每一笔转账，使所有账户恢复到本局之前的余额；
+// 已经退回过的牌局不会重复退回，返回冲回的转账笔数
+func (w *Wallet) RefundRound(roundID string) (int, error) {
+	if roundID == "" {
+		return 0, errors.New("round ID must not be empty")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entries, err := w.store.Load()
+	if err != nil {
+		return 0, err
+	}
+	var debits []Entry
+	for _, entry := range entries {
+		if entry.RoundID != roundID {
+			continue
+		}
+		if entry.Reason == REASON_ROUND_REFUND {
+			return 0, nil
+		}
+		if entry.Amount < 0 {
+			debits = append(debits, entry)
+		}
+	}
+
+	for i := len(debits) - 1; i >= 0; i-- {
+		debit := debits[i]
+		if err := w.transferLocked(debit.CounterpartyID, debit.AccountID, -debit.Amount, roundID, REASON_ROUND_REFUND); err != nil {
+			return len(debits) - 1 - i, fmt.Errorf("refund entry %d: %w", debit.ID, err)
+		}
+	}
+	return len(debits), nil
+}
+
+// Adjust 管理员调整账户余额：正数从平台账户划入，负数划回平台账户，返回调整后的余额。
+// 账户必须已经存在，扣减后余额不能为负
+func (w *Wallet) Adjust(accountID int64, amount int64) (int64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if accountID == HouseAccountID {
+		return 0, errors.New("cannot adjust the house account")
+	}
+	if _, exists := w.balances[accountID]; !exists {
+		return 0, errors.New("account not found")
+	}
+	from, to := HouseAccountID, accountID
+	if amount < 0 {
+		from, to, amount = accountID, HouseAccountID, -amount
+	}
+	if err := w.transferLocked(from, to, amount, "", REASON_ADMIN_ADJUSTMENT); err != nil {
+		return 0, err
+	}
+	return w.balances[accountID], nil
+}
+
+// CloseAccounts 关闭所有满足条件的账户：余额全部划回平台账户，返回关闭时有余额的账户数。
+// 例如启动时关闭上次运行留下的游客账户，游客的余额不会带到下一次启动
+func (w *Wallet) CloseAccounts(match func(accountID int64) bool) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	closed := 0
+	for accountID, balance := range w.balances {
+		if accountID == HouseAccountID || balance <= 0 || !match(accountID) {
+			continue
+		}
+		if err := w.transferLocked(accountID, HouseAccountID, balance, "", REASON_ACCOUNT_CLOSED); err != nil {
+			return closed, err
+		}
+		closed++
+	}
+	return closed, nil
+}
+
+// SumByReason 汇总一个账户中指定原因的账目金额，例如平台账户收到的抽水总额
+func (w *Wallet) SumByReason(accountID int64, reason Reason) (int64, error) {
+	entries, err := w.Entries(accountID)
+	if err != nil {
+		return 0, err
+	}
+	var sum int64
+	for _, entry := range entries {
+		if entry.Reason == reason {
+			sum += entry.Amount
+		}
+	}
+	return sum, nil
+}
+
+// Reconcile 对账：每个账户的账目之和必须等于当前余额，每条账目记录的余额必须与累加结果一致，
+// 所有账目之和必须为 0（每笔转账都是一进一出）
+func (w *Wallet) Reconcile() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entries, err := w.store.Load()
+	if err != nil {
+		return err
+	}
+
+	sums := make(map[int64]int64)
+	var total int64
+	for _, entry := range entries {
+		sums[entry.AccountID] += entry.Amount
+		total += entry.Amount
+		if entry.Balance != sums[entry.AccountID] {
+			return fmt.Errorf("entry %d: recorded balance %d, expected %d", entry.ID, entry.Balance, sums[entry.AccountID])
+		}
+	}
+	if total != 0 {
+		return fmt.Errorf("ledger does not balance: entries sum to %d", total)
+	}
+	for accountID, balance := range w.balances {
+		if sums[accountID] != balance {
+			return fmt.Errorf("account %d: balance %d, entries sum to %d", accountID, balance, sums[accountID])
+		}
+	}
+	for accountID := range sums {
+		if _, exists := w.balances[accountID]; !exists {
+			return fmt.Errorf("account %d has entries but no balance", accountID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package wallet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenAccountGrantsOnce(t *testing.T) {
+	w, _ := NewWallet(NewMemoryStore())
+
+	balance, err := w.OpenAccount(1, 1000)
+	if err != nil || balance != 1000 {
+		t.Fatalf("OpenAccount = %d, %v; expected 1000", balance, err)
+	}
+	balance, _ = w.OpenAccount(1, 1000)
+	if balance != 1000 {
+		t.Errorf("Expected reopening an account not to grant again, got %d", balance)
+	}
+	if w.Balance(HouseAccountID) != -1000 {
+		t.Errorf("Expected grant to be drawn from the house account, got %d", w.Balance(HouseAccountID))
+	}
+
+	entries, _ := w.Entries(1)
+	if len(entries) != 1 || entries[0].Reason != REASON_INITIAL_GRANT || entries[0].CounterpartyID != HouseAccountID {
+		t.Errorf("Expected a single grant entry, got %+v", entries)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	w, _ := NewWallet(NewMemoryStore())
+	w.OpenAccount(1, 100)
+	w.OpenAccount(2, 100)
+
+	if err := w.Transfer(1, 2, 30, "round-1", REASON_ROUND_SETTLEMENT); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	if w.Balance(1) != 70 || w.Balance(2) != 130 {
+		t.Errorf("Expected balances 70 and 130, got %d and %d", w.Balance(1), w.Balance(2))
+	}
+
+	if err := w.Transfer(1, 2, 71, "round-2", REASON_ROUND_SETTLEMENT); !errors.Is(err, ErrInsufficientBalance) {
+		t.Errorf("Expected ErrInsufficientBalance, got %v", err)
+	}
+	if err := w.Transfer(1, 2, 0, "round-2", REASON_ROUND_SETTLEMENT); err == nil {
+		t.Error("Expected error for a zero transfer, but got nil")
+	}
+	if w.Balance(1) != 70 {
+		t.Errorf("Expected failed transfers not to change balances, got %d", w.Balance(1))
+	}
+
+	entries, _ := w.Entries(2)
+	last := entries[len(entries)-1]
+	if last.Amount != 30 || last.Balance != 130 || last.RoundID != "round-1" || last.CounterpartyID != 1 {
+		t.Errorf("Unexpected credit entry: %+v", last)
+	}
+	if err := w.Reconcile(); err != nil {
+		t.Errorf("Reconcile failed: %v", err)
+	}
+}
+
+func TestReconcileDetectsMismatch(t *testing.T) {
+	store := NewMemoryStore()
+	w, _ := NewWallet(store)
+	w.OpenAccount(1, 100)
+
+	// 绕过钱包直接写入一条不平衡的账目
+	store.Append(Entry{ID: 99, AccountID: 1, Amount: 5, Balance: 105})
+	if err := w.Reconcile(); err == nil {
+		t.Error("Expected reconcile to fail for an unbalanced ledger, but got nil")
+	}
+}
+
+func TestFileStoreReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	w, _ := NewWallet(store)
+	w.OpenAccount(1, 100)
+	w.OpenAccount(2, 100)
+	w.Transfer(2, 1, 40, "round-1", REASON_ROUND_SETTLEMENT)
+	store.Close()
+
+	// 重新打开后回放账目恢复余额
+	store, err = NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	defer store.Close()
+	reloaded, err := NewWallet(store)
+	if err != nil {
+		t.Fatalf("NewWallet failed: %v", err)
+	}
+	if reloaded.Balance(1) != 140 || reloaded.Balance(2) != 60 {
+		t.Errorf("Expected balances 140 and 60 after reload, got %d and %d", reloaded.Balance(1), reloaded.Balance(2))
+	}
+	if err := reloaded.Reconcile(); err != nil {
+		t.Errorf("Reconcile failed after reload: %v", err)
+	}
+
+	// 新的账目ID接着已有账目继续编号
+	reloaded.Transfer(1, 2, 10, "round-2", REASON_ROUND_SETTLEMENT)
+	entries, _ := reloaded.Entries(2)
+	if entries[len(entries)-1].ID != 8 {
+		t.Errorf("Expected entry IDs to continue after reload, got %d", entries[len(entries)-1].ID)
+	}
+
+	data, _ := os.ReadFile(path)
+	if len(data) == 0 || data[len(data)-1] != '\n' {
+		t.Error("Expected the ledger file to be newline-terminated JSON lines")
+	}
+}
+
+func TestWalletRefundRound(t *testing.T) {
+	w, _ := NewWallet(NewMemoryStore())
+	w.OpenAccount(1, 100)
+	w.OpenAccount(2, 100)
+	w.OpenAccount(3, 100)
+	w.Transfer(1, 2, 100, "round-1", REASON_ROUND_SETTLEMENT)
+	w.Transfer(2, 3, 150, "round-1", REASON_ROUND_SETTLEMENT)
+	w.Transfer(3, 1, 10, "round-2", REASON_ROUND_SETTLEMENT)
+
+	if settled, _ := w.HasRoundEntries("round-1"); !settled {
+		t.Error("Expected round-1 to have ledger entries")
+	}
+	if settled, _ := w.HasRoundEntries("round-3"); settled {
+		t.Error("Expected round-3 to have no ledger entries")
+	}
+
+	// 按相反顺序冲回：先 3 → 2 退 150，再 2 → 1 退 100，只影响 round-1
+	refunded, err := w.RefundRound("round-1")
+	if err != nil {
+		t.Fatalf("RefundRound failed: %v", err)
+	}
+	if refunded != 2 {
+		t.Errorf("Expected 2 refunded transfers, got %d", refunded)
+	}
+	if w.Balance(1) != 110 || w.Balance(2) != 100 || w.Balance(3) != 90 {
+		t.Errorf("Expected balances 110, 100 and 90, got %d, %d and %d", w.Balance(1), w.Balance(2), w.Balance(3))
+	}
+
+	// 重复退回不会再次转账
+	if refunded, _ := w.RefundRound("round-1"); refunded != 0 {
+		t.Errorf("Expected a second refund to be a no-op, got %d transfers", refunded)
+	}
+	if err := w.Reconcile(); err != nil {
+		t.Errorf("Reconcile failed: %v", err)
+	}
+}
+
+func TestAdjust(t *testing.T) {
+	w, _ := NewWallet(NewMemoryStore())
+	if _, err := w.Adjust(1, 100); err == nil {
+		t.Error("Expected adjusting a missing account to fail")
+	}
+	w.OpenAccount(1, 1000)
+
+	if balance, err := w.Adjust(1, 250); err != nil || balance != 1250 {
+		t.Errorf("Adjust(+250) = %d, %v; expected 1250", balance, err)
+	}
+	if balance, err := w.Adjust(1, -1000); err != nil || balance != 250 {
+		t.Errorf("Adjust(-1000) = %d, %v; expected 250", balance, err)
+	}
+	if _, err := w.Adjust(1, -300); !errors.Is(err, ErrInsufficientBalance) {
+		t.Errorf("Expected ErrInsufficientBalance, got %v", err)
+	}
+	if _, err := w.Adjust(HouseAccountID, 100); err == nil {
+		t.Error("Expected adjusting the house account to fail")
+	}
+	if sum, _ := w.SumByReason(1, REASON_ADMIN_ADJUSTMENT); sum != -750 {
+		t.Errorf("Expected adjustments to sum to -750, got %d", sum)
+	}
+	if err := w.Reconcile(); err != nil {
+		t.Errorf("Expected the ledger to reconcile, got %v", err)
+	}
+}
+
+func TestCloseAccounts(t *testing.T) {
+	w, _ := NewWallet(NewMemoryStore())
+	w.OpenAccount(1, 1000)
+	w.OpenAccount(-5, 1000)
+	w.OpenAccount(-6, 1000)
+	w.Transfer(-6, 1, 1000, "round-1", REASON_ROUND_SETTLEMENT)
+
+	// 只关闭负数ID的账户，余额为 0 的账户不记账
+	closed, err := w.CloseAccounts(func(accountID int64) bool { return accountID < 0 })
+	if err != nil || closed != 1 {
+		t.Errorf("CloseAccounts = %d, %v; expected 1", closed, err)
+	}
+	if w.Balance(-5) != 0 || w.Balance(-6) != 0 || w.Balance(1) != 2000 {
+		t.Errorf("Expected closed accounts to be empty and others untouched, got %d, %d, %d", w.Balance(-5), w.Balance(-6), w.Balance(1))
+	}
+	if sum, _ := w.SumByReason(-5, REASON_ACCOUNT_CLOSED); sum != -1000 {
+		t.Errorf("Expected the closing entry to move 1000 to the house, got %d", sum)
+	}
+	if err := w.Reconcile(); err != nil {
+		t.Errorf("Expected the ledger to reconcile, got %v", err)
+	}
+}
@@ -1,12 +1,29 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"github.com/aceld/zinx/zconf"
+	"github.com/aceld/zinx/ziface"
 	"github.com/aceld/zinx/znet"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+	"xizexcample/internal/admin"
+	"xizexcample/internal/audit"
 	"xizexcample/internal/conf"
+	"xizexcample/internal/health"
+	"xizexcample/internal/history"
+	"xizexcample/internal/logic"
 	"xizexcample/internal/pkg/logger"
+	"xizexcample/internal/profile"
 	"xizexcample/internal/router"
 	"xizexcample/internal/server"
+	"xizexcample/internal/snapshot"
+	"xizexcample/internal/wallet"
 )
 
 var (
@@ -14,14 +31,147 @@ var (
 	commit  = "none"
 )
 
-func init() {
-	logger.InfoLogger.Printf("Version: %s, Commit: %s", version, commit)
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
 
-func main() {
+// run 启动服务并阻塞到停服完成。启动失败时返回错误，返回前执行所有 defer，
+// 已打开的账目、牌局记录和审计日志都会关闭，日志也会写完
+func run() (err error) {
+	// 加载配置：配置文件、环境变量和命令行参数依次覆盖默认值
+	loader := conf.NewLoader()
+	loader.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	// 按配置初始化日志，之后的日志写入轮转的日志文件
+	logs, err := logger.Init(logger.Config{
+		Level:      cfg.Log.Level,
+		Format:     cfg.Log.Format,
+		File:       cfg.Log.File,
+		MaxSizeMB:  cfg.Log.MaxSizeMB,
+		MaxBackups: cfg.Log.MaxBackups,
+	})
+	if err != nil {
+		return fmt.Errorf("initialize logger: %w", err)
+	}
+	defer logs.Close()
+	defer func() {
+		if err != nil {
+			logger.L().Error("Server failed", logger.Err(err))
+		}
+	}()
+	logger.L().Info("Starting", "version", version, "commit", commit, "config", loader.Path)
+
 	// 在服务器启动前，通过 zconf.GlobalObject 配置全局设置
-	zconf.GlobalObject.Host = conf.AppConfig.ServerHost
-	zconf.GlobalObject.TCPPort = conf.AppConfig.ServerPort
+	zconf.GlobalObject.Name = cfg.Server.Name
+	zconf.GlobalObject.Host = cfg.Server.Host
+	zconf.GlobalObject.TCPPort = cfg.Server.Port
+	zconf.GlobalObject.MaxConn = cfg.Server.MaxConn
+	zconf.GlobalObject.WorkerPoolSize = cfg.Server.WorkerPoolSize
+	zconf.GlobalObject.MaxWorkerTaskLen = cfg.Server.MaxWorkerTaskLen
+
+	// 打开钱包账目文件，回放账目恢复余额并对账
+	if err := os.MkdirAll(filepath.Dir(cfg.Storage.LedgerPath), 0755); err != nil {
+		return fmt.Errorf("create ledger directory: %w", err)
+	}
+	ledger, err := wallet.NewFileStore(cfg.Storage.LedgerPath)
+	if err != nil {
+		return fmt.Errorf("open ledger: %w", err)
+	}
+	defer ledger.Close()
+	w, err := wallet.NewWallet(ledger)
+	if err != nil {
+		return fmt.Errorf("load ledger: %w", err)
+	}
+	if err := w.Reconcile(); err != nil {
+		return fmt.Errorf("ledger does not reconcile: %w", err)
+	}
+	wallet.SetWallet(w)
+
+	// 设置新玩家的初始分数，以及新建房间使用的抽水策略和默认设置
+	logic.InitialScore = cfg.Economy.InitialScore
+	rake, err := cfg.Economy.Rake.Policy()
+	if err != nil {
+		return fmt.Errorf("invalid rake config: %w", err)
+	}
+	if err := server.GetRoomManager().SetRakePolicy(rake); err != nil {
+		return fmt.Errorf("invalid rake config: %w", err)
+	}
+	settings, err := cfg.RoomSettings()
+	if err != nil {
+		return fmt.Errorf("invalid room config: %w", err)
+	}
+	if err := server.GetRoomManager().SetDefaultSettings(settings); err != nil {
+		return fmt.Errorf("invalid room config: %w", err)
+	}
+
+	// 打开牌局记录目录，每局结算后追加一条记录
+	recorder, err := history.NewFileRecorder(cfg.Storage.HistoryDir)
+	if err != nil {
+		return fmt.Errorf("open hand history: %w", err)
+	}
+	defer recorder.Close()
+	history.SetRecorder(recorder)
+
+	// 打开玩家档案目录，登录时加载档案，每局结算后写回
+	profiles, err := profile.NewFileRepository(cfg.Storage.ProfileDir)
+	if err != nil {
+		return fmt.Errorf("open profile repository: %w", err)
+	}
+	profile.SetRepository(profiles)
+
+	// 配置了签名密钥时才允许登录，否则所有连接以游客身份游戏；
+	// 游客的钱包账户只在本次启动内有效，下次启动时余额划回平台账户
+	if cfg.Auth.LoginSecret != "" {
+		auth, err := profile.NewAuthenticator(cfg.Auth.LoginSecret)
+		if err != nil {
+			return fmt.Errorf("invalid login secret: %w", err)
+		}
+		profile.SetAuthenticator(auth)
+	} else {
+		logger.L().Info("Login disabled: no login secret configured")
+	}
+
+	// 打开审计日志，记录玩家操作和服务器决定，从最后一条记录继续哈希链
+	auditLog, err := audit.NewFileLog(cfg.Storage.AuditPath)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	defer auditLog.Close()
+	audit.SetLog(auditLog)
+
+	// 从快照恢复重启前的房间，之后每次状态转换和每隔一段时间保存快照
+	snapshots, err := snapshot.NewFileStore(cfg.Storage.SnapshotDir)
+	if err != nil {
+		return fmt.Errorf("open snapshot store: %w", err)
+	}
+	server.GetRoomManager().EnableSnapshots(snapshots)
+	restored, err := server.GetRoomManager().RestoreRooms(w)
+	if err != nil {
+		return fmt.Errorf("restore rooms: %w", err)
+	}
+	logger.L().Info("Restored rooms from snapshots", "rooms", restored)
+
+	// 游客不随快照恢复，关闭上次运行留下的游客账户，作废牌局的退款已在恢复房间时完成
+	closed, err := w.CloseAccounts(logic.IsGuest)
+	if err != nil {
+		return fmt.Errorf("close guest accounts: %w", err)
+	}
+	logger.L().Info("Closed guest wallet accounts from the previous run", "accounts", closed)
+	server.GetRoomManager().StartSnapshots(seconds(cfg.Timers.SnapshotInterval))
+
+	// 应用运行中可以重新加载的配置
+	applyRuntimeConfig(cfg)
+
+	// 恢复时仍在抢庄阶段的房间重新开始抢庄计时
+	router.ResumeBidTimers(server.GetRoomManager().GetAllRooms())
 
 	// 创建一个Zinx服务器句柄
 	s := znet.NewServer()
@@ -32,8 +182,137 @@ func main() {
 	// 设置钩子
 	s.SetOnConnStop(server.OnConnStop)
 
-	// 启动服务
-	logger.InfoLogger.Printf("Starting server at %s:%d...", zconf.GlobalObject.Host, zconf.GlobalObject.TCPPort)
-	s.Serve()
-	logger.InfoLogger.Println("Server stopped.")
+	// 启动快速匹配服务
+	server.GetMatchmaker().Start()
+
+	// 启动管理后台，未配置管理令牌时不启动
+	if cfg.Admin.Token != "" {
+		adminServer, err := admin.NewServer(cfg.Admin.Token, s)
+		if err != nil {
+			return fmt.Errorf("create admin server: %w", err)
+		}
+		if err := adminServer.Start(cfg.Admin.Addr); err != nil {
+			return fmt.Errorf("start admin server: %w", err)
+		}
+		defer adminServer.Shutdown(context.Background())
+	} else {
+		logger.L().Info("Admin server disabled: no admin token configured")
+	}
+
+	// 启动健康检查服务，供编排系统探测存活和就绪状态
+	if cfg.Health.Addr != "" {
+		healthServer := health.NewServer(version, commit,
+			health.NotDraining(server.GetRoomManager()),
+			health.NotInMaintenance(server.GetRoomManager()),
+			health.WritableDir("ledger", filepath.Dir(cfg.Storage.LedgerPath)),
+			health.WritableDir("snapshots", cfg.Storage.SnapshotDir),
+			health.WritableDir("history", cfg.Storage.HistoryDir),
+			health.WritableDir("profiles", cfg.Storage.ProfileDir),
+			health.WritableDir("audit", filepath.Dir(cfg.Storage.AuditPath)),
+			health.RoomGoroutines(cfg.Health.MaxRoomGoroutines),
+			health.WorkerBacklog(s, cfg.Health.MaxWorkerBacklog),
+		)
+		if err := healthServer.Start(cfg.Health.Addr); err != nil {
+			return fmt.Errorf("start health server: %w", err)
+		}
+		defer healthServer.Shutdown(context.Background())
+	}
+
+	// 启动服务，收到 SIGHUP 时重新加载配置，收到停服信号后排空进行中的牌局再退出
+	logger.L().Info("Starting server", "host", zconf.GlobalObject.Host, "port", zconf.GlobalObject.TCPPort)
+	s.Start()
+	handleSignals(s, loader, cfg)
+	logger.L().Info("Server stopped")
+	return nil
+}
+
+// handleSignals 阻塞直到收到 SIGINT 或 SIGTERM 并完成停服排空；期间每次收到 SIGHUP 都重新加载配置
+func handleSignals(s ziface.IServer, loader *conf.Loader, cfg *conf.Config) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	started, current := cfg, cfg
+	for sig := range signals {
+		if sig == syscall.SIGHUP {
+			current = reloadConfig(s, loader, started, current)
+			continue
+		}
+		drain(s, signals, sig, seconds(current.Timers.ShutdownTimeout))
+		return
+	}
+}
+
+// reloadConfig 重新加载配置并应用其中可以在运行中修改的部分，配置不合法时保留当前配置；
+// started 是启动时的配置，与之相比需要重启才能生效的变化只记录警告
+func reloadConfig(s ziface.IServer, loader *conf.Loader, started, current *conf.Config) *conf.Config {
+	next, err := loader.Load()
+	if err != nil {
+		logger.L().Error("Failed to reload config, keeping the current config", logger.Err(err))
+		return current
+	}
+
+	applyRuntimeConfig(next)
+	if next.Announcement != "" && next.Announcement != current.Announcement {
+		router.BroadcastAnnouncement(s, next.Announcement)
+	}
+	if changed := conf.RestartRequired(started, next); len(changed) > 0 {
+		logger.L().Warn("Config changes take effect after a restart", "fields", changed)
+	}
+	logger.L().Info("Config reloaded", "config", loader.Path)
+	return next
+}
+
+// applyRuntimeConfig 应用运行中可以重新加载的配置：各类时限和日志级别
+func applyRuntimeConfig(cfg *conf.Config) {
+	if err := logger.SetLevel(cfg.Log.Level); err != nil {
+		logger.L().Error("Invalid log level", logger.Err(err))
+	}
+	server.GetRoomManager().SetSnapshotInterval(seconds(cfg.Timers.SnapshotInterval))
+	server.GetMatchmaker().SetMaxWait(seconds(cfg.Timers.MatchMaxWait))
+	logic.SetBidTimeout(seconds(cfg.Timers.BidTimeout))
+	logic.SetDissolveVoteTimeout(seconds(cfg.Timers.DissolveVoteTimeout))
+	logic.SetReconnectTimeout(seconds(cfg.Timers.ReconnectTimeout))
+}
+
+// drain 停服排空：不再接受新的加入和新的一局，通知所有连接，等待进行中的牌局打完或超时，
+// 保存房间快照后关闭所有连接。排空期间再次收到停服信号时不再等待，立即停服
+func drain(s ziface.IServer, signals <-chan os.Signal, sig os.Signal, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	logger.L().Info("Received shutdown signal, draining", "signal", sig.String(), "deadline", deadline.Format(time.RFC3339))
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				if sig == syscall.SIGHUP {
+					continue
+				}
+				logger.L().Info("Received shutdown signal again, stopping without waiting for rounds", "signal", sig.String())
+				cancel()
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	roomManager := server.GetRoomManager()
+	roomManager.BeginDrain()
+	if tickets := server.GetMatchmaker().CancelAll(); len(tickets) > 0 {
+		logger.L().Info("Removed players from the match queue", "players", len(tickets))
+	}
+	router.BroadcastMaintenance(s, true, "Server is shutting down for maintenance", deadline)
+
+	// 超时未打完的牌局随快照保存，重启后继续或作废退款
+	if remaining := roomManager.WaitForRounds(ctx); remaining > 0 {
+		logger.L().Error("Drain deadline reached with rounds still in progress", "rounds", remaining)
+	}
+	roomManager.SnapshotAll()
+	s.Stop()
+}
+
+// seconds 将以秒为单位的配置转换为时长
+func seconds(n int) time.Duration {
+	return time.Duration(n) * time.Second
 }
@@ -0,0 +1,142 @@
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/aceld/zinx/znet"
+	"github.com/aceld/zinx/zpack"
+	"github.com/stretchr/testify/assert"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/router"
+	"xizexcample/internal/server"
+)
+
+// newPlayerRequest 构造一个已登录玩家发出的请求
+func newPlayerRequest(conn *fakeConn, msgID msg.MsgID, data string) ziface.IRequest {
+	return znet.NewRequest(conn, zpack.NewMsgPackage(uint32(msgID), []byte(data)))
+}
+
+// TestForceStartSitOutPlayerCannotAct 测试强制开局时未准备的玩家不能抢庄和下注
+func TestForceStartSitOutPlayerCannotAct(t *testing.T) {
+	// 1. Setup: 三名玩家入座，只有两名准备
+	roomManager := server.GetRoomManager()
+	room, err := roomManager.CreateRoomWithSettings(logic.DefaultRoomSettings(), "")
+	assert.NoError(t, err)
+	defer roomManager.DeleteRoom(room.ID)
+
+	conns := make(map[int64]*fakeConn)
+	for _, id := range []int64{9101, 9102, 9103} {
+		conn := newFakeConn(uint64(id))
+		conn.SetProperty("playerID", id)
+		conns[id] = conn
+		player := logic.NewPlayer(id, "Player", conn)
+		assert.NoError(t, room.AddPlayer(player))
+		roomManager.RegisterPlayer(id, room.ID)
+	}
+	room.SetOwner(9101)
+	for _, id := range []int64{9101, 9102} {
+		player, _ := room.GetPlayer(id)
+		player.SetStatus(logic.STATUS_READY)
+	}
+
+	// 2. 房主强制开局，未准备的玩家不参与本局
+	(&router.ForceStartHandler{}).Handle(newPlayerRequest(conns[9101], msg.MsgID_C2S_FORCE_START_REQ, `{}`))
+	assert.Equal(t, logic.STATE_BIDDING, room.GetFSM().GetCurrentState(), "Round should be in the bidding state")
+	sitOut, _ := room.GetPlayer(9103)
+	assert.NotEqual(t, logic.STATUS_PLAYING, sitOut.GetStatus(), "Unready player should sit out the round")
+
+	// 3. 未参与的玩家抢庄被拒绝
+	(&router.BidBankerHandler{}).Handle(newPlayerRequest(conns[9103], msg.MsgID_C2S_BID_BANKER_REQ, `{"multiple": 1}`))
+	assert.False(t, room.HasBanker(), "Sit-out player should not become the banker")
+	assert.Equal(t, logic.STATE_BIDDING, room.GetFSM().GetCurrentState(), "Round should still be in the bidding state")
+
+	// 4. 参与的玩家都抢庄后选出庄家，进入下注阶段
+	(&router.BidBankerHandler{}).Handle(newPlayerRequest(conns[9101], msg.MsgID_C2S_BID_BANKER_REQ, `{"multiple": 1}`))
+	(&router.BidBankerHandler{}).Handle(newPlayerRequest(conns[9102], msg.MsgID_C2S_BID_BANKER_REQ, `{"multiple": 0}`))
+	assert.Equal(t, int64(9101), room.GetBankerID(), "Participating player should become the banker")
+	assert.Equal(t, logic.STATE_BETTING, room.GetFSM().GetCurrentState(), "Round should be in the betting state")
+
+	// 5. 未参与的玩家下注被拒绝
+	(&router.PlaceBetHandler{}).Handle(newPlayerRequest(conns[9103], msg.MsgID_C2S_PLACE_BET_REQ, `{"multiple": 1}`))
+	assert.False(t, sitOut.HasBet(), "Sit-out player should not be able to bet")
+	assert.Equal(t, logic.STATE_BETTING, room.GetFSM().GetCurrentState(), "Round should still be in the betting state")
+}
+
+// TestBidBankerHighestMultipleWins 测试所有玩家抢庄后由倍数最高的玩家坐庄，先抢的玩家不会因为先抢而坐庄
+func TestBidBankerHighestMultipleWins(t *testing.T) {
+	// 1. Setup: 三名玩家准备后开局，进入抢庄阶段
+	roomManager := server.GetRoomManager()
+	room, err := roomManager.CreateRoomWithSettings(logic.DefaultRoomSettings(), "")
+	assert.NoError(t, err)
+	defer roomManager.DeleteRoom(room.ID)
+
+	conns := make(map[int64]*fakeConn)
+	for _, id := range []int64{9111, 9112, 9113} {
+		conn := newFakeConn(uint64(id))
+		conn.SetProperty("playerID", id)
+		conns[id] = conn
+		player := logic.NewPlayer(id, "Player", conn)
+		assert.NoError(t, room.AddPlayer(player))
+		roomManager.RegisterPlayer(id, room.ID)
+		player.SetStatus(logic.STATUS_READY)
+	}
+	room.SetOwner(9111)
+	(&router.ForceStartHandler{}).Handle(newPlayerRequest(conns[9111], msg.MsgID_C2S_FORCE_START_REQ, `{}`))
+	assert.Equal(t, logic.STATE_BIDDING, room.GetFSM().GetCurrentState(), "Round should be in the bidding state")
+
+	// 2. 第一位玩家不抢，仍在等待其他玩家抢庄
+	(&router.BidBankerHandler{}).Handle(newPlayerRequest(conns[9111], msg.MsgID_C2S_BID_BANKER_REQ, `{"multiple": 0}`))
+	assert.False(t, room.HasBanker(), "Declining should not make the player the banker")
+	assert.Equal(t, logic.STATE_BIDDING, room.GetFSM().GetCurrentState(), "Round should still be in the bidding state")
+
+	// 3. 同一玩家不能再次抢庄
+	(&router.BidBankerHandler{}).Handle(newPlayerRequest(conns[9111], msg.MsgID_C2S_BID_BANKER_REQ, `{"multiple": 4}`))
+	first, _ := room.GetPlayer(9111)
+	assert.Equal(t, int32(0), first.GetBidMultiple(), "A second bid should be rejected")
+
+	// 4. 后抢的玩家倍数更高，所有人抢完后由倍数最高的玩家坐庄
+	(&router.BidBankerHandler{}).Handle(newPlayerRequest(conns[9112], msg.MsgID_C2S_BID_BANKER_REQ, `{"multiple": 3}`))
+	assert.False(t, room.HasBanker(), "Banker should not be chosen before everyone has bid")
+	(&router.BidBankerHandler{}).Handle(newPlayerRequest(conns[9113], msg.MsgID_C2S_BID_BANKER_REQ, `{"multiple": 1}`))
+	assert.Equal(t, int64(9112), room.GetBankerID(), "Highest bidder should become the banker")
+	banker, _ := room.GetPlayer(9112)
+	assert.Equal(t, int32(3), banker.GetBidMultiple(), "Banker should keep the bid multiple")
+	assert.Equal(t, logic.STATE_BETTING, room.GetFSM().GetCurrentState(), "Round should be in the betting state")
+}
+
+// TestBidBankerTimerExpires 测试抢庄时限到时未抢庄的玩家视为不抢，由已抢庄的玩家坐庄
+func TestBidBankerTimerExpires(t *testing.T) {
+	logic.SetBidTimeout(20 * time.Millisecond)
+	defer logic.SetBidTimeout(0)
+
+	// 1. Setup: 两名玩家准备后开局，进入抢庄阶段
+	roomManager := server.GetRoomManager()
+	room, err := roomManager.CreateRoomWithSettings(logic.DefaultRoomSettings(), "")
+	assert.NoError(t, err)
+	defer roomManager.DeleteRoom(room.ID)
+
+	conns := make(map[int64]*fakeConn)
+	for _, id := range []int64{9121, 9122} {
+		conn := newFakeConn(uint64(id))
+		conn.SetProperty("playerID", id)
+		conns[id] = conn
+		player := logic.NewPlayer(id, "Player", conn)
+		assert.NoError(t, room.AddPlayer(player))
+		roomManager.RegisterPlayer(id, room.ID)
+		player.SetStatus(logic.STATUS_READY)
+	}
+	room.SetOwner(9121)
+	room.Do(func() {
+		(&router.ForceStartHandler{}).Handle(newPlayerRequest(conns[9121], msg.MsgID_C2S_FORCE_START_REQ, `{}`))
+		(&router.BidBankerHandler{}).Handle(newPlayerRequest(conns[9122], msg.MsgID_C2S_BID_BANKER_REQ, `{"multiple": 2}`))
+	})
+
+	// 2. 另一名玩家一直不抢庄，时限到时由已抢庄的玩家坐庄
+	assert.Eventually(t, func() bool {
+		return room.GetFSM().GetCurrentState() == logic.STATE_BETTING
+	}, time.Second, 5*time.Millisecond, "Bid timer should end the bidding")
+	assert.Equal(t, int64(9122), room.GetBankerID(), "Only bidder should become the banker")
+}
@@ -1,8 +1,14 @@
 package e2e
 
 import (
+	"fmt"
 	"testing"
-	"time"
+
+	"github.com/stretchr/testify/assert"
+	"xizexcample/internal/logic"
+	"xizexcample/internal/msg"
+	"xizexcample/internal/router"
+	"xizexcample/internal/server"
 )
 
 // 这是一个 E2E 测试的占位符。
@@ -41,3 +47,51 @@ func TestRoomFull(t *testing.T) {
 
 	t.Skip("E2E test is a placeholder. Requires a real TCP client implementation.")
 }
+
+// TestGuestDoesNotCollideWithLoggedInPlayer 测试游客的连接ID与登录玩家的ID相同时，两人仍是不同的玩家
+func TestGuestDoesNotCollideWithLoggedInPlayer(t *testing.T) {
+	// 1. Setup
+	roomManager := server.GetRoomManager()
+	room, err := roomManager.CreateRoomWithSettings(logic.DefaultRoomSettings(), "")
+	assert.NoError(t, err)
+	defer roomManager.DeleteRoom(room.ID)
+	joinData := fmt.Sprintf(`{"room_id": %d}`, room.ID)
+
+	// 2. 已登录的玩家 9201 和连接ID为 9201 的游客先后加入同一房间
+	member := newFakeConn(9301)
+	member.SetProperty("playerID", int64(9201))
+	(&router.JoinRoomHandler{}).Handle(newPlayerRequest(member, msg.MsgID_C2S_JOIN_ROOM_REQ, joinData))
+	guest := newFakeConn(9201)
+	(&router.JoinRoomHandler{}).Handle(newPlayerRequest(guest, msg.MsgID_C2S_JOIN_ROOM_REQ, joinData))
+
+	// 3. 两人各自入座，游客使用单独的ID
+	assert.Equal(t, 2, room.GetPlayerCount(), "Guest and logged-in player should take separate seats")
+	_, err = room.GetPlayer(9201)
+	assert.NoError(t, err, "Logged-in player should be seated with the login ID")
+	guestID, err := guest.GetProperty("playerID")
+	assert.NoError(t, err)
+	assert.NotEqual(t, int64(9201), guestID, "Guest should not take the logged-in player's ID")
+	_, err = room.GetPlayer(guestID.(int64))
+	assert.NoError(t, err, "Guest should be seated with the guest ID")
+}
+
+// TestGuestCannotTakeOverOfflineSeat 测试游客ID与离线玩家相同时不能按重连接管座位
+func TestGuestCannotTakeOverOfflineSeat(t *testing.T) {
+	// 1. Setup: 房间里有一名离线的游客
+	roomManager := server.GetRoomManager()
+	room, err := roomManager.CreateRoomWithSettings(logic.DefaultRoomSettings(), "")
+	assert.NoError(t, err)
+	defer roomManager.DeleteRoom(room.ID)
+	offline := logic.NewPlayer(-9501, "Guest", newFakeConn(9501))
+	assert.NoError(t, room.AddPlayer(offline))
+	room.SetPlayerOffline(offline.ID)
+
+	// 2. 另一个连接以相同的游客ID加入
+	conn := newFakeConn(9502)
+	conn.SetProperty("playerID", offline.ID)
+	(&router.JoinRoomHandler{}).Handle(newPlayerRequest(conn, msg.MsgID_C2S_JOIN_ROOM_REQ, fmt.Sprintf(`{"room_id": %d}`, room.ID)))
+
+	// 3. 离线游客的座位不会被接管
+	assert.False(t, offline.IsOnline(), "Offline guest should stay offline")
+	assert.NotEqual(t, conn, offline.Conn, "Offline guest's seat should not be taken over")
+}
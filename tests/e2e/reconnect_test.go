@@ -1,31 +1,75 @@
 package e2e
 
-import (
-	"testing"
-	"time"
-)
-
 // 这是一个 E2E 测试的占位符。
 // 完整的 E2E 测试需要模拟一个 TCP 客户端，连接到服务器，断开连接，然后重新连接，并验证服务器状态同步。
 // 这超出了当前代码生成任务的范围，但在此处创建文件以符合项目章程。
 
 import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/aceld/zinx/ziface"
 	"github.com/aceld/zinx/znet"
+	"github.com/aceld/zinx/zpack"
 	"github.com/stretchr/testify/assert"
-	"testing"
-	"time"
 	"xizexcample/internal/logic"
+	"xizexcample/internal/msg"
 	"xizexcample/internal/router"
 	"xizexcample/internal/server"
 )
 
+// fakeConn 是一个最小化的连接桩，只实现处理器用到的方法
+type fakeConn struct {
+	ziface.IConnection
+	connID   uint64
+	mu       sync.Mutex
+	property map[string]interface{}
+	sent     []uint32
+}
+
+func newFakeConn(connID uint64) *fakeConn {
+	return &fakeConn{connID: connID, property: make(map[string]interface{})}
+}
+
+func (c *fakeConn) GetConnID() uint64 { return c.connID }
+
+func (c *fakeConn) SetProperty(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.property[key] = value
+}
+
+func (c *fakeConn) GetProperty(key string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.property[key]; ok {
+		return v, nil
+	}
+	return nil, errors.New("no property found")
+}
+
+func (c *fakeConn) RemoveProperty(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.property, key)
+}
+
+func (c *fakeConn) SendMsg(msgID uint32, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, msgID)
+	return nil
+}
+
 // TestDisconnectAndReconnect 测试玩家断线重连流程
 func TestDisconnectAndReconnect(t *testing.T) {
 	// 1. Setup
 	roomManager := server.GetRoomManager()
 	room, _ := roomManager.CreateRoom(101)
-	player := logic.NewPlayer(123, "TestPlayer", &znet.Connection{})
+	player := logic.NewPlayer(123, "TestPlayer", newFakeConn(123))
 	room.AddPlayer(player)
+	roomManager.RegisterPlayer(player.ID, room.ID)
 
 	// 2. Simulate Disconnect
 	room.SetPlayerOffline(player.ID)
@@ -34,11 +78,8 @@ func TestDisconnectAndReconnect(t *testing.T) {
 
 	// 3. Simulate Reconnect
 	// Create a mock request for the JoinRoom handler
-	mockConn := &znet.Connection{} // In a real test, this would be a real connection
-	mockRequest := &znet.Request{
-		Conn: mockConn,
-		Msg:  znet.NewMessage(uint32(msg.C2S_JOIN_ROOM_REQ), []byte(`{"room_id": 101}`)),
-	}
+	mockConn := newFakeConn(uint64(player.ID))
+	mockRequest := znet.NewRequest(mockConn, zpack.NewMsgPackage(uint32(msg.MsgID_C2S_JOIN_ROOM_REQ), []byte(`{"room_id": 101}`)))
 	mockConn.SetProperty("playerID", player.ID) // Simulate middleware setting playerID
 
 	// Manually call the handler to simulate receiving a request